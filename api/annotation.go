@@ -0,0 +1,129 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package api // import "miniflux.app/api"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/json"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func (h *handler) getEntryAnnotations(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+
+	if !h.store.EntryIDExists(userID, entryID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	annotations, err := h.store.Annotations(userID, entryID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, annotations)
+}
+
+func (h *handler) createAnnotation(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+
+	if !h.store.EntryIDExists(userID, entryID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	var annotationRequest model.AnnotationRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&annotationRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateAnnotationRequest(&annotationRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	annotation, err := h.store.CreateAnnotation(userID, entryID, &annotationRequest)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.Created(w, r, annotation)
+}
+
+func (h *handler) getAnnotation(w http.ResponseWriter, r *http.Request) {
+	annotation, err := h.store.Annotation(request.UserID(r), request.RouteInt64Param(r, "annotationID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if annotation == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	json.OK(w, r, annotation)
+}
+
+func (h *handler) updateAnnotation(w http.ResponseWriter, r *http.Request) {
+	annotation, err := h.store.Annotation(request.UserID(r), request.RouteInt64Param(r, "annotationID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if annotation == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	var annotationRequest model.AnnotationRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&annotationRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateAnnotationRequest(&annotationRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	annotationRequest.Patch(annotation)
+	if err := h.store.UpdateAnnotation(annotation); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.Created(w, r, annotation)
+}
+
+func (h *handler) removeAnnotation(w http.ResponseWriter, r *http.Request) {
+	annotation, err := h.store.Annotation(request.UserID(r), request.RouteInt64Param(r, "annotationID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if annotation == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.RemoveAnnotation(annotation); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
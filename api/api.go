@@ -35,6 +35,8 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	sr.HandleFunc("/users/{userID:[0-9]+}", handler.updateUser).Methods(http.MethodPut)
 	sr.HandleFunc("/users/{userID:[0-9]+}", handler.removeUser).Methods(http.MethodDelete)
 	sr.HandleFunc("/users/{userID:[0-9]+}/mark-all-as-read", handler.markUserAsRead).Methods(http.MethodPut)
+	sr.HandleFunc("/users/{userID:[0-9]+}/export", handler.exportUser).Methods(http.MethodGet)
+	sr.HandleFunc("/users/{userID:[0-9]+}/import", handler.importUser).Methods(http.MethodPost)
 	sr.HandleFunc("/users/{username}", handler.userByUsername).Methods(http.MethodGet)
 	sr.HandleFunc("/me", handler.currentUser).Methods(http.MethodGet)
 	sr.HandleFunc("/categories", handler.createCategory).Methods(http.MethodPost)
@@ -56,6 +58,7 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	sr.HandleFunc("/feeds/{feedID}", handler.updateFeed).Methods(http.MethodPut)
 	sr.HandleFunc("/feeds/{feedID}", handler.removeFeed).Methods(http.MethodDelete)
 	sr.HandleFunc("/feeds/{feedID}/icon", handler.feedIcon).Methods(http.MethodGet)
+	sr.HandleFunc("/feeds/{feedID}/history", handler.getFeedHistory).Methods(http.MethodGet)
 	sr.HandleFunc("/feeds/{feedID}/mark-all-as-read", handler.markFeedAsRead).Methods(http.MethodPut)
 	sr.HandleFunc("/export", handler.exportFeeds).Methods(http.MethodGet)
 	sr.HandleFunc("/import", handler.importFeeds).Methods(http.MethodPost)
@@ -66,4 +69,22 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	sr.HandleFunc("/entries/{entryID}", handler.getEntry).Methods(http.MethodGet)
 	sr.HandleFunc("/entries/{entryID}/bookmark", handler.toggleBookmark).Methods(http.MethodPut)
 	sr.HandleFunc("/entries/{entryID}/fetch-content", handler.fetchContent).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/{entryID}/tags", handler.getEntryTags).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/{entryID}/tags", handler.updateEntryTags).Methods(http.MethodPut)
+	sr.HandleFunc("/entries/{entryID}/annotations", handler.getEntryAnnotations).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/{entryID}/annotations", handler.createAnnotation).Methods(http.MethodPost)
+	sr.HandleFunc("/annotations/{annotationID}", handler.getAnnotation).Methods(http.MethodGet)
+	sr.HandleFunc("/annotations/{annotationID}", handler.updateAnnotation).Methods(http.MethodPut)
+	sr.HandleFunc("/annotations/{annotationID}", handler.removeAnnotation).Methods(http.MethodDelete)
+	sr.HandleFunc("/enclosures/{enclosureID}", handler.getEnclosure).Methods(http.MethodGet)
+	sr.HandleFunc("/enclosures/{enclosureID}", handler.updateEnclosure).Methods(http.MethodPut)
+	sr.HandleFunc("/tags", handler.getTags).Methods(http.MethodGet)
+	sr.HandleFunc("/saved-searches", handler.getSavedSearches).Methods(http.MethodGet)
+	sr.HandleFunc("/saved-searches", handler.createSavedSearch).Methods(http.MethodPost)
+	sr.HandleFunc("/saved-searches/{searchID}", handler.getSavedSearch).Methods(http.MethodGet)
+	sr.HandleFunc("/saved-searches/{searchID}", handler.updateSavedSearch).Methods(http.MethodPut)
+	sr.HandleFunc("/saved-searches/{searchID}", handler.removeSavedSearch).Methods(http.MethodDelete)
+	sr.HandleFunc("/saved-searches/{searchID}/mark-all-as-read", handler.markSavedSearchAsRead).Methods(http.MethodPut)
+	sr.HandleFunc("/saved-searches/{searchID}/entries", handler.getSavedSearchEntries).Methods(http.MethodGet)
+	sr.HandleFunc("/events", handler.streamEvents).Methods(http.MethodGet)
 }
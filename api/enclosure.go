@@ -0,0 +1,62 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package api // import "miniflux.app/api"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/json"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func (h *handler) getEnclosure(w http.ResponseWriter, r *http.Request) {
+	enclosure, err := h.store.GetEnclosure(request.UserID(r), request.RouteInt64Param(r, "enclosureID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if enclosure == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	json.OK(w, r, enclosure)
+}
+
+func (h *handler) updateEnclosure(w http.ResponseWriter, r *http.Request) {
+	enclosure, err := h.store.GetEnclosure(request.UserID(r), request.RouteInt64Param(r, "enclosureID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if enclosure == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	var enclosureUpdateRequest model.EnclosureUpdateRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&enclosureUpdateRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if err := validator.ValidateEnclosureUpdateRequest(&enclosureUpdateRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	enclosureUpdateRequest.Patch(enclosure)
+	if err := h.store.UpdateEnclosurePlayback(enclosure); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, enclosure)
+}
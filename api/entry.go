@@ -35,11 +35,13 @@ func (h *handler) getEntryFromBuilder(w http.ResponseWriter, r *http.Request, b
 		return
 	}
 
-	entry.Content = proxy.AbsoluteImageProxyRewriter(h.router, r.Host, entry.Content)
+	entry.Content = proxy.AbsoluteEntryImageProxyRewriter(h.router, r.Host, entry)
 	proxyImage := config.Opts.ProxyImages()
 
 	for i := range entry.Enclosures {
-		if strings.HasPrefix(entry.Enclosures[i].MimeType, "image/") && (proxyImage == "all" || proxyImage != "none" && !url.IsHTTPS(entry.Enclosures[i].URL)) {
+		if entry.Feed.IsNewsletter() || (entry.Feed.CacheMedia && config.Opts.HasMediaCacheEnclosures()) {
+			entry.Enclosures[i].URL = proxy.AbsoluteProxifyCachedURL(h.router, r.Host, entry.ID, entry.Enclosures[i].URL)
+		} else if strings.HasPrefix(entry.Enclosures[i].MimeType, "image/") && (proxyImage == "all" || proxyImage != "none" && !url.IsHTTPS(entry.Enclosures[i].URL)) {
 			entry.Enclosures[i].URL = proxy.AbsoluteProxifyURL(h.router, r.Host, entry.Enclosures[i].URL)
 		}
 	}
@@ -79,19 +81,19 @@ func (h *handler) getEntry(w http.ResponseWriter, r *http.Request) {
 
 func (h *handler) getFeedEntries(w http.ResponseWriter, r *http.Request) {
 	feedID := request.RouteInt64Param(r, "feedID")
-	h.findEntries(w, r, feedID, 0)
+	h.findEntries(w, r, feedID, 0, nil)
 }
 
 func (h *handler) getCategoryEntries(w http.ResponseWriter, r *http.Request) {
 	categoryID := request.RouteInt64Param(r, "categoryID")
-	h.findEntries(w, r, 0, categoryID)
+	h.findEntries(w, r, 0, categoryID, nil)
 }
 
 func (h *handler) getEntries(w http.ResponseWriter, r *http.Request) {
-	h.findEntries(w, r, 0, 0)
+	h.findEntries(w, r, 0, 0, nil)
 }
 
-func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int64, categoryID int64) {
+func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int64, categoryID int64, search *model.SavedSearch) {
 	statuses := request.QueryStringParamList(r, "status")
 	for _, status := range statuses {
 		if err := validator.ValidateEntryStatus(status); err != nil {
@@ -119,6 +121,11 @@ func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int
 		return
 	}
 
+	if err := validator.ValidateSearchQuery(request.QueryStringParam(r, "search", "")); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
 	userID := request.UserID(r)
 	categoryID = request.QueryInt64Param(r, "category_id", categoryID)
 	if categoryID > 0 && !h.store.CategoryIDExists(userID, categoryID) {
@@ -133,6 +140,9 @@ func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int
 	}
 
 	builder := h.store.NewEntryQueryBuilder(userID)
+	if search != nil {
+		builder.WithSavedSearch(search)
+	}
 	builder.WithFeedID(feedID)
 	builder.WithCategoryID(categoryID)
 	builder.WithStatuses(statuses)
@@ -155,7 +165,7 @@ func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int
 	}
 
 	for i := range entries {
-		entries[i].Content = proxy.AbsoluteImageProxyRewriter(h.router, r.Host, entries[i].Content)
+		entries[i].Content = proxy.AbsoluteEntryImageProxyRewriter(h.router, r.Host, entries[i])
 	}
 
 	json.OK(w, r, &entriesResponse{Total: count, Entries: entries})
@@ -275,6 +285,11 @@ func configureFilters(builder *storage.EntryQueryBuilder, r *http.Request) {
 		}
 	}
 
+	tags := request.QueryStringParamList(r, "tag")
+	if len(tags) > 0 {
+		builder.WithTags(tags)
+	}
+
 	searchQuery := request.QueryStringParam(r, "search", "")
 	if searchQuery != "" {
 		builder.WithSearchQuery(searchQuery)
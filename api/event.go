@@ -0,0 +1,16 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package api // import "miniflux.app/api"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/sse"
+)
+
+func (h *handler) streamEvents(w http.ResponseWriter, r *http.Request) {
+	sse.Stream(w, r, h.store.Events(), request.UserID(r))
+}
@@ -196,6 +196,23 @@ func (h *handler) getFeed(w http.ResponseWriter, r *http.Request) {
 	json.OK(w, r, feed)
 }
 
+func (h *handler) getFeedHistory(w http.ResponseWriter, r *http.Request) {
+	feedID := request.RouteInt64Param(r, "feedID")
+
+	if !h.store.FeedExists(request.UserID(r), feedID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	refreshes, err := h.store.FeedRefreshes(feedID, request.QueryIntParam(r, "limit", 100))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, refreshes)
+}
+
 func (h *handler) removeFeed(w http.ResponseWriter, r *http.Request) {
 	feedID := request.RouteInt64Param(r, "feedID")
 	userID := request.UserID(r)
@@ -8,6 +8,7 @@ import (
 	"context"
 	"net/http"
 
+	"miniflux.app/config"
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/json"
 	"miniflux.app/logger"
@@ -59,6 +60,12 @@ func (m *middleware) apiKeyAuth(next http.Handler) http.Handler {
 			return
 		}
 
+		if len(config.Opts.AuthRequiredGroups()) > 0 && m.store.IsDeniedByGroups(user.ID) {
+			logger.Error("[API][TokenAuth] [ClientIP=%s] User not member of the required groups: %s", clientIP, user.Username)
+			json.Forbidden(w, r)
+			return
+		}
+
 		logger.Info("[API][TokenAuth] [ClientIP=%s] User authenticated: %s", clientIP, user.Username)
 		m.store.SetLastLogin(user.ID)
 		m.store.SetAPIKeyUsedTimestamp(user.ID, token)
@@ -115,6 +122,19 @@ func (m *middleware) basicAuth(next http.Handler) http.Handler {
 			return
 		}
 
+		if len(config.Opts.AuthRequiredGroups()) > 0 && m.store.IsDeniedByGroups(user.ID) {
+			logger.Error("[API][BasicAuth] [ClientIP=%s] User not member of the required groups: %s", clientIP, username)
+			json.Forbidden(w, r)
+			return
+		}
+
+		// The password alone is not enough when two-factor authentication is enabled, API keys must be used instead.
+		if m.store.HasTwoFactor(user.ID) {
+			logger.Error("[API][BasicAuth] [ClientIP=%s] Two-factor authentication enabled, an API key is required: %s", clientIP, username)
+			json.Unauthorized(w, r)
+			return
+		}
+
 		logger.Info("[API][BasicAuth] [ClientIP=%s] User authenticated: %s", clientIP, username)
 		m.store.SetLastLogin(user.ID)
 
@@ -0,0 +1,160 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package api // import "miniflux.app/api"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+	"time"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/json"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func (h *handler) getSavedSearches(w http.ResponseWriter, r *http.Request) {
+	searches, err := h.store.SavedSearchesWithUnreadCount(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, searches)
+}
+
+func (h *handler) getSavedSearch(w http.ResponseWriter, r *http.Request) {
+	search, err := h.store.SavedSearch(request.UserID(r), request.RouteInt64Param(r, "searchID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if search == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if search.TotalUnread, err = h.store.CountSavedSearchUnreadEntries(search); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, search)
+}
+
+func (h *handler) createSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	var searchRequest model.SavedSearchRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&searchRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateSavedSearchCreation(h.store, userID, &searchRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	search, err := h.store.CreateSavedSearch(userID, &searchRequest)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.Created(w, r, search)
+}
+
+func (h *handler) updateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	search, err := h.store.SavedSearch(userID, request.RouteInt64Param(r, "searchID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if search == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	var searchRequest model.SavedSearchRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&searchRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateSavedSearchModification(h.store, userID, search.ID, &searchRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	searchRequest.Patch(search)
+	if err := h.store.UpdateSavedSearch(search); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.Created(w, r, search)
+}
+
+func (h *handler) removeSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	search, err := h.store.SavedSearch(userID, request.RouteInt64Param(r, "searchID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if search == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.RemoveSavedSearch(userID, search.ID); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
+func (h *handler) markSavedSearchAsRead(w http.ResponseWriter, r *http.Request) {
+	search, err := h.store.SavedSearch(request.UserID(r), request.RouteInt64Param(r, "searchID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if search == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.MarkSavedSearchAsRead(search, time.Now()); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
+func (h *handler) getSavedSearchEntries(w http.ResponseWriter, r *http.Request) {
+	search, err := h.store.SavedSearch(request.UserID(r), request.RouteInt64Param(r, "searchID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if search == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	h.findEntries(w, r, 0, 0, search)
+}
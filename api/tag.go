@@ -0,0 +1,77 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package api // import "miniflux.app/api"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/json"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func (h *handler) getTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.store.Tags(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, tags)
+}
+
+func (h *handler) getEntryTags(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+
+	if !h.store.EntryIDExists(userID, entryID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	tags, err := h.store.EntryTags(userID, entryID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, &model.EntryTagsRequest{Tags: tags})
+}
+
+func (h *handler) updateEntryTags(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+
+	if !h.store.EntryIDExists(userID, entryID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	var entryTagsRequest model.EntryTagsRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&entryTagsRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if err := validator.ValidateEntryTagsRequest(&entryTagsRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if err := h.store.SetEntryTags(userID, entryID, entryTagsRequest.Tags); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	tags, err := h.store.EntryTags(userID, entryID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, &model.EntryTagsRequest{Tags: tags})
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package archive // import "miniflux.app/archive"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"miniflux.app/model"
+)
+
+// Version is the version of the archive format.
+const Version = 1
+
+// Archive contains all the data of a user account.
+//
+// Entries carry their enclosures with the playback state, their annotations and their share code and comment.
+// The notification targets are part of the integration settings.
+// Sessions, passkeys, two-factor secrets and the Google Reader password are never exported.
+type Archive struct {
+	Version           int                `json:"version"`
+	CreatedAt         time.Time          `json:"created_at"`
+	User              *model.User        `json:"user"`
+	Categories        model.Categories   `json:"categories"`
+	Feeds             model.Feeds        `json:"feeds"`
+	Integration       *model.Integration `json:"integration"`
+	APIKeys           []*APIKey          `json:"api_keys"`
+	SavedSearches     []*SavedSearch     `json:"saved_searches"`
+	FeedOutputs       []*FeedOutput      `json:"feed_outputs"`
+	SharedEntriesCode string             `json:"shared_entries_code"`
+}
+
+// APIKey represents an API key in the archive.
+type APIKey struct {
+	Token       string `json:"token"`
+	Description string `json:"description"`
+}
+
+// SavedSearch represents a saved search in the archive, the category and the feed are referenced by title and URL.
+type SavedSearch struct {
+	Title         string `json:"title"`
+	Query         string `json:"query"`
+	CategoryTitle string `json:"category_title,omitempty"`
+	FeedURL       string `json:"feed_url,omitempty"`
+	Status        string `json:"status,omitempty"`
+}
+
+// FeedOutput represents a feed output in the archive, the category and the saved search are referenced by title.
+type FeedOutput struct {
+	Kind             string `json:"kind"`
+	CategoryTitle    string `json:"category_title,omitempty"`
+	SavedSearchTitle string `json:"saved_search_title,omitempty"`
+	Token            string `json:"token"`
+}
+
+// Parse reads an archive.
+func Parse(data io.Reader) (*Archive, error) {
+	var archive Archive
+	if err := json.NewDecoder(data).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("archive: unable to parse JSON document: %v", err)
+	}
+
+	if archive.Version != Version {
+		return nil, fmt.Errorf("archive: unsupported version %d", archive.Version)
+	}
+
+	if archive.User == nil {
+		return nil, fmt.Errorf("archive: the user settings are missing")
+	}
+
+	return &archive, nil
+}
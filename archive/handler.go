@@ -0,0 +1,566 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package archive // import "miniflux.app/archive"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"miniflux.app/crypto"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+	"miniflux.app/validator"
+)
+
+// Handler handles the logic for account export/import.
+type Handler struct {
+	store *storage.Storage
+}
+
+// Export returns all the data of the given user.
+func (h *Handler) Export(userID int64) (*Archive, error) {
+	user, err := h.store.UserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, errors.New("archive: user not found")
+	}
+
+	categories, err := h.store.Categories(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	feeds, err := h.store.Feeds(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithOrder("e.id")
+	builder.WithDirection(model.DefaultSortingDirection)
+	entries, err := builder.GetEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	enclosures, err := h.store.UserEnclosures(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations, err := h.store.UserAnnotations(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	feedsByID := make(map[int64]*model.Feed, len(feeds))
+	for _, feed := range feeds {
+		feed.Entries = make(model.Entries, 0)
+		feedsByID[feed.ID] = feed
+	}
+
+	for _, entry := range entries {
+		feed, found := feedsByID[entry.FeedID]
+		if !found {
+			continue
+		}
+
+		entry.Feed = nil
+		entry.Enclosures = enclosures[entry.ID]
+		entry.Annotations = annotations[entry.ID]
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	integration, err := h.store.Integration(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Google Reader password is stored as a hash that cannot be imported back.
+	integration.GoogleReaderPassword = ""
+
+	apiKeys, err := h.store.APIKeys(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	searches, err := h.store.SavedSearches(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := h.store.FeedOutputs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedEntriesCode, err := h.store.SharedEntriesCode(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &Archive{
+		Version:           Version,
+		CreatedAt:         time.Now(),
+		User:              user,
+		Categories:        categories,
+		Feeds:             feeds,
+		Integration:       integration,
+		APIKeys:           make([]*APIKey, 0, len(apiKeys)),
+		SavedSearches:     make([]*SavedSearch, 0, len(searches)),
+		FeedOutputs:       make([]*FeedOutput, 0, len(outputs)),
+		SharedEntriesCode: sharedEntriesCode,
+	}
+
+	for _, apiKey := range apiKeys {
+		archive.APIKeys = append(archive.APIKeys, &APIKey{Token: apiKey.Token, Description: apiKey.Description})
+	}
+
+	categoryTitles := make(map[int64]string, len(categories))
+	for _, category := range categories {
+		categoryTitles[category.ID] = category.Title
+	}
+
+	searchTitles := make(map[int64]string, len(searches))
+	for _, search := range searches {
+		searchTitles[search.ID] = search.Title
+
+		archivedSearch := &SavedSearch{
+			Title:         search.Title,
+			Query:         search.Query,
+			CategoryTitle: categoryTitles[search.CategoryID],
+			Status:        search.Status,
+		}
+
+		if feed, found := feedsByID[search.FeedID]; found {
+			archivedSearch.FeedURL = feed.FeedURL
+		}
+
+		archive.SavedSearches = append(archive.SavedSearches, archivedSearch)
+	}
+
+	for _, output := range outputs {
+		archive.FeedOutputs = append(archive.FeedOutputs, &FeedOutput{
+			Kind:             output.Kind,
+			CategoryTitle:    categoryTitles[output.CategoryID],
+			SavedSearchTitle: searchTitles[output.SavedSearchID],
+			Token:            output.Token,
+		})
+	}
+
+	return archive, nil
+}
+
+// Validate checks that the archive can be imported into the given user account, nothing is modified.
+func (h *Handler) Validate(userID int64, archive *Archive) error {
+	user, err := h.store.UserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return errors.New("archive: user not found")
+	}
+
+	if validationErr := validator.ValidateUserModification(h.store, userID, settingsModification(archive.User)); validationErr != nil {
+		return fmt.Errorf("archive: invalid user settings: %v", validationErr.Error())
+	}
+
+	for _, feed := range archive.Feeds {
+		if feed.FeedURL == "" {
+			return fmt.Errorf("archive: the feed %q has no URL", feed.Title)
+		}
+	}
+
+	if integration := archive.Integration; integration != nil {
+		if integration.FeverUsername != "" && h.store.HasDuplicateFeverUsername(userID, integration.FeverUsername) {
+			return fmt.Errorf("archive: the Fever username %q is already used by another user", integration.FeverUsername)
+		}
+
+		if integration.GoogleReaderUsername != "" && h.store.HasDuplicateGoogleReaderUsername(userID, integration.GoogleReaderUsername) {
+			return fmt.Errorf("archive: the Google Reader username %q is already used by another user", integration.GoogleReaderUsername)
+		}
+	}
+
+	// The category and the feed of the saved searches may not exist yet, they are checked during the import.
+	for _, search := range archive.SavedSearches {
+		request := &model.SavedSearchRequest{Title: search.Title, Query: search.Query, Status: search.Status}
+
+		existingSearch, err := h.store.SavedSearchByTitle(userID, search.Title)
+		if err != nil {
+			return err
+		}
+
+		var validationErr *validator.ValidationError
+		if existingSearch == nil {
+			validationErr = validator.ValidateSavedSearchCreation(h.store, userID, request)
+		} else {
+			validationErr = validator.ValidateSavedSearchModification(h.store, userID, existingSearch.ID, request)
+		}
+
+		if validationErr != nil {
+			return fmt.Errorf("archive: invalid saved search %q: %v", search.Title, validationErr.Error())
+		}
+	}
+
+	return nil
+}
+
+// Import restores an archive into the given user account.
+//
+// The whole archive is validated before any modification. Categories, feeds and entries that already exist
+// are updated instead of being duplicated: importing the same archive several times gives the same result,
+// an import interrupted by a database error is completed by running it again.
+func (h *Handler) Import(userID int64, archive *Archive) error {
+	if err := h.Validate(userID, archive); err != nil {
+		return err
+	}
+
+	if err := h.importSettings(userID, archive.User); err != nil {
+		return err
+	}
+
+	categories, err := h.importCategories(userID, archive.Categories)
+	if err != nil {
+		return err
+	}
+
+	if err := h.importFeeds(userID, categories, archive.Feeds); err != nil {
+		return err
+	}
+
+	if archive.Integration != nil {
+		if err := h.importIntegration(userID, archive.Integration); err != nil {
+			return err
+		}
+	}
+
+	for _, apiKey := range archive.APIKeys {
+		if h.store.APIKeyTokenExists(apiKey.Token) || h.store.APIKeyExists(userID, apiKey.Description) {
+			continue
+		}
+
+		if err := h.store.CreateAPIKey(&model.APIKey{UserID: userID, Token: apiKey.Token, Description: apiKey.Description}); err != nil {
+			return err
+		}
+	}
+
+	searches, err := h.importSavedSearches(userID, categories, archive.SavedSearches)
+	if err != nil {
+		return err
+	}
+
+	if err := h.importFeedOutputs(userID, categories, searches, archive.FeedOutputs); err != nil {
+		return err
+	}
+
+	return h.importSharedEntriesCode(userID, archive.SharedEntriesCode)
+}
+
+func settingsModification(settings *model.User) *model.UserModificationRequest {
+	return &model.UserModificationRequest{
+		Theme:                  &settings.Theme,
+		Language:               &settings.Language,
+		Timezone:               &settings.Timezone,
+		EntryDirection:         &settings.EntryDirection,
+		EntryOrder:             &settings.EntryOrder,
+		Stylesheet:             &settings.Stylesheet,
+		EntriesPerPage:         &settings.EntriesPerPage,
+		KeyboardShortcuts:      &settings.KeyboardShortcuts,
+		ShowReadingTime:        &settings.ShowReadingTime,
+		EntrySwipe:             &settings.EntrySwipe,
+		DoubleTap:              &settings.DoubleTap,
+		DisplayMode:            &settings.DisplayMode,
+		DefaultReadingSpeed:    &settings.DefaultReadingSpeed,
+		CJKReadingSpeed:        &settings.CJKReadingSpeed,
+		DefaultHomePage:        &settings.DefaultHomePage,
+		CategoriesSortingOrder: &settings.CategoriesSortingOrder,
+		FilterRules:            &settings.FilterRules,
+	}
+}
+
+func (h *Handler) importSettings(userID int64, settings *model.User) error {
+	user, err := h.store.UserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return errors.New("archive: user not found")
+	}
+
+	settingsModification(settings).Patch(user)
+	return h.store.UpdateUser(user)
+}
+
+// importCategories returns the categories of the user indexed by title.
+func (h *Handler) importCategories(userID int64, categories model.Categories) (map[string]*model.Category, error) {
+	for _, category := range categories {
+		existingCategory, err := h.store.CategoryByTitle(userID, category.Title)
+		if err != nil {
+			return nil, err
+		}
+
+		if existingCategory == nil {
+			existingCategory, err = h.store.CreateCategory(userID, &model.CategoryRequest{Title: category.Title})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		existingCategory.HideGlobally = category.HideGlobally
+		existingCategory.Notify = category.Notify
+		existingCategory.ExcludeFromDigest = category.ExcludeFromDigest
+		if err := h.store.UpdateCategory(existingCategory); err != nil {
+			return nil, err
+		}
+	}
+
+	userCategories, err := h.store.Categories(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	categoriesByTitle := make(map[string]*model.Category, len(userCategories))
+	for _, category := range userCategories {
+		categoriesByTitle[category.Title] = category
+	}
+
+	return categoriesByTitle, nil
+}
+
+func (h *Handler) importFeeds(userID int64, categories map[string]*model.Category, feeds model.Feeds) error {
+	userFeeds, err := h.store.Feeds(userID)
+	if err != nil {
+		return err
+	}
+
+	feedsByURL := make(map[string]*model.Feed, len(userFeeds))
+	for _, feed := range userFeeds {
+		feedsByURL[feed.FeedURL] = feed
+	}
+
+	for _, feed := range feeds {
+		var category *model.Category
+		if feed.Category != nil {
+			category = categories[feed.Category.Title]
+		}
+
+		if category == nil {
+			category, err = h.store.FirstCategory(userID)
+			if err != nil {
+				return err
+			}
+		}
+
+		entries := feed.Entries
+		feed.Entries = nil
+
+		if existingFeed, found := feedsByURL[feed.FeedURL]; found {
+			existingFeed.SiteURL = feed.SiteURL
+			existingFeed.Title = feed.Title
+			existingFeed.Category = category
+			existingFeed.ScraperRules = feed.ScraperRules
+			existingFeed.RewriteRules = feed.RewriteRules
+			existingFeed.BlocklistRules = feed.BlocklistRules
+			existingFeed.KeeplistRules = feed.KeeplistRules
+			existingFeed.UrlRewriteRules = feed.UrlRewriteRules
+			existingFeed.Crawler = feed.Crawler
+			existingFeed.UserAgent = feed.UserAgent
+			existingFeed.Cookie = feed.Cookie
+			existingFeed.Username = feed.Username
+			existingFeed.Password = feed.Password
+			existingFeed.Disabled = feed.Disabled
+			existingFeed.IgnoreHTTPCache = feed.IgnoreHTTPCache
+			existingFeed.AllowSelfSignedCertificates = feed.AllowSelfSignedCertificates
+			existingFeed.FetchViaProxy = feed.FetchViaProxy
+			existingFeed.HideGlobally = feed.HideGlobally
+			existingFeed.CacheMedia = feed.CacheMedia
+			existingFeed.Notify = feed.Notify
+			existingFeed.ItemSelector = feed.ItemSelector
+			existingFeed.TitleSelector = feed.TitleSelector
+			existingFeed.LinkSelector = feed.LinkSelector
+			existingFeed.DateSelector = feed.DateSelector
+			existingFeed.ContentSelector = feed.ContentSelector
+
+			if err := h.store.UpdateFeed(existingFeed); err != nil {
+				return err
+			}
+
+			feed = existingFeed
+		} else {
+			feed.ID = 0
+			feed.UserID = userID
+			feed.Category = category
+			feed.Icon = nil
+
+			// The token of the newsletter is not exported, the imported newsletter receives a new address.
+			if strings.HasPrefix(feed.FeedURL, model.NewsletterURLPrefix) {
+				feed.NewsletterToken = crypto.GenerateRandomStringHex(16)
+			}
+
+			if err := h.store.CreateFeed(feed); err != nil {
+				return err
+			}
+
+			feedsByURL[feed.FeedURL] = feed
+		}
+
+		if err := h.store.ImportFeedEntries(userID, feed.ID, entries); err != nil {
+			return err
+		}
+
+		logger.Debug("[Archive:Import] Feed %q imported for user #%d with %d entries", feed.FeedURL, userID, len(entries))
+	}
+
+	return nil
+}
+
+func (h *Handler) importIntegration(userID int64, integration *model.Integration) error {
+	// The Google Reader password is not part of the archive, it has to be defined again.
+	integration.UserID = userID
+	integration.GoogleReaderPassword = ""
+
+	return h.store.UpdateIntegration(integration)
+}
+
+// importSavedSearches returns the saved searches of the user indexed by title.
+func (h *Handler) importSavedSearches(userID int64, categories map[string]*model.Category, searches []*SavedSearch) (map[string]*model.SavedSearch, error) {
+	userFeeds, err := h.store.Feeds(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	feedsByURL := make(map[string]*model.Feed, len(userFeeds))
+	for _, feed := range userFeeds {
+		feedsByURL[feed.FeedURL] = feed
+	}
+
+	for _, search := range searches {
+		request := &model.SavedSearchRequest{
+			Title:  search.Title,
+			Query:  search.Query,
+			Status: search.Status,
+		}
+
+		if category, found := categories[search.CategoryTitle]; found {
+			request.CategoryID = category.ID
+		}
+
+		if feed, found := feedsByURL[search.FeedURL]; found {
+			request.FeedID = feed.ID
+		}
+
+		existingSearch, err := h.store.SavedSearchByTitle(userID, search.Title)
+		if err != nil {
+			return nil, err
+		}
+
+		if existingSearch == nil {
+			if validationErr := validator.ValidateSavedSearchCreation(h.store, userID, request); validationErr != nil {
+				return nil, fmt.Errorf("archive: invalid saved search %q: %v", search.Title, validationErr.Error())
+			}
+
+			if _, err := h.store.CreateSavedSearch(userID, request); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if validationErr := validator.ValidateSavedSearchModification(h.store, userID, existingSearch.ID, request); validationErr != nil {
+			return nil, fmt.Errorf("archive: invalid saved search %q: %v", search.Title, validationErr.Error())
+		}
+
+		request.Patch(existingSearch)
+		if err := h.store.UpdateSavedSearch(existingSearch); err != nil {
+			return nil, err
+		}
+	}
+
+	userSearches, err := h.store.SavedSearches(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	searchesByTitle := make(map[string]*model.SavedSearch, len(userSearches))
+	for _, search := range userSearches {
+		searchesByTitle[search.Title] = search
+	}
+
+	return searchesByTitle, nil
+}
+
+func (h *Handler) importFeedOutputs(userID int64, categories map[string]*model.Category, searches map[string]*model.SavedSearch, outputs []*FeedOutput) error {
+	for _, output := range outputs {
+		var targetID int64
+		switch output.Kind {
+		case model.FeedOutputStarred:
+		case model.FeedOutputCategory:
+			category, found := categories[output.CategoryTitle]
+			if !found {
+				continue
+			}
+			targetID = category.ID
+		case model.FeedOutputSavedSearch:
+			search, found := searches[output.SavedSearchTitle]
+			if !found {
+				continue
+			}
+			targetID = search.ID
+		default:
+			continue
+		}
+
+		if h.store.FeedOutputExists(userID, output.Kind, targetID) {
+			continue
+		}
+
+		// The token is kept to avoid breaking the subscriptions, unless it is already used on this instance.
+		feedOutput := model.NewFeedOutput(userID, output.Kind, targetID)
+		existingOutput, err := h.store.FeedOutputByToken(output.Token)
+		if err != nil {
+			return err
+		}
+
+		if output.Token != "" && existingOutput == nil {
+			feedOutput.Token = output.Token
+		}
+
+		if err := h.store.CreateFeedOutput(feedOutput); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) importSharedEntriesCode(userID int64, code string) error {
+	if code == "" {
+		return nil
+	}
+
+	ownerID, err := h.store.UserIDBySharedEntriesCode(code)
+	if err != nil {
+		return err
+	}
+
+	// The public page keeps its address, unless another user already published a page with the same code.
+	if ownerID != 0 {
+		return nil
+	}
+
+	return h.store.SetSharedEntriesCode(userID, code)
+}
+
+// NewHandler creates a new handler for account archives.
+func NewHandler(store *storage.Storage) *Handler {
+	return &Handler{store: store}
+}
@@ -0,0 +1,189 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package archive // import "miniflux.app/archive"
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/database"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+)
+
+func newTestStorage(t *testing.T) *storage.Storage {
+	db, err := database.NewConnectionPool("sqlite://"+filepath.Join(t.TempDir(), "miniflux.db"), 1, 1, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := database.Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+
+	return storage.NewStorage(db)
+}
+
+func createTestUser(t *testing.T, store *storage.Storage, username string) *model.User {
+	user, err := store.CreateUser(&model.UserCreationRequest{Username: username, Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return user
+}
+
+// exportTestUser returns the archive of a user who has a category, a feed with an entry and a newsletter.
+func exportTestUser(t *testing.T, store *storage.Storage, handler *Handler) *Archive {
+	user := createTestUser(t, store, "alice")
+	category, err := store.CreateCategory(user.ID, &model.CategoryRequest{Title: "News"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feeds := model.Feeds{
+		{FeedURL: "https://example.org/feed.xml", Title: "Feed"},
+		{FeedURL: "newsletter:fedcba9876543210", Title: "Newsletter", NewsletterToken: "0123456789abcdef"},
+	}
+
+	for _, feed := range feeds {
+		feed.UserID = user.ID
+		feed.Category = category
+		feed.Entries = model.Entries{{Title: "Entry", URL: feed.FeedURL + "#1", Hash: feed.FeedURL, Date: time.Now(), Status: model.EntryStatusUnread}}
+		if err := store.CreateFeed(feed); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	archive, err := handler.Export(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return archive
+}
+
+func TestImport(t *testing.T) {
+	store := newTestStorage(t)
+	handler := NewHandler(store)
+	archive := exportTestUser(t, store, handler)
+	user := createTestUser(t, store, "bob")
+
+	// The second import completes or repeats the first one.
+	for i := 0; i < 2; i++ {
+		if err := handler.Import(user.ID, archive); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	feeds, err := store.Feeds(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feeds) != 2 {
+		t.Fatalf(`The feeds should be imported once, got %d feeds`, len(feeds))
+	}
+
+	if count, err := store.NewEntryQueryBuilder(user.ID).CountEntries(); err != nil || count != 2 {
+		t.Fatalf(`The entries should be imported once, got %d entries (%v)`, count, err)
+	}
+
+	if category, _ := store.CategoryByTitle(user.ID, "News"); category == nil {
+		t.Fatal(`The category should be imported`)
+	}
+}
+
+func TestImportNewsletterWithNewToken(t *testing.T) {
+	store := newTestStorage(t)
+	handler := NewHandler(store)
+	archive := exportTestUser(t, store, handler)
+	user := createTestUser(t, store, "bob")
+
+	data, _ := json.Marshal(archive)
+	if strings.Contains(string(data), "0123456789abcdef") {
+		t.Fatal(`The token of the newsletter should not be exported`)
+	}
+
+	archive, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handler.Import(user.ID, archive); err != nil {
+		t.Fatal(err)
+	}
+
+	feeds, _ := store.Feeds(user.ID)
+	for _, feed := range feeds {
+		if feed.FeedURL != "newsletter:fedcba9876543210" {
+			continue
+		}
+
+		if !feed.IsNewsletter() || feed.NewsletterToken == "0123456789abcdef" {
+			t.Fatalf(`The newsletter should receive a new token, got %q`, feed.NewsletterToken)
+		}
+
+		if newsletter, _ := store.FeedByNewsletterToken(feed.NewsletterToken); newsletter == nil || newsletter.UserID != user.ID {
+			t.Fatal(`The new token should deliver the emails to the imported newsletter`)
+		}
+
+		return
+	}
+
+	t.Fatal(`The newsletter should be imported`)
+}
+
+func TestImportIsValidatedFirst(t *testing.T) {
+	store := newTestStorage(t)
+	handler := NewHandler(store)
+	archive := exportTestUser(t, store, handler)
+	user := createTestUser(t, store, "bob")
+
+	archive.SavedSearches = []*SavedSearch{{Title: "Invalid", Query: "is:"}}
+	if err := handler.Import(user.ID, archive); err == nil {
+		t.Fatal(`An archive with an invalid saved search should be rejected`)
+	}
+
+	if feeds, _ := store.Feeds(user.ID); len(feeds) != 0 {
+		t.Fatalf(`Nothing should be imported from an invalid archive, got %d feeds`, len(feeds))
+	}
+
+	if category, _ := store.CategoryByTitle(user.ID, "News"); category != nil {
+		t.Fatal(`Nothing should be imported from an invalid archive, the category was created`)
+	}
+}
+
+func TestImportWithDuplicateFeverUsername(t *testing.T) {
+	store := newTestStorage(t)
+	handler := NewHandler(store)
+	archive := exportTestUser(t, store, handler)
+	user := createTestUser(t, store, "bob")
+
+	// The archive is imported on the same instance, the Fever username of the exported user is taken.
+	archive.Integration.FeverUsername = "alice"
+	integration, _ := store.Integration(archive.User.ID)
+	integration.FeverUsername = "alice"
+	if err := store.UpdateIntegration(integration); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handler.Validate(user.ID, archive); err == nil {
+		t.Fatal(`The Fever username of another user should be rejected`)
+	}
+
+	if err := handler.Import(user.ID, archive); err == nil {
+		t.Fatal(`The import should be rejected`)
+	}
+
+	if feeds, _ := store.Feeds(user.ID); len(feeds) != 0 {
+		t.Fatalf(`Nothing should be imported, got %d feeds`, len(feeds))
+	}
+}
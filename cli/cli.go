@@ -25,6 +25,9 @@ const (
 	flagCreateAdminHelp     = "Create admin user"
 	flagResetPasswordHelp   = "Reset user password"
 	flagResetFeedErrorsHelp = "Clear all feed errors for all users"
+	flagExportUserHelp      = "Export all the data of the given user as JSON to the standard output"
+	flagImportUserHelp      = "Import a JSON export from the standard input into the given user account"
+	flagResetTwoFactorHelp  = "Disable two-factor authentication for the given user"
 	flagDebugModeHelp       = "Show debug logs"
 	flagConfigFileHelp      = "Load configuration file"
 	flagConfigDumpHelp      = "Print parsed configuration values"
@@ -42,6 +45,9 @@ func Parse() {
 		flagCreateAdmin     bool
 		flagResetPassword   bool
 		flagResetFeedErrors bool
+		flagExportUser      string
+		flagImportUser      string
+		flagResetTwoFactor  string
 		flagDebugMode       bool
 		flagConfigFile      string
 		flagConfigDump      bool
@@ -57,6 +63,9 @@ func Parse() {
 	flag.BoolVar(&flagCreateAdmin, "create-admin", false, flagCreateAdminHelp)
 	flag.BoolVar(&flagResetPassword, "reset-password", false, flagResetPasswordHelp)
 	flag.BoolVar(&flagResetFeedErrors, "reset-feed-errors", false, flagResetFeedErrorsHelp)
+	flag.StringVar(&flagExportUser, "export-user", "", flagExportUserHelp)
+	flag.StringVar(&flagImportUser, "import-user", "", flagImportUserHelp)
+	flag.StringVar(&flagResetTwoFactor, "reset-2fa", "", flagResetTwoFactorHelp)
 	flag.BoolVar(&flagDebugMode, "debug", false, flagDebugModeHelp)
 	flag.StringVar(&flagConfigFile, "config-file", "", flagConfigFileHelp)
 	flag.StringVar(&flagConfigFile, "c", "", flagConfigFileHelp)
@@ -172,6 +181,21 @@ func Parse() {
 		return
 	}
 
+	if flagExportUser != "" {
+		exportUser(store, flagExportUser)
+		return
+	}
+
+	if flagImportUser != "" {
+		importUser(store, flagImportUser)
+		return
+	}
+
+	if flagResetTwoFactor != "" {
+		resetTwoFactor(store, flagResetTwoFactor)
+		return
+	}
+
 	// Run migrations and start the daemon.
 	if config.Opts.RunMigrations() {
 		if err := database.Migrate(db); err != nil {
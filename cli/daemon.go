@@ -16,6 +16,7 @@ import (
 	"miniflux.app/logger"
 	"miniflux.app/metric"
 	"miniflux.app/service/httpd"
+	"miniflux.app/service/maild"
 	"miniflux.app/service/scheduler"
 	"miniflux.app/storage"
 	"miniflux.app/systemd"
@@ -29,6 +30,11 @@ func startDaemon(store *storage.Storage) {
 	signal.Notify(stop, os.Interrupt)
 	signal.Notify(stop, syscall.SIGTERM)
 
+	// The live updates of the web interface must receive the events of the schedulers running in other processes.
+	if config.Opts.HasHTTPService() {
+		store.ListenEvents(config.Opts.DatabaseURL())
+	}
+
 	pool := worker.NewPool(store, config.Opts.WorkerPoolSize())
 
 	if config.Opts.HasSchedulerService() && !config.Opts.HasMaintenanceMode() {
@@ -40,6 +46,11 @@ func startDaemon(store *storage.Storage) {
 		httpServer = httpd.Serve(store, pool)
 	}
 
+	var mailServer *maild.Server
+	if config.Opts.HasNewsletterService() {
+		mailServer = maild.Serve(store)
+	}
+
 	if config.Opts.HasMetricsCollector() {
 		collector := metric.NewCollector(store, config.Opts.MetricsRefreshInterval())
 		go collector.GatherStorageMetrics()
@@ -85,5 +96,9 @@ func startDaemon(store *storage.Storage) {
 		httpServer.Shutdown(ctx)
 	}
 
+	if mailServer != nil {
+		mailServer.Shutdown(ctx)
+	}
+
 	logger.Info("Process gracefully stopped")
 }
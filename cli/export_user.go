@@ -0,0 +1,40 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package cli // import "miniflux.app/cli"
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"miniflux.app/archive"
+	"miniflux.app/storage"
+)
+
+func exportUser(store *storage.Storage, username string) {
+	user, err := store.UserByUsername(username)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	if user == nil {
+		fmt.Fprintf(os.Stderr, "User not found!\n")
+		os.Exit(1)
+	}
+
+	userArchive, err := archive.NewHandler(store).Export(user.ID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(userArchive); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
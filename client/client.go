@@ -148,6 +148,28 @@ func (c *Client) MarkAllAsRead(userID int64) error {
 	return err
 }
 
+// ExportUser exports all the data of a given user as JSON.
+func (c *Client) ExportUser(userID int64) ([]byte, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/users/%d/export", userID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// ImportUser imports a JSON export into a given user account.
+func (c *Client) ImportUser(userID int64, f io.ReadCloser) error {
+	_, err := c.request.PostFile(fmt.Sprintf("/v1/users/%d/import", userID), f)
+	return err
+}
+
 // Discover try to find subscriptions from a website.
 func (c *Client) Discover(url string) (Subscriptions, error) {
 	body, err := c.request.Post("/v1/discover", map[string]string{"url": url})
@@ -387,6 +409,23 @@ func (c *Client) FeedIcon(feedID int64) (*FeedIcon, error) {
 	return feedIcon, nil
 }
 
+// FeedHistory gets the refresh history of a feed.
+func (c *Client) FeedHistory(feedID int64) (FeedRefreshes, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/feeds/%d/history", feedID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var refreshes FeedRefreshes
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&refreshes); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return refreshes, nil
+}
+
 // FeedEntry gets a single feed entry.
 func (c *Client) FeedEntry(feedID, entryID int64) (*Entry, error) {
 	body, err := c.request.Get(fmt.Sprintf("/v1/feeds/%d/entries/%d", feedID, entryID))
@@ -512,6 +551,262 @@ func (c *Client) ToggleBookmark(entryID int64) error {
 	return err
 }
 
+// EntryTags gets the list of tags attached to an entry.
+func (c *Client) EntryTags(entryID int64) ([]string, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/entries/%d/tags", entryID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result entryTags
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return result.Tags, nil
+}
+
+// UpdateEntryTags replaces the list of tags attached to an entry.
+func (c *Client) UpdateEntryTags(entryID int64, tags []string) ([]string, error) {
+	body, err := c.request.Put(fmt.Sprintf("/v1/entries/%d/tags", entryID), &entryTags{Tags: tags})
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result entryTags
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return result.Tags, nil
+}
+
+// Enclosure gets an attachment.
+func (c *Client) Enclosure(enclosureID int64) (*Enclosure, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/enclosures/%d", enclosureID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var enclosure *Enclosure
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&enclosure); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return enclosure, nil
+}
+
+// UpdateEnclosure updates the playback position and the played state of an attachment.
+func (c *Client) UpdateEnclosure(enclosureID int64, enclosureUpdateRequest *EnclosureUpdateRequest) (*Enclosure, error) {
+	body, err := c.request.Put(fmt.Sprintf("/v1/enclosures/%d", enclosureID), enclosureUpdateRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var enclosure *Enclosure
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&enclosure); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return enclosure, nil
+}
+
+// EntryAnnotations gets the highlights and notes of an entry.
+func (c *Client) EntryAnnotations(entryID int64) (Annotations, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/entries/%d/annotations", entryID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var annotations Annotations
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&annotations); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return annotations, nil
+}
+
+// CreateAnnotation attaches a highlight or a note to an entry.
+func (c *Client) CreateAnnotation(entryID int64, annotationRequest *AnnotationRequest) (*Annotation, error) {
+	body, err := c.request.Post(fmt.Sprintf("/v1/entries/%d/annotations", entryID), annotationRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var annotation *Annotation
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&annotation); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return annotation, nil
+}
+
+// Annotation gets an annotation.
+func (c *Client) Annotation(annotationID int64) (*Annotation, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/annotations/%d", annotationID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var annotation *Annotation
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&annotation); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return annotation, nil
+}
+
+// UpdateAnnotation updates an annotation.
+func (c *Client) UpdateAnnotation(annotationID int64, annotationRequest *AnnotationRequest) (*Annotation, error) {
+	body, err := c.request.Put(fmt.Sprintf("/v1/annotations/%d", annotationID), annotationRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var annotation *Annotation
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&annotation); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return annotation, nil
+}
+
+// DeleteAnnotation removes an annotation.
+func (c *Client) DeleteAnnotation(annotationID int64) error {
+	return c.request.Delete(fmt.Sprintf("/v1/annotations/%d", annotationID))
+}
+
+// Tags gets the list of tags.
+func (c *Client) Tags() (Tags, error) {
+	body, err := c.request.Get("/v1/tags")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var tags Tags
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&tags); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return tags, nil
+}
+
+// SavedSearches gets the list of saved searches.
+func (c *Client) SavedSearches() (SavedSearches, error) {
+	body, err := c.request.Get("/v1/saved-searches")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var searches SavedSearches
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&searches); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return searches, nil
+}
+
+// SavedSearch gets a saved search.
+func (c *Client) SavedSearch(searchID int64) (*SavedSearch, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/saved-searches/%d", searchID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var search *SavedSearch
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&search); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return search, nil
+}
+
+// CreateSavedSearch creates a saved search.
+func (c *Client) CreateSavedSearch(searchRequest *SavedSearchRequest) (*SavedSearch, error) {
+	body, err := c.request.Post("/v1/saved-searches", searchRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var search *SavedSearch
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&search); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return search, nil
+}
+
+// UpdateSavedSearch updates a saved search.
+func (c *Client) UpdateSavedSearch(searchID int64, searchRequest *SavedSearchRequest) (*SavedSearch, error) {
+	body, err := c.request.Put(fmt.Sprintf("/v1/saved-searches/%d", searchID), searchRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var search *SavedSearch
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&search); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return search, nil
+}
+
+// DeleteSavedSearch removes a saved search.
+func (c *Client) DeleteSavedSearch(searchID int64) error {
+	return c.request.Delete(fmt.Sprintf("/v1/saved-searches/%d", searchID))
+}
+
+// MarkSavedSearchAsRead marks all unread entries matching a saved search as read.
+func (c *Client) MarkSavedSearchAsRead(searchID int64) error {
+	_, err := c.request.Put(fmt.Sprintf("/v1/saved-searches/%d/mark-all-as-read", searchID), nil)
+	return err
+}
+
+// SavedSearchEntries fetches entries matching a saved search.
+func (c *Client) SavedSearchEntries(searchID int64, filter *Filter) (*EntryResultSet, error) {
+	path := buildFilterQueryString(fmt.Sprintf("/v1/saved-searches/%d/entries", searchID), filter)
+
+	body, err := c.request.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result EntryResultSet
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &result, nil
+}
+
 // FetchCounters
 func (c *Client) FetchCounters() (*FeedCounters, error) {
 	body, err := c.request.Get("/v1/feeds/counters")
@@ -589,6 +884,10 @@ func buildFilterQueryString(path string, filter *Filter) string {
 			values.Add("status", status)
 		}
 
+		for _, tag := range filter.Tags {
+			values.Add("tag", tag)
+		}
+
 		path = fmt.Sprintf("%s?%s", path, values.Encode())
 	}
 
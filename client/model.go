@@ -41,6 +41,7 @@ type User struct {
 	CJKReadingSpeed        int        `json:"cjk_reading_speed"`
 	DefaultHomePage        string     `json:"default_home_page"`
 	CategoriesSortingOrder string     `json:"categories_sorting_order"`
+	FilterRules            string     `json:"filter_rules"`
 }
 
 func (u User) String() string {
@@ -79,6 +80,7 @@ type UserModificationRequest struct {
 	CJKReadingSpeed        *int    `json:"cjk_reading_speed"`
 	DefaultHomePage        *string `json:"default_home_page"`
 	CategoriesSortingOrder *string `json:"categories_sorting_order"`
+	FilterRules            *string `json:"filter_rules"`
 }
 
 // Users represents a list of users.
@@ -139,6 +141,15 @@ type Feed struct {
 	Password                    string    `json:"password"`
 	Category                    *Category `json:"category,omitempty"`
 	HideGlobally                bool      `json:"hide_globally"`
+	CacheMedia                  bool      `json:"cache_media"`
+	Notify                      bool      `json:"notify"`
+	ItemSelector                string    `json:"item_selector"`
+	TitleSelector               string    `json:"title_selector"`
+	LinkSelector                string    `json:"link_selector"`
+	DateSelector                string    `json:"date_selector"`
+	ContentSelector             string    `json:"content_selector"`
+	PermanentRedirectURL        string    `json:"permanent_redirect_url"`
+	Gone                        bool      `json:"gone"`
 }
 
 // FeedCreationRequest represents the request to create a feed.
@@ -159,6 +170,13 @@ type FeedCreationRequest struct {
 	BlocklistRules              string `json:"blocklist_rules"`
 	KeeplistRules               string `json:"keeplist_rules"`
 	HideGlobally                bool   `json:"hide_globally"`
+	CacheMedia                  bool   `json:"cache_media"`
+	Notify                      bool   `json:"notify"`
+	ItemSelector                string `json:"item_selector"`
+	TitleSelector               string `json:"title_selector"`
+	LinkSelector                string `json:"link_selector"`
+	DateSelector                string `json:"date_selector"`
+	ContentSelector             string `json:"content_selector"`
 }
 
 // FeedModificationRequest represents the request to update a feed.
@@ -181,6 +199,13 @@ type FeedModificationRequest struct {
 	AllowSelfSignedCertificates *bool   `json:"allow_self_signed_certificates"`
 	FetchViaProxy               *bool   `json:"fetch_via_proxy"`
 	HideGlobally                *bool   `json:"hide_globally"`
+	CacheMedia                  *bool   `json:"cache_media"`
+	Notify                      *bool   `json:"notify"`
+	ItemSelector                *string `json:"item_selector"`
+	TitleSelector               *string `json:"title_selector"`
+	LinkSelector                *string `json:"link_selector"`
+	DateSelector                *string `json:"date_selector"`
+	ContentSelector             *string `json:"content_selector"`
 }
 
 // FeedIcon represents the feed icon.
@@ -190,6 +215,25 @@ type FeedIcon struct {
 	Data     string `json:"data"`
 }
 
+// FeedRefresh represents a refresh in the history of a feed.
+type FeedRefresh struct {
+	ID             int64     `json:"id"`
+	FeedID         int64     `json:"feed_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	StatusCode     int       `json:"status_code"`
+	EffectiveURL   string    `json:"effective_url"`
+	ResponseSize   int64     `json:"response_size"`
+	Duration       int       `json:"duration"`
+	EtagHeader     string    `json:"etag_header"`
+	NotModified    bool      `json:"not_modified"`
+	NewEntries     int       `json:"new_entries"`
+	UpdatedEntries int       `json:"updated_entries"`
+	ErrorMsg       string    `json:"error_message"`
+}
+
+// FeedRefreshes represents the refresh history of a feed.
+type FeedRefreshes []*FeedRefresh
+
 type FeedCounters struct {
 	ReadCounters   map[int64]int `json:"reads"`
 	UnreadCounters map[int64]int `json:"unreads"`
@@ -200,24 +244,27 @@ type Feeds []*Feed
 
 // Entry represents a subscription item in the system.
 type Entry struct {
-	ID          int64      `json:"id"`
-	UserID      int64      `json:"user_id"`
-	FeedID      int64      `json:"feed_id"`
-	Status      string     `json:"status"`
-	Hash        string     `json:"hash"`
-	Title       string     `json:"title"`
-	URL         string     `json:"url"`
-	CommentsURL string     `json:"comments_url"`
-	Date        time.Time  `json:"published_at"`
-	CreatedAt   time.Time  `json:"created_at"`
-	ChangedAt   time.Time  `json:"changed_at"`
-	Content     string     `json:"content"`
-	Author      string     `json:"author"`
-	ShareCode   string     `json:"share_code"`
-	Starred     bool       `json:"starred"`
-	ReadingTime int        `json:"reading_time"`
-	Enclosures  Enclosures `json:"enclosures,omitempty"`
-	Feed        *Feed      `json:"feed,omitempty"`
+	ID           int64       `json:"id"`
+	UserID       int64       `json:"user_id"`
+	FeedID       int64       `json:"feed_id"`
+	Status       string      `json:"status"`
+	Hash         string      `json:"hash"`
+	Title        string      `json:"title"`
+	URL          string      `json:"url"`
+	CommentsURL  string      `json:"comments_url"`
+	Date         time.Time   `json:"published_at"`
+	CreatedAt    time.Time   `json:"created_at"`
+	ChangedAt    time.Time   `json:"changed_at"`
+	Content      string      `json:"content"`
+	Author       string      `json:"author"`
+	ShareCode    string      `json:"share_code"`
+	ShareComment string      `json:"share_comment"`
+	Starred      bool        `json:"starred"`
+	ReadingTime  int         `json:"reading_time"`
+	Enclosures   Enclosures  `json:"enclosures,omitempty"`
+	Tags         []string    `json:"tags"`
+	Annotations  Annotations `json:"annotations,omitempty"`
+	Feed         *Feed       `json:"feed,omitempty"`
 }
 
 // Entries represents a list of entries.
@@ -225,17 +272,103 @@ type Entries []*Entry
 
 // Enclosure represents an attachment.
 type Enclosure struct {
-	ID       int64  `json:"id"`
-	UserID   int64  `json:"user_id"`
-	EntryID  int64  `json:"entry_id"`
-	URL      string `json:"url"`
-	MimeType string `json:"mime_type"`
-	Size     int    `json:"size"`
+	ID               int64             `json:"id"`
+	UserID           int64             `json:"user_id"`
+	EntryID          int64             `json:"entry_id"`
+	URL              string            `json:"url"`
+	MimeType         string            `json:"mime_type"`
+	Size             int               `json:"size"`
+	Duration         int               `json:"duration"`
+	ArtworkURL       string            `json:"artwork_url"`
+	Chapters         EnclosureChapters `json:"chapters"`
+	ChaptersURL      string            `json:"chapters_url"`
+	MediaProgression int               `json:"media_progression"`
+	Played           bool              `json:"played"`
+}
+
+// EnclosureChapter represents a chapter of an audio or video attachment, the start is in seconds.
+type EnclosureChapter struct {
+	Start int    `json:"start"`
+	Title string `json:"title"`
+}
+
+// EnclosureChapters represents a list of chapters.
+type EnclosureChapters []*EnclosureChapter
+
+// EnclosureUpdateRequest represents the request to update the playback state of an attachment.
+type EnclosureUpdateRequest struct {
+	MediaProgression *int  `json:"media_progression,omitempty"`
+	Played           *bool `json:"played,omitempty"`
 }
 
 // Enclosures represents a list of attachments.
 type Enclosures []*Enclosure
 
+// Tag represents a user-defined label attached to entries.
+type Tag struct {
+	ID          int64  `json:"id"`
+	UserID      int64  `json:"user_id"`
+	Title       string `json:"title"`
+	EntryCount  int    `json:"entry_count"`
+	TotalUnread int    `json:"total_unread"`
+}
+
+// Tags represents a list of tags.
+type Tags []*Tag
+
+type entryTags struct {
+	Tags []string `json:"tags"`
+}
+
+// SavedSearch represents a search query saved as a virtual feed.
+type SavedSearch struct {
+	ID          int64     `json:"id"`
+	UserID      int64     `json:"user_id"`
+	Title       string    `json:"title"`
+	Query       string    `json:"query"`
+	CategoryID  int64     `json:"category_id"`
+	FeedID      int64     `json:"feed_id"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	TotalUnread int       `json:"total_unread"`
+}
+
+// SavedSearches represents a list of saved searches.
+type SavedSearches []*SavedSearch
+
+// SavedSearchRequest represents the request to create or update a saved search.
+type SavedSearchRequest struct {
+	Title      string `json:"title"`
+	Query      string `json:"query"`
+	CategoryID int64  `json:"category_id"`
+	FeedID     int64  `json:"feed_id"`
+	Status     string `json:"status"`
+}
+
+// Annotation represents a highlight or a note attached to an entry.
+type Annotation struct {
+	ID          int64     `json:"id"`
+	UserID      int64     `json:"user_id"`
+	EntryID     int64     `json:"entry_id"`
+	Quote       string    `json:"quote"`
+	StartOffset int       `json:"start_offset"`
+	EndOffset   int       `json:"end_offset"`
+	Note        string    `json:"note"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Annotations represents a list of annotations.
+type Annotations []*Annotation
+
+// AnnotationRequest represents the request to create or update an annotation.
+type AnnotationRequest struct {
+	Quote       string `json:"quote"`
+	StartOffset int    `json:"start_offset"`
+	EndOffset   int    `json:"end_offset"`
+	Note        string `json:"note"`
+}
+
 const (
 	FilterNotStarred  = "0"
 	FilterOnlyStarred = "1"
@@ -257,6 +390,7 @@ type Filter struct {
 	CategoryID    int64
 	FeedID        int64
 	Statuses      []string
+	Tags          []string
 }
 
 // EntryResultSet represents the response when fetching entries.
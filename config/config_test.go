@@ -515,6 +515,133 @@ func TestCleanupRemoveSessionsDays(t *testing.T) {
 	}
 }
 
+func TestDefaultCleanupRemoveFeedHistoryDaysValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 7
+	result := opts.CleanupRemoveFeedHistoryDays()
+
+	if result != expected {
+		t.Fatalf(`Unexpected CLEANUP_REMOVE_FEED_HISTORY_DAYS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestCleanupRemoveFeedHistoryDays(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("CLEANUP_REMOVE_FEED_HISTORY_DAYS", "30")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 30
+	result := opts.CleanupRemoveFeedHistoryDays()
+
+	if result != expected {
+		t.Fatalf(`Unexpected CLEANUP_REMOVE_FEED_HISTORY_DAYS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultStaleFeedDaysValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 90
+	result := opts.StaleFeedDays()
+
+	if result != expected {
+		t.Fatalf(`Unexpected STALE_FEED_DAYS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestStaleFeedDays(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("STALE_FEED_DAYS", "30")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 30
+	result := opts.StaleFeedDays()
+
+	if result != expected {
+		t.Fatalf(`Unexpected STALE_FEED_DAYS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultHTTPClientHostLimit(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := HostLimit{MaxConcurrency: defaultHTTPClientHostMaxConcurrency, RequestInterval: defaultHTTPClientHostRequestInterval}
+	result := opts.HTTPClientHostLimit("example.org")
+
+	if result != expected {
+		t.Fatalf(`Unexpected host limit, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestHTTPClientHostLimits(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("HTTP_CLIENT_HOST_MAX_CONCURRENCY", "4")
+	os.Setenv("HTTP_CLIENT_HOST_REQUEST_INTERVAL", "100")
+	os.Setenv("HTTP_CLIENT_HOST_LIMITS", "Example.org=1:5000, feeds.example.org=0:0")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	scenarios := map[string]HostLimit{
+		"example.org":         {1, 5000},
+		"www.example.org":     {1, 5000},
+		"feeds.example.org":   {0, 0},
+		"a.feeds.example.org": {0, 0},
+		"notexample.org":      {4, 100},
+		"example.com":         {4, 100},
+		"":                    {4, 100},
+	}
+
+	for hostname, expected := range scenarios {
+		if result := opts.HTTPClientHostLimit(hostname); result != expected {
+			t.Errorf(`Unexpected limit for %q, got %v instead of %v`, hostname, result, expected)
+		}
+	}
+}
+
+func TestInvalidHTTPClientHostLimits(t *testing.T) {
+	for _, value := range []string{"example.org", "example.org=1", "example.org=a:1", "example.org=1:-5", "=1:1"} {
+		os.Clearenv()
+		os.Setenv("HTTP_CLIENT_HOST_LIMITS", value)
+
+		if _, err := NewParser().ParseEnvironmentVariables(); err == nil {
+			t.Errorf(`Parsing must fail for %q`, value)
+		}
+	}
+}
+
 func TestDefaultWorkerPoolSizeValue(t *testing.T) {
 	os.Clearenv()
 
@@ -1432,6 +1559,165 @@ func TestFetchYouTubeWatchTime(t *testing.T) {
 	}
 }
 
+func TestWebSub(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("WEBSUB", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := true
+	result := opts.HasWebSub()
+
+	if result != expected {
+		t.Fatalf(`Unexpected WEBSUB value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestWebSubPollingInterval(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("WEBSUB_POLLING_INTERVAL", "720")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 720
+	result := opts.WebSubPollingInterval()
+
+	if result != expected {
+		t.Fatalf(`Unexpected WEBSUB_POLLING_INTERVAL value, got %d instead of %d`, result, expected)
+	}
+}
+
+func TestDefaultWebSubPollingIntervalValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultWebSubPollingInterval
+	result := opts.WebSubPollingInterval()
+
+	if result != expected {
+		t.Fatalf(`Unexpected WEBSUB_POLLING_INTERVAL value, got %d instead of %d`, result, expected)
+	}
+}
+
+func TestMediaCacheEnclosures(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("MEDIA_CACHE_ENCLOSURES", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := true
+	result := opts.HasMediaCacheEnclosures()
+
+	if result != expected {
+		t.Fatalf(`Unexpected MEDIA_CACHE_ENCLOSURES value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestMediaCacheMaxFileSize(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("MEDIA_CACHE_MAX_FILE_SIZE", "2")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := int64(2 * 1024 * 1024)
+	result := opts.MediaCacheMaxFileSize()
+
+	if result != expected {
+		t.Fatalf(`Unexpected MEDIA_CACHE_MAX_FILE_SIZE value, got %d instead of %d`, result, expected)
+	}
+}
+
+func TestDefaultMediaCacheUserQuotaValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := int64(defaultMediaCacheUserQuota * 1024 * 1024)
+	result := opts.MediaCacheUserQuota()
+
+	if result != expected {
+		t.Fatalf(`Unexpected MEDIA_CACHE_USER_QUOTA value, got %d instead of %d`, result, expected)
+	}
+}
+
+func TestDefaultSMTPValues(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.HasSMTP() {
+		t.Fatal(`SMTP should be disabled by default`)
+	}
+
+	if opts.SMTPPort() != defaultSMTPPort {
+		t.Fatalf(`Unexpected SMTP_PORT value, got %d instead of %d`, opts.SMTPPort(), defaultSMTPPort)
+	}
+}
+
+func TestSMTPOptions(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SMTP_HOST", "smtp.example.org")
+	os.Setenv("SMTP_PORT", "587")
+	os.Setenv("SMTP_USERNAME", "miniflux")
+	os.Setenv("SMTP_PASSWORD", "secret")
+	os.Setenv("SMTP_FROM_ADDRESS", "miniflux@example.org")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.HasSMTP() {
+		t.Fatal(`SMTP should be enabled`)
+	}
+
+	if opts.SMTPHost() != "smtp.example.org" {
+		t.Fatalf(`Unexpected SMTP_HOST value, got %q`, opts.SMTPHost())
+	}
+
+	if opts.SMTPPort() != 587 {
+		t.Fatalf(`Unexpected SMTP_PORT value, got %d`, opts.SMTPPort())
+	}
+
+	if opts.SMTPUsername() != "miniflux" || opts.SMTPPassword() != "secret" {
+		t.Fatalf(`Unexpected SMTP credentials, got %q and %q`, opts.SMTPUsername(), opts.SMTPPassword())
+	}
+
+	if opts.SMTPFromAddress() != "miniflux@example.org" {
+		t.Fatalf(`Unexpected SMTP_FROM_ADDRESS value, got %q`, opts.SMTPFromAddress())
+	}
+}
+
 func TestParseConfigDumpOutput(t *testing.T) {
 	os.Clearenv()
 
@@ -1466,3 +1752,206 @@ func TestParseConfigDumpOutput(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestDefaultNewsletterValues(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("BASE_URL", "https://reader.example.org/miniflux")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.HasNewsletterService() {
+		t.Fatal(`The newsletter service should be disabled by default`)
+	}
+
+	if opts.NewsletterDomain() != "reader.example.org" {
+		t.Fatalf(`The newsletter domain should default to the hostname of the base URL, got %q`, opts.NewsletterDomain())
+	}
+
+	if opts.NewsletterMaxMessageSize() != defaultNewsletterMaxMessageSize*1024*1024 {
+		t.Fatalf(`Unexpected NEWSLETTER_MAX_MESSAGE_SIZE value, got %d`, opts.NewsletterMaxMessageSize())
+	}
+}
+
+func TestNewsletterOptions(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("NEWSLETTER_LISTEN_ADDR", "127.0.0.1:2525")
+	os.Setenv("NEWSLETTER_DOMAIN", "newsletters.example.org")
+	os.Setenv("NEWSLETTER_MAX_MESSAGE_SIZE", "25")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.HasNewsletterService() || opts.NewsletterListenAddr() != "127.0.0.1:2525" {
+		t.Fatalf(`Unexpected NEWSLETTER_LISTEN_ADDR value, got %q`, opts.NewsletterListenAddr())
+	}
+
+	if opts.NewsletterDomain() != "newsletters.example.org" {
+		t.Fatalf(`Unexpected NEWSLETTER_DOMAIN value, got %q`, opts.NewsletterDomain())
+	}
+
+	if opts.NewsletterMaxMessageSize() != 25*1024*1024 {
+		t.Fatalf(`Unexpected NEWSLETTER_MAX_MESSAGE_SIZE value, got %d`, opts.NewsletterMaxMessageSize())
+	}
+}
+
+func TestWebAuthn(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("WEBAUTHN", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.WebAuthn() {
+		t.Fatalf(`Unexpected WEBAUTHN value, got %v`, opts.WebAuthn())
+	}
+}
+
+func TestWebAuthnWhenUnset(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.WebAuthn() != defaultWebAuthn {
+		t.Fatalf(`Unexpected WEBAUTHN value, got %v`, opts.WebAuthn())
+	}
+}
+
+func TestAuthGroupsOptions(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("OAUTH2_OIDC_GROUPS_CLAIM", "realm_access.roles")
+	os.Setenv("OAUTH2_OIDC_EXTRA_SCOPES", "groups, profile")
+	os.Setenv("AUTH_PROXY_GROUPS_HEADER", "X-Forwarded-Groups")
+	os.Setenv("AUTH_ADMIN_GROUPS", "miniflux-admins")
+	os.Setenv("AUTH_REQUIRED_GROUPS", "miniflux-users, miniflux-admins")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.OAuth2OidcGroupsClaim() != "realm_access.roles" {
+		t.Fatalf(`Unexpected OAUTH2_OIDC_GROUPS_CLAIM value, got %q`, opts.OAuth2OidcGroupsClaim())
+	}
+
+	if scopes := opts.OAuth2OidcExtraScopes(); len(scopes) != 2 || scopes[0] != "groups" || scopes[1] != "profile" {
+		t.Fatalf(`Unexpected OAUTH2_OIDC_EXTRA_SCOPES value, got %v`, scopes)
+	}
+
+	if opts.AuthProxyGroupsHeader() != "X-Forwarded-Groups" {
+		t.Fatalf(`Unexpected AUTH_PROXY_GROUPS_HEADER value, got %q`, opts.AuthProxyGroupsHeader())
+	}
+
+	if groups := opts.AuthAdminGroups(); len(groups) != 1 || groups[0] != "miniflux-admins" {
+		t.Fatalf(`Unexpected AUTH_ADMIN_GROUPS value, got %v`, groups)
+	}
+
+	if groups := opts.AuthRequiredGroups(); len(groups) != 2 || groups[0] != "miniflux-users" || groups[1] != "miniflux-admins" {
+		t.Fatalf(`Unexpected AUTH_REQUIRED_GROUPS value, got %v`, groups)
+	}
+}
+
+func TestAuthGroupsOptionsWhenUnset(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.OAuth2OidcGroupsClaim() != defaultOAuth2OidcGroupsClaim {
+		t.Fatalf(`Unexpected OAUTH2_OIDC_GROUPS_CLAIM value, got %q`, opts.OAuth2OidcGroupsClaim())
+	}
+
+	if len(opts.OAuth2OidcExtraScopes()) != 0 {
+		t.Fatalf(`Unexpected OAUTH2_OIDC_EXTRA_SCOPES value, got %v`, opts.OAuth2OidcExtraScopes())
+	}
+
+	if opts.AuthProxyGroupsHeader() != defaultAuthProxyGroupsHeader {
+		t.Fatalf(`Unexpected AUTH_PROXY_GROUPS_HEADER value, got %q`, opts.AuthProxyGroupsHeader())
+	}
+
+	if len(opts.AuthAdminGroups()) != 0 || len(opts.AuthRequiredGroups()) != 0 {
+		t.Fatalf(`Unexpected AUTH_ADMIN_GROUPS or AUTH_REQUIRED_GROUPS value, got %v and %v`, opts.AuthAdminGroups(), opts.AuthRequiredGroups())
+	}
+}
+
+func TestAuthGroupsWithoutSource(t *testing.T) {
+	scenarios := []map[string]string{
+		{"AUTH_REQUIRED_GROUPS": "miniflux-users"},
+		{"AUTH_ADMIN_GROUPS": "miniflux-admins"},
+		{"AUTH_REQUIRED_GROUPS": "miniflux-users", "OAUTH2_OIDC_GROUPS_CLAIM": "groups", "OAUTH2_PROVIDER": "google"},
+	}
+
+	for _, scenario := range scenarios {
+		os.Clearenv()
+		for key, value := range scenario {
+			os.Setenv(key, value)
+		}
+
+		if _, err := NewParser().ParseEnvironmentVariables(); err == nil {
+			t.Errorf(`Parsing must fail for %v`, scenario)
+		}
+	}
+}
+
+func TestAuthGroupsWithOidcSource(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("OAUTH2_PROVIDER", "oidc")
+	os.Setenv("OAUTH2_OIDC_GROUPS_CLAIM", "groups")
+	os.Setenv("AUTH_REQUIRED_GROUPS", "miniflux-users")
+
+	if _, err := NewParser().ParseEnvironmentVariables(); err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+}
+
+func TestDefaultHTTPServerTimeoutValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultHTTPServerTimeout
+	result := opts.HTTPServerTimeout()
+
+	if result != expected {
+		t.Fatalf(`Unexpected HTTP_SERVER_TIMEOUT value, got %d instead of %d`, result, expected)
+	}
+}
+
+func TestHTTPServerTimeout(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("HTTP_SERVER_TIMEOUT", "60")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 60
+	result := opts.HTTPServerTimeout()
+
+	if result != expected {
+		t.Fatalf(`Unexpected HTTP_SERVER_TIMEOUT value, got %d instead of %d`, result, expected)
+	}
+}
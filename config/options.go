@@ -7,6 +7,7 @@ package config // import "miniflux.app/config"
 import (
 	"crypto/rand"
 	"fmt"
+	"net/url"
 	"sort"
 	"strings"
 	"time"
@@ -32,6 +33,7 @@ const (
 	defaultSchedulerEntryFrequencyMinInterval = 5
 	defaultSchedulerEntryFrequencyMaxInterval = 24 * 60
 	defaultPollingParsingErrorLimit           = 3
+	defaultStaleFeedDays                      = 90
 	defaultRunMigrations                      = false
 	defaultDatabaseURL                        = "user=postgres password=postgres dbname=miniflux2 sslmode=disable"
 	defaultDatabaseMaxConns                   = 20
@@ -46,6 +48,7 @@ const (
 	defaultCleanupArchiveUnreadDays           = 180
 	defaultCleanupArchiveBatchSize            = 10000
 	defaultCleanupRemoveSessionsDays          = 30
+	defaultCleanupRemoveFeedHistoryDays       = 7
 	defaultProxyImages                        = "http-only"
 	defaultProxyImageUrl                      = ""
 	defaultFetchYouTubeWatchTime              = false
@@ -57,13 +60,18 @@ const (
 	defaultOAuth2ClientSecret                 = ""
 	defaultOAuth2RedirectURL                  = ""
 	defaultOAuth2OidcDiscoveryEndpoint        = ""
+	defaultOAuth2OidcGroupsClaim              = ""
 	defaultOAuth2Provider                     = ""
 	defaultPocketConsumerKey                  = ""
 	defaultHTTPClientTimeout                  = 20
+	defaultHTTPServerTimeout                  = 300
 	defaultHTTPClientMaxBodySize              = 15
 	defaultHTTPClientProxy                    = ""
+	defaultHTTPClientHostMaxConcurrency       = 2
+	defaultHTTPClientHostRequestInterval      = 500
 	defaultAuthProxyHeader                    = ""
 	defaultAuthProxyUserCreation              = false
+	defaultAuthProxyGroupsHeader              = ""
 	defaultMaintenanceMode                    = false
 	defaultMaintenanceMessage                 = "Miniflux is currently under maintenance"
 	defaultMetricsCollector                   = false
@@ -71,10 +79,36 @@ const (
 	defaultMetricsAllowedNetworks             = "127.0.0.1/8"
 	defaultWatchdog                           = true
 	defaultInvidiousInstance                  = "yewtu.be"
+	defaultWebSub                             = false
+	defaultWebSubPollingInterval              = 24 * 60
+	defaultMediaCacheEnclosures               = false
+	defaultMediaCacheMaxFileSize              = 10
+	defaultMediaCacheUserQuota                = 500
+	defaultSMTPHost                           = ""
+	defaultSMTPPort                           = 25
+	defaultSMTPUsername                       = ""
+	defaultSMTPPassword                       = ""
+	defaultSMTPFromAddress                    = ""
+	defaultNewsletterListenAddr               = ""
+	defaultNewsletterDomain                   = ""
+	defaultNewsletterMaxMessageSize           = 10
+	defaultWebAuthn                           = false
 )
 
 var defaultHTTPClientUserAgent = "Mozilla/5.0 (compatible; Miniflux/" + version.Version + "; +https://miniflux.app)"
 
+// HostLimit contains the politeness rules applied to the requests sent to a host.
+//
+// A zero value means no limit.
+type HostLimit struct {
+	MaxConcurrency  int
+	RequestInterval int
+}
+
+func (h HostLimit) String() string {
+	return fmt.Sprintf("%d:%d", h.MaxConcurrency, h.RequestInterval)
+}
+
 // Option contains a key to value map of a single option. It may be used to output debug strings.
 type Option struct {
 	Key   string
@@ -107,12 +141,14 @@ type Options struct {
 	cleanupArchiveUnreadDays           int
 	cleanupArchiveBatchSize            int
 	cleanupRemoveSessionsDays          int
+	cleanupRemoveFeedHistoryDays       int
 	pollingFrequency                   int
 	batchSize                          int
 	pollingScheduler                   string
 	schedulerEntryFrequencyMinInterval int
 	schedulerEntryFrequencyMaxInterval int
 	pollingParsingErrorLimit           int
+	staleFeedDays                      int
 	workerPoolSize                     int
 	createAdmin                        bool
 	adminUsername                      string
@@ -125,14 +161,23 @@ type Options struct {
 	oauth2ClientSecret                 string
 	oauth2RedirectURL                  string
 	oauth2OidcDiscoveryEndpoint        string
+	oauth2OidcGroupsClaim              string
+	oauth2OidcExtraScopes              []string
 	oauth2Provider                     string
 	pocketConsumerKey                  string
 	httpClientTimeout                  int
+	httpServerTimeout                  int
 	httpClientMaxBodySize              int64
 	httpClientProxy                    string
 	httpClientUserAgent                string
+	httpClientHostMaxConcurrency       int
+	httpClientHostRequestInterval      int
+	httpClientHostLimits               map[string]HostLimit
 	authProxyHeader                    string
 	authProxyUserCreation              bool
+	authProxyGroupsHeader              string
+	authAdminGroups                    []string
+	authRequiredGroups                 []string
 	maintenanceMode                    bool
 	maintenanceMessage                 string
 	metricsCollector                   bool
@@ -141,6 +186,20 @@ type Options struct {
 	watchdog                           bool
 	invidiousInstance                  string
 	proxyPrivateKey                    []byte
+	webSub                             bool
+	webSubPollingInterval              int
+	mediaCacheEnclosures               bool
+	mediaCacheMaxFileSize              int64
+	mediaCacheUserQuota                int64
+	smtpHost                           string
+	smtpPort                           int
+	smtpUsername                       string
+	smtpPassword                       string
+	smtpFromAddress                    string
+	newsletterListenAddr               string
+	newsletterDomain                   string
+	newsletterMaxMessageSize           int64
+	webAuthn                           bool
 }
 
 // NewOptions returns Options with default values.
@@ -173,12 +232,14 @@ func NewOptions() *Options {
 		cleanupArchiveUnreadDays:           defaultCleanupArchiveUnreadDays,
 		cleanupArchiveBatchSize:            defaultCleanupArchiveBatchSize,
 		cleanupRemoveSessionsDays:          defaultCleanupRemoveSessionsDays,
+		cleanupRemoveFeedHistoryDays:       defaultCleanupRemoveFeedHistoryDays,
 		pollingFrequency:                   defaultPollingFrequency,
 		batchSize:                          defaultBatchSize,
 		pollingScheduler:                   defaultPollingScheduler,
 		schedulerEntryFrequencyMinInterval: defaultSchedulerEntryFrequencyMinInterval,
 		schedulerEntryFrequencyMaxInterval: defaultSchedulerEntryFrequencyMaxInterval,
 		pollingParsingErrorLimit:           defaultPollingParsingErrorLimit,
+		staleFeedDays:                      defaultStaleFeedDays,
 		workerPoolSize:                     defaultWorkerPoolSize,
 		createAdmin:                        defaultCreateAdmin,
 		proxyImages:                        defaultProxyImages,
@@ -189,14 +250,20 @@ func NewOptions() *Options {
 		oauth2ClientSecret:                 defaultOAuth2ClientSecret,
 		oauth2RedirectURL:                  defaultOAuth2RedirectURL,
 		oauth2OidcDiscoveryEndpoint:        defaultOAuth2OidcDiscoveryEndpoint,
+		oauth2OidcGroupsClaim:              defaultOAuth2OidcGroupsClaim,
 		oauth2Provider:                     defaultOAuth2Provider,
 		pocketConsumerKey:                  defaultPocketConsumerKey,
 		httpClientTimeout:                  defaultHTTPClientTimeout,
+		httpServerTimeout:                  defaultHTTPServerTimeout,
 		httpClientMaxBodySize:              defaultHTTPClientMaxBodySize * 1024 * 1024,
 		httpClientProxy:                    defaultHTTPClientProxy,
 		httpClientUserAgent:                defaultHTTPClientUserAgent,
+		httpClientHostMaxConcurrency:       defaultHTTPClientHostMaxConcurrency,
+		httpClientHostRequestInterval:      defaultHTTPClientHostRequestInterval,
+		httpClientHostLimits:               make(map[string]HostLimit),
 		authProxyHeader:                    defaultAuthProxyHeader,
 		authProxyUserCreation:              defaultAuthProxyUserCreation,
+		authProxyGroupsHeader:              defaultAuthProxyGroupsHeader,
 		maintenanceMode:                    defaultMaintenanceMode,
 		maintenanceMessage:                 defaultMaintenanceMessage,
 		metricsCollector:                   defaultMetricsCollector,
@@ -205,6 +272,20 @@ func NewOptions() *Options {
 		watchdog:                           defaultWatchdog,
 		invidiousInstance:                  defaultInvidiousInstance,
 		proxyPrivateKey:                    randomKey,
+		webSub:                             defaultWebSub,
+		webSubPollingInterval:              defaultWebSubPollingInterval,
+		mediaCacheEnclosures:               defaultMediaCacheEnclosures,
+		mediaCacheMaxFileSize:              defaultMediaCacheMaxFileSize * 1024 * 1024,
+		mediaCacheUserQuota:                defaultMediaCacheUserQuota * 1024 * 1024,
+		smtpHost:                           defaultSMTPHost,
+		smtpPort:                           defaultSMTPPort,
+		smtpUsername:                       defaultSMTPUsername,
+		smtpPassword:                       defaultSMTPPassword,
+		smtpFromAddress:                    defaultSMTPFromAddress,
+		newsletterListenAddr:               defaultNewsletterListenAddr,
+		newsletterDomain:                   defaultNewsletterDomain,
+		newsletterMaxMessageSize:           defaultNewsletterMaxMessageSize * 1024 * 1024,
+		webAuthn:                           defaultWebAuthn,
 	}
 }
 
@@ -318,6 +399,11 @@ func (o *Options) CleanupRemoveSessionsDays() int {
 	return o.cleanupRemoveSessionsDays
 }
 
+// CleanupRemoveFeedHistoryDays returns the number of days after which to remove the refresh history of feeds.
+func (o *Options) CleanupRemoveFeedHistoryDays() int {
+	return o.cleanupRemoveFeedHistoryDays
+}
+
 // WorkerPoolSize returns the number of background worker.
 func (o *Options) WorkerPoolSize() int {
 	return o.workerPoolSize
@@ -353,6 +439,11 @@ func (o *Options) PollingParsingErrorLimit() int {
 	return o.pollingParsingErrorLimit
 }
 
+// StaleFeedDays returns the number of days without new entries after which a feed is considered stale.
+func (o *Options) StaleFeedDays() int {
+	return o.staleFeedDays
+}
+
 // IsOAuth2UserCreationAllowed returns true if user creation is allowed for OAuth2 users.
 func (o *Options) IsOAuth2UserCreationAllowed() bool {
 	return o.oauth2UserCreationAllowed
@@ -378,6 +469,16 @@ func (o *Options) OAuth2OidcDiscoveryEndpoint() string {
 	return o.oauth2OidcDiscoveryEndpoint
 }
 
+// OAuth2OidcGroupsClaim returns the name of the OIDC claim that contains the groups of the user.
+func (o *Options) OAuth2OidcGroupsClaim() string {
+	return o.oauth2OidcGroupsClaim
+}
+
+// OAuth2OidcExtraScopes returns the scopes requested in addition to "openid" and "email".
+func (o *Options) OAuth2OidcExtraScopes() []string {
+	return o.oauth2OidcExtraScopes
+}
+
 // OAuth2Provider returns the name of the OAuth2 provider configured.
 func (o *Options) OAuth2Provider() string {
 	return o.oauth2Provider
@@ -447,6 +548,11 @@ func (o *Options) HTTPClientTimeout() int {
 	return o.httpClientTimeout
 }
 
+// HTTPServerTimeout returns the read, write and idle timeout in seconds of the HTTP server.
+func (o *Options) HTTPServerTimeout() int {
+	return o.httpServerTimeout
+}
+
 // HTTPClientMaxBodySize returns the number of bytes allowed for the HTTP client to transfer.
 func (o *Options) HTTPClientMaxBodySize() int64 {
 	return o.httpClientMaxBodySize
@@ -474,6 +580,21 @@ func (o *Options) IsAuthProxyUserCreationAllowed() bool {
 	return o.authProxyUserCreation
 }
 
+// AuthProxyGroupsHeader returns the proxy authentication HTTP header that contains the groups of the user.
+func (o *Options) AuthProxyGroupsHeader() string {
+	return o.authProxyGroupsHeader
+}
+
+// AuthAdminGroups returns the groups of the identity provider whose members are administrators.
+func (o *Options) AuthAdminGroups() []string {
+	return o.authAdminGroups
+}
+
+// AuthRequiredGroups returns the groups of the identity provider allowed to log in, any group is allowed when empty.
+func (o *Options) AuthRequiredGroups() []string {
+	return o.authRequiredGroups
+}
+
 // HasMetricsCollector returns true if metrics collection is enabled.
 func (o *Options) HasMetricsCollector() bool {
 	return o.metricsCollector
@@ -494,6 +615,44 @@ func (o *Options) HTTPClientUserAgent() string {
 	return o.httpClientUserAgent
 }
 
+// HTTPClientHostMaxConcurrency returns the default maximum number of simultaneous requests sent to a host.
+func (o *Options) HTTPClientHostMaxConcurrency() int {
+	return o.httpClientHostMaxConcurrency
+}
+
+// HTTPClientHostRequestInterval returns the default minimum interval between two requests sent to a host in milliseconds.
+func (o *Options) HTTPClientHostRequestInterval() int {
+	return o.httpClientHostRequestInterval
+}
+
+// HTTPClientHostLimits returns the politeness rules defined for specific hosts.
+func (o *Options) HTTPClientHostLimits() map[string]HostLimit {
+	return o.httpClientHostLimits
+}
+
+// HTTPClientHostLimit returns the politeness rules applied to the given host.
+//
+// The most specific override wins, "example.org" also applies to "feeds.example.org".
+func (o *Options) HTTPClientHostLimit(hostname string) HostLimit {
+	hostname = strings.TrimSuffix(strings.ToLower(hostname), ".")
+	for domain := hostname; domain != ""; {
+		if limit, found := o.httpClientHostLimits[domain]; found {
+			return limit
+		}
+
+		_, parent, found := strings.Cut(domain, ".")
+		if !found {
+			break
+		}
+		domain = parent
+	}
+
+	return HostLimit{
+		MaxConcurrency:  o.httpClientHostMaxConcurrency,
+		RequestInterval: o.httpClientHostRequestInterval,
+	}
+}
+
 // HasWatchdog returns true if the systemd watchdog is enabled.
 func (o *Options) HasWatchdog() bool {
 	return o.watchdog
@@ -509,6 +668,94 @@ func (o *Options) ProxyPrivateKey() []byte {
 	return o.proxyPrivateKey
 }
 
+// HasWebSub returns true if feeds advertising a WebSub hub should be updated by push notifications.
+func (o *Options) HasWebSub() bool {
+	return o.webSub
+}
+
+// WebSubPollingInterval returns the polling interval in minutes for feeds with an active WebSub subscription.
+func (o *Options) WebSubPollingInterval() int {
+	return o.webSubPollingInterval
+}
+
+// HasMediaCacheEnclosures returns true if the enclosures are stored in the media cache with the images.
+func (o *Options) HasMediaCacheEnclosures() bool {
+	return o.mediaCacheEnclosures
+}
+
+// MediaCacheMaxFileSize returns the maximum size in bytes of a file stored in the media cache.
+func (o *Options) MediaCacheMaxFileSize() int64 {
+	return o.mediaCacheMaxFileSize
+}
+
+// MediaCacheUserQuota returns the maximum size in bytes of the media cache of each user.
+func (o *Options) MediaCacheUserQuota() int64 {
+	return o.mediaCacheUserQuota
+}
+
+// HasSMTP returns true if an SMTP server is configured to send emails.
+func (o *Options) HasSMTP() bool {
+	return o.smtpHost != "" && o.smtpFromAddress != ""
+}
+
+// SMTPHost returns the hostname of the SMTP server.
+func (o *Options) SMTPHost() string {
+	return o.smtpHost
+}
+
+// SMTPPort returns the port of the SMTP server.
+func (o *Options) SMTPPort() int {
+	return o.smtpPort
+}
+
+// SMTPUsername returns the username used to authenticate on the SMTP server.
+func (o *Options) SMTPUsername() string {
+	return o.smtpUsername
+}
+
+// SMTPPassword returns the password used to authenticate on the SMTP server.
+func (o *Options) SMTPPassword() string {
+	return o.smtpPassword
+}
+
+// SMTPFromAddress returns the sender address of the emails.
+func (o *Options) SMTPFromAddress() string {
+	return o.smtpFromAddress
+}
+
+// HasNewsletterService returns true if the mail listener that receives the newsletters is enabled.
+func (o *Options) HasNewsletterService() bool {
+	return o.newsletterListenAddr != ""
+}
+
+// NewsletterListenAddr returns the address or the Unix socket of the mail listener.
+func (o *Options) NewsletterListenAddr() string {
+	return o.newsletterListenAddr
+}
+
+// NewsletterDomain returns the domain of the newsletter addresses, the hostname of the base URL is used by default.
+func (o *Options) NewsletterDomain() string {
+	if o.newsletterDomain != "" {
+		return o.newsletterDomain
+	}
+
+	if u, err := url.Parse(o.rootURL); err == nil {
+		return u.Hostname()
+	}
+
+	return ""
+}
+
+// NewsletterMaxMessageSize returns the maximum size of the received emails in bytes.
+func (o *Options) NewsletterMaxMessageSize() int64 {
+	return o.newsletterMaxMessageSize
+}
+
+// WebAuthn returns true if users can register passkeys and log in with them.
+func (o *Options) WebAuthn() bool {
+	return o.webAuthn
+}
+
 // SortedOptions returns options as a list of key value pairs, sorted by keys.
 func (o *Options) SortedOptions(redactSecret bool) []*Option {
 	var keyValues = map[string]interface{}{
@@ -516,6 +763,9 @@ func (o *Options) SortedOptions(redactSecret bool) []*Option {
 		"ADMIN_USERNAME":                         o.adminUsername,
 		"AUTH_PROXY_HEADER":                      o.authProxyHeader,
 		"AUTH_PROXY_USER_CREATION":               o.authProxyUserCreation,
+		"AUTH_PROXY_GROUPS_HEADER":               o.authProxyGroupsHeader,
+		"AUTH_ADMIN_GROUPS":                      strings.Join(o.authAdminGroups, ","),
+		"AUTH_REQUIRED_GROUPS":                   strings.Join(o.authRequiredGroups, ","),
 		"BASE_PATH":                              o.basePath,
 		"BASE_URL":                               o.baseURL,
 		"BATCH_SIZE":                             o.batchSize,
@@ -526,6 +776,7 @@ func (o *Options) SortedOptions(redactSecret bool) []*Option {
 		"CLEANUP_ARCHIVE_BATCH_SIZE":             o.cleanupArchiveBatchSize,
 		"CLEANUP_FREQUENCY_HOURS":                o.cleanupFrequencyHours,
 		"CLEANUP_REMOVE_SESSIONS_DAYS":           o.cleanupRemoveSessionsDays,
+		"CLEANUP_REMOVE_FEED_HISTORY_DAYS":       o.cleanupRemoveFeedHistoryDays,
 		"CREATE_ADMIN":                           o.createAdmin,
 		"DATABASE_MAX_CONNS":                     o.databaseMaxConns,
 		"DATABASE_MIN_CONNS":                     o.databaseMinConns,
@@ -537,10 +788,14 @@ func (o *Options) SortedOptions(redactSecret bool) []*Option {
 		"DISABLE_HTTP_SERVICE":                   !o.httpService,
 		"FETCH_YOUTUBE_WATCH_TIME":               o.fetchYouTubeWatchTime,
 		"HTTPS":                                  o.HTTPS,
+		"HTTP_CLIENT_HOST_LIMITS":                formatHostLimits(o.httpClientHostLimits),
+		"HTTP_CLIENT_HOST_MAX_CONCURRENCY":       o.httpClientHostMaxConcurrency,
+		"HTTP_CLIENT_HOST_REQUEST_INTERVAL":      o.httpClientHostRequestInterval,
 		"HTTP_CLIENT_MAX_BODY_SIZE":              o.httpClientMaxBodySize,
 		"HTTP_CLIENT_PROXY":                      o.httpClientProxy,
 		"HTTP_CLIENT_TIMEOUT":                    o.httpClientTimeout,
 		"HTTP_CLIENT_USER_AGENT":                 o.httpClientUserAgent,
+		"HTTP_SERVER_TIMEOUT":                    o.httpServerTimeout,
 		"HTTP_SERVICE":                           o.httpService,
 		"KEY_FILE":                               o.certKeyFile,
 		"INVIDIOUS_INSTANCE":                     o.invidiousInstance,
@@ -548,12 +803,17 @@ func (o *Options) SortedOptions(redactSecret bool) []*Option {
 		"LOG_DATE_TIME":                          o.logDateTime,
 		"MAINTENANCE_MESSAGE":                    o.maintenanceMessage,
 		"MAINTENANCE_MODE":                       o.maintenanceMode,
+		"MEDIA_CACHE_ENCLOSURES":                 o.mediaCacheEnclosures,
+		"MEDIA_CACHE_MAX_FILE_SIZE":              o.mediaCacheMaxFileSize,
+		"MEDIA_CACHE_USER_QUOTA":                 o.mediaCacheUserQuota,
 		"METRICS_ALLOWED_NETWORKS":               strings.Join(o.metricsAllowedNetworks, ","),
 		"METRICS_COLLECTOR":                      o.metricsCollector,
 		"METRICS_REFRESH_INTERVAL":               o.metricsRefreshInterval,
 		"OAUTH2_CLIENT_ID":                       o.oauth2ClientID,
 		"OAUTH2_CLIENT_SECRET":                   redactSecretValue(o.oauth2ClientSecret, redactSecret),
 		"OAUTH2_OIDC_DISCOVERY_ENDPOINT":         o.oauth2OidcDiscoveryEndpoint,
+		"OAUTH2_OIDC_GROUPS_CLAIM":               o.oauth2OidcGroupsClaim,
+		"OAUTH2_OIDC_EXTRA_SCOPES":               strings.Join(o.oauth2OidcExtraScopes, ","),
 		"OAUTH2_PROVIDER":                        o.oauth2Provider,
 		"OAUTH2_REDIRECT_URL":                    o.oauth2RedirectURL,
 		"OAUTH2_USER_CREATION":                   o.oauth2UserCreationAllowed,
@@ -564,14 +824,26 @@ func (o *Options) SortedOptions(redactSecret bool) []*Option {
 		"PROXY_IMAGES":                           o.proxyImages,
 		"PROXY_IMAGE_URL":                        o.proxyImageUrl,
 		"PROXY_PRIVATE_KEY":                      redactSecretValue(string(o.proxyPrivateKey), redactSecret),
+		"NEWSLETTER_DOMAIN":                      o.newsletterDomain,
+		"NEWSLETTER_LISTEN_ADDR":                 o.newsletterListenAddr,
+		"NEWSLETTER_MAX_MESSAGE_SIZE":            o.newsletterMaxMessageSize,
+		"SMTP_FROM_ADDRESS":                      o.smtpFromAddress,
+		"SMTP_HOST":                              o.smtpHost,
+		"SMTP_PASSWORD":                          redactSecretValue(o.smtpPassword, redactSecret),
+		"SMTP_PORT":                              o.smtpPort,
+		"SMTP_USERNAME":                          o.smtpUsername,
 		"ROOT_URL":                               o.rootURL,
 		"RUN_MIGRATIONS":                         o.runMigrations,
 		"SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL": o.schedulerEntryFrequencyMaxInterval,
 		"SCHEDULER_ENTRY_FREQUENCY_MIN_INTERVAL": o.schedulerEntryFrequencyMinInterval,
 		"SCHEDULER_SERVICE":                      o.schedulerService,
 		"SERVER_TIMING_HEADER":                   o.serverTimingHeader,
+		"STALE_FEED_DAYS":                        o.staleFeedDays,
 		"WORKER_POOL_SIZE":                       o.workerPoolSize,
 		"WATCHDOG":                               o.watchdog,
+		"WEBAUTHN":                               o.webAuthn,
+		"WEBSUB":                                 o.webSub,
+		"WEBSUB_POLLING_INTERVAL":                o.webSubPollingInterval,
 	}
 
 	keys := make([]string, 0, len(keyValues))
@@ -603,3 +875,13 @@ func redactSecretValue(value string, redactSecret bool) string {
 	}
 	return value
 }
+
+func formatHostLimits(limits map[string]HostLimit) string {
+	var items []string
+	for host, limit := range limits {
+		items = append(items, host+"="+limit.String())
+	}
+
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
@@ -124,6 +124,8 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.cleanupArchiveBatchSize = parseInt(value, defaultCleanupArchiveBatchSize)
 		case "CLEANUP_REMOVE_SESSIONS_DAYS":
 			p.opts.cleanupRemoveSessionsDays = parseInt(value, defaultCleanupRemoveSessionsDays)
+		case "CLEANUP_REMOVE_FEED_HISTORY_DAYS":
+			p.opts.cleanupRemoveFeedHistoryDays = parseInt(value, defaultCleanupRemoveFeedHistoryDays)
 		case "WORKER_POOL_SIZE":
 			p.opts.workerPoolSize = parseInt(value, defaultWorkerPoolSize)
 		case "POLLING_FREQUENCY":
@@ -138,6 +140,8 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.schedulerEntryFrequencyMinInterval = parseInt(value, defaultSchedulerEntryFrequencyMinInterval)
 		case "POLLING_PARSING_ERROR_LIMIT":
 			p.opts.pollingParsingErrorLimit = parseInt(value, defaultPollingParsingErrorLimit)
+		case "STALE_FEED_DAYS":
+			p.opts.staleFeedDays = parseInt(value, defaultStaleFeedDays)
 		case "PROXY_IMAGES":
 			p.opts.proxyImages = parseString(value, defaultProxyImages)
 		case "PROXY_IMAGE_URL":
@@ -170,20 +174,41 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.oauth2RedirectURL = parseString(value, defaultOAuth2RedirectURL)
 		case "OAUTH2_OIDC_DISCOVERY_ENDPOINT":
 			p.opts.oauth2OidcDiscoveryEndpoint = parseString(value, defaultOAuth2OidcDiscoveryEndpoint)
+		case "OAUTH2_OIDC_GROUPS_CLAIM":
+			p.opts.oauth2OidcGroupsClaim = parseString(value, defaultOAuth2OidcGroupsClaim)
+		case "OAUTH2_OIDC_EXTRA_SCOPES":
+			p.opts.oauth2OidcExtraScopes = parseStringList(value, nil)
 		case "OAUTH2_PROVIDER":
 			p.opts.oauth2Provider = parseString(value, defaultOAuth2Provider)
 		case "HTTP_CLIENT_TIMEOUT":
 			p.opts.httpClientTimeout = parseInt(value, defaultHTTPClientTimeout)
+		case "HTTP_SERVER_TIMEOUT":
+			p.opts.httpServerTimeout = parseInt(value, defaultHTTPServerTimeout)
 		case "HTTP_CLIENT_MAX_BODY_SIZE":
 			p.opts.httpClientMaxBodySize = int64(parseInt(value, defaultHTTPClientMaxBodySize) * 1024 * 1024)
 		case "HTTP_CLIENT_PROXY":
 			p.opts.httpClientProxy = parseString(value, defaultHTTPClientProxy)
 		case "HTTP_CLIENT_USER_AGENT":
 			p.opts.httpClientUserAgent = parseString(value, defaultHTTPClientUserAgent)
+		case "HTTP_CLIENT_HOST_MAX_CONCURRENCY":
+			p.opts.httpClientHostMaxConcurrency = parseInt(value, defaultHTTPClientHostMaxConcurrency)
+		case "HTTP_CLIENT_HOST_REQUEST_INTERVAL":
+			p.opts.httpClientHostRequestInterval = parseInt(value, defaultHTTPClientHostRequestInterval)
+		case "HTTP_CLIENT_HOST_LIMITS":
+			p.opts.httpClientHostLimits, err = parseHostLimits(value)
+			if err != nil {
+				return err
+			}
 		case "AUTH_PROXY_HEADER":
 			p.opts.authProxyHeader = parseString(value, defaultAuthProxyHeader)
 		case "AUTH_PROXY_USER_CREATION":
 			p.opts.authProxyUserCreation = parseBool(value, defaultAuthProxyUserCreation)
+		case "AUTH_PROXY_GROUPS_HEADER":
+			p.opts.authProxyGroupsHeader = parseString(value, defaultAuthProxyGroupsHeader)
+		case "AUTH_ADMIN_GROUPS":
+			p.opts.authAdminGroups = parseStringList(value, nil)
+		case "AUTH_REQUIRED_GROUPS":
+			p.opts.authRequiredGroups = parseStringList(value, nil)
 		case "MAINTENANCE_MODE":
 			p.opts.maintenanceMode = parseBool(value, defaultMaintenanceMode)
 		case "MAINTENANCE_MESSAGE":
@@ -200,6 +225,34 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.watchdog = parseBool(value, defaultWatchdog)
 		case "INVIDIOUS_INSTANCE":
 			p.opts.invidiousInstance = parseString(value, defaultInvidiousInstance)
+		case "WEBSUB":
+			p.opts.webSub = parseBool(value, defaultWebSub)
+		case "WEBSUB_POLLING_INTERVAL":
+			p.opts.webSubPollingInterval = parseInt(value, defaultWebSubPollingInterval)
+		case "MEDIA_CACHE_ENCLOSURES":
+			p.opts.mediaCacheEnclosures = parseBool(value, defaultMediaCacheEnclosures)
+		case "MEDIA_CACHE_MAX_FILE_SIZE":
+			p.opts.mediaCacheMaxFileSize = int64(parseInt(value, defaultMediaCacheMaxFileSize) * 1024 * 1024)
+		case "MEDIA_CACHE_USER_QUOTA":
+			p.opts.mediaCacheUserQuota = int64(parseInt(value, defaultMediaCacheUserQuota) * 1024 * 1024)
+		case "SMTP_HOST":
+			p.opts.smtpHost = parseString(value, defaultSMTPHost)
+		case "SMTP_PORT":
+			p.opts.smtpPort = parseInt(value, defaultSMTPPort)
+		case "SMTP_USERNAME":
+			p.opts.smtpUsername = parseString(value, defaultSMTPUsername)
+		case "SMTP_PASSWORD":
+			p.opts.smtpPassword = parseString(value, defaultSMTPPassword)
+		case "SMTP_FROM_ADDRESS":
+			p.opts.smtpFromAddress = parseString(value, defaultSMTPFromAddress)
+		case "NEWSLETTER_LISTEN_ADDR":
+			p.opts.newsletterListenAddr = parseString(value, defaultNewsletterListenAddr)
+		case "NEWSLETTER_DOMAIN":
+			p.opts.newsletterDomain = parseString(value, defaultNewsletterDomain)
+		case "NEWSLETTER_MAX_MESSAGE_SIZE":
+			p.opts.newsletterMaxMessageSize = int64(parseInt(value, defaultNewsletterMaxMessageSize) * 1024 * 1024)
+		case "WEBAUTHN":
+			p.opts.webAuthn = parseBool(value, defaultWebAuthn)
 		case "PROXY_PRIVATE_KEY":
 			randomKey := make([]byte, 16)
 			rand.Read(randomKey)
@@ -210,6 +263,14 @@ func (p *Parser) parseLines(lines []string) (err error) {
 	if port != "" {
 		p.opts.listenAddr = ":" + port
 	}
+
+	if len(p.opts.authRequiredGroups) > 0 || len(p.opts.authAdminGroups) > 0 {
+		hasOidcGroups := p.opts.oauth2Provider == "oidc" && p.opts.oauth2OidcGroupsClaim != ""
+		if !hasOidcGroups && p.opts.authProxyGroupsHeader == "" {
+			return errors.New("config: AUTH_REQUIRED_GROUPS and AUTH_ADMIN_GROUPS require OAUTH2_OIDC_GROUPS_CLAIM with the oidc provider or AUTH_PROXY_GROUPS_HEADER")
+		}
+	}
+
 	return nil
 }
 
@@ -237,6 +298,37 @@ func parseBaseURL(value string) (string, string, string, error) {
 	return value, url.String(), basePath, nil
 }
 
+// parseHostLimits parses a list of "hostname=max_concurrency:request_interval" items separated by commas.
+func parseHostLimits(value string) (map[string]HostLimit, error) {
+	limits := make(map[string]HostLimit)
+	if value == "" {
+		return limits, nil
+	}
+
+	for _, item := range parseStringList(value, nil) {
+		hostname, rules, found := strings.Cut(item, "=")
+		maxConcurrency, requestInterval, hasInterval := strings.Cut(rules, ":")
+		hostname = strings.ToLower(strings.TrimSpace(hostname))
+		if !found || !hasInterval || hostname == "" {
+			return nil, fmt.Errorf("config: invalid HTTP_CLIENT_HOST_LIMITS item %q, the format is hostname=max_concurrency:request_interval", item)
+		}
+
+		limit := HostLimit{}
+		var err error
+		if limit.MaxConcurrency, err = strconv.Atoi(strings.TrimSpace(maxConcurrency)); err != nil || limit.MaxConcurrency < 0 {
+			return nil, fmt.Errorf("config: invalid maximum concurrency in HTTP_CLIENT_HOST_LIMITS item %q", item)
+		}
+
+		if limit.RequestInterval, err = strconv.Atoi(strings.TrimSpace(requestInterval)); err != nil || limit.RequestInterval < 0 {
+			return nil, fmt.Errorf("config: invalid request interval in HTTP_CLIENT_HOST_LIMITS item %q", item)
+		}
+
+		limits[hostname] = limit
+	}
+
+	return limits, nil
+}
+
 func parseBool(value string, fallback bool) bool {
 	if value == "" {
 		return fallback
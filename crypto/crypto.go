@@ -5,6 +5,7 @@
 package crypto // import "miniflux.app/crypto"
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
@@ -42,3 +43,10 @@ func GenerateRandomString(size int) string {
 func GenerateRandomStringHex(size int) string {
 	return hex.EncodeToString(GenerateRandomBytes(size))
 }
+
+// GenerateSHA256Hmac returns a hexadecimal HMAC-SHA256 signature of the data.
+func GenerateSHA256Hmac(secret string, data []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
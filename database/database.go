@@ -14,8 +14,16 @@ import (
 )
 
 // NewConnectionPool configures the database connection pool.
+//
+// The SQLite driver is used when the data source name starts with "sqlite://", PostgreSQL otherwise.
 func NewConnectionPool(dsn string, minConnections, maxConnections int, connectionLifetime time.Duration) (*sql.DB, error) {
-	db, err := sql.Open("postgres", dsn)
+	driverName := "postgres"
+	if IsSQLiteURL(dsn) {
+		driverName = sqliteDriverName
+		dsn = sqliteDataSource(dsn)
+	}
+
+	db, err := sql.Open(driverName, dsn)
 	if err != nil {
 		return nil, err
 	}
@@ -29,6 +37,9 @@ func NewConnectionPool(dsn string, minConnections, maxConnections int, connectio
 
 // Migrate executes database migrations.
 func Migrate(db *sql.DB) error {
+	migrations := migrationsFor(db)
+	schemaVersion := len(migrations)
+
 	var currentVersion int
 	db.QueryRow(`SELECT version FROM schema_version`).Scan(&currentVersion)
 
@@ -69,6 +80,8 @@ func Migrate(db *sql.DB) error {
 
 // IsSchemaUpToDate checks if the database schema is up to date.
 func IsSchemaUpToDate(db *sql.DB) error {
+	schemaVersion := len(migrationsFor(db))
+
 	var currentVersion int
 	db.QueryRow(`SELECT version FROM schema_version`).Scan(&currentVersion)
 	if currentVersion < schemaVersion {
@@ -76,3 +89,10 @@ func IsSchemaUpToDate(db *sql.DB) error {
 	}
 	return nil
 }
+
+func migrationsFor(db *sql.DB) []func(tx *sql.Tx) error {
+	if IsSQLite(db) {
+		return sqliteMigrations
+	}
+	return migrations
+}
@@ -8,8 +8,6 @@ import (
 	"database/sql"
 )
 
-var schemaVersion = len(migrations)
-
 // Order is important. Add new migrations at the end of the list.
 var migrations = []func(tx *sql.Tx) error{
 	func(tx *sql.Tx) (err error) {
@@ -638,4 +636,881 @@ var migrations = []func(tx *sql.Tx) error{
 		_, err = tx.Exec(sql)
 		return err
 	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE tags (
+				id bigserial not null,
+				user_id int not null,
+				title text not null,
+				primary key (id),
+				unique (user_id, title),
+				foreign key (user_id) references users(id) on delete cascade
+			);
+
+			CREATE TABLE entry_tags (
+				entry_id bigint not null,
+				tag_id bigint not null,
+				primary key (entry_id, tag_id),
+				foreign key (entry_id) references entries(id) on delete cascade,
+				foreign key (tag_id) references tags(id) on delete cascade
+			);
+
+			CREATE INDEX entry_tags_tag_id_idx ON entry_tags(tag_id);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE integrations ADD COLUMN webhook_enabled bool default 'f';
+			ALTER TABLE integrations ADD COLUMN webhook_url text default '';
+			ALTER TABLE integrations ADD COLUMN webhook_secret text default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE users ADD COLUMN filter_rules text not null default ''`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TYPE websub_state AS enum('pending', 'subscribed', 'unsubscribed', 'denied');
+
+			CREATE TABLE websub_subscriptions (
+				feed_id bigint not null,
+				hub_url text not null,
+				topic_url text not null,
+				secret text not null,
+				state websub_state not null default 'pending',
+				lease_expires_at timestamp with time zone,
+				created_at timestamp with time zone not null default now(),
+				primary key (feed_id),
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+
+			CREATE INDEX websub_subscriptions_lease_expires_at_idx ON websub_subscriptions(lease_expires_at) WHERE state = 'subscribed';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE feed_refreshes (
+				id bigserial not null,
+				feed_id bigint not null,
+				created_at timestamp with time zone not null default now(),
+				status_code int not null default 0,
+				effective_url text not null default '',
+				response_size bigint not null default 0,
+				duration int not null default 0,
+				etag_header text not null default '',
+				not_modified bool not null default 'f',
+				new_entries int not null default 0,
+				updated_entries int not null default 0,
+				error_msg text not null default '',
+				primary key (id),
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+
+			CREATE INDEX feed_refreshes_feed_id_created_at_idx ON feed_refreshes(feed_id, created_at);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE saved_searches (
+				id bigserial not null,
+				user_id int not null,
+				title text not null,
+				query text not null,
+				category_id int,
+				feed_id bigint,
+				status text check (status in ('unread', 'read')),
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				unique (user_id, title),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (category_id) references categories(id) on delete cascade,
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN cache_media bool not null default false;
+
+			CREATE TABLE media (
+				id bigserial not null,
+				hash text not null unique,
+				mime_type text not null,
+				size bigint not null,
+				content bytea not null,
+				created_at timestamp with time zone not null default now(),
+				primary key (id)
+			);
+
+			CREATE TABLE entry_media (
+				entry_id bigint not null,
+				media_id bigint not null,
+				url_hash text not null,
+				primary key (entry_id, url_hash),
+				foreign key (entry_id) references entries(id) on delete cascade,
+				foreign key (media_id) references media(id) on delete cascade
+			);
+
+			CREATE INDEX entry_media_url_hash_idx ON entry_media(url_hash);
+			CREATE INDEX entry_media_media_id_idx ON entry_media(media_id);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE enclosures ADD COLUMN duration int not null default 0;
+			ALTER TABLE enclosures ADD COLUMN artwork_url text not null default '';
+			ALTER TABLE enclosures ADD COLUMN chapters text not null default '';
+			ALTER TABLE enclosures ADD COLUMN chapters_url text not null default '';
+			ALTER TABLE enclosures ADD COLUMN media_progression int not null default 0;
+			ALTER TABLE enclosures ADD COLUMN played bool not null default false;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN notify bool not null default false;
+			ALTER TABLE categories ADD COLUMN notify bool not null default false;
+
+			ALTER TABLE integrations ADD COLUMN ntfy_enabled bool default 'f';
+			ALTER TABLE integrations ADD COLUMN ntfy_url text default '';
+			ALTER TABLE integrations ADD COLUMN ntfy_token text default '';
+			ALTER TABLE integrations ADD COLUMN gotify_enabled bool default 'f';
+			ALTER TABLE integrations ADD COLUMN gotify_url text default '';
+			ALTER TABLE integrations ADD COLUMN gotify_token text default '';
+			ALTER TABLE integrations ADD COLUMN email_notification_enabled bool default 'f';
+			ALTER TABLE integrations ADD COLUMN email_notification_address text default '';
+
+			-- Telegram and Matrix used to notify for all feeds.
+			UPDATE feeds SET notify='t' WHERE user_id IN (
+				SELECT user_id FROM integrations WHERE telegram_bot_enabled='t' OR matrix_bot_enabled='t'
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE categories ADD COLUMN exclude_from_digest bool not null default false;
+			ALTER TABLE integrations ADD COLUMN email_digest_frequency text default 'none';
+			ALTER TABLE integrations ADD COLUMN email_digest_sent_at timestamp with time zone;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN newsletter_token text not null default '';
+			CREATE UNIQUE INDEX feeds_newsletter_token_idx ON feeds(newsletter_token) WHERE newsletter_token <> '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN item_selector text not null default '';
+			ALTER TABLE feeds ADD COLUMN title_selector text not null default '';
+			ALTER TABLE feeds ADD COLUMN link_selector text not null default '';
+			ALTER TABLE feeds ADD COLUMN date_selector text not null default '';
+			ALTER TABLE feeds ADD COLUMN content_selector text not null default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE feed_outputs (
+				id bigserial not null,
+				user_id int not null,
+				kind text not null check (kind in ('category', 'starred', 'search')),
+				category_id int,
+				saved_search_id bigint,
+				token text not null unique,
+				last_used_at timestamp with time zone,
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (category_id) references categories(id) on delete cascade,
+				foreign key (saved_search_id) references saved_searches(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN share_comment text not null default '';
+			ALTER TABLE users ADD COLUMN shared_entries_code text not null default '';
+			CREATE UNIQUE INDEX users_shared_entries_code_idx ON users(shared_entries_code) WHERE shared_entries_code <> '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE annotations (
+				id bigserial not null,
+				user_id int not null,
+				entry_id bigint not null,
+				quote text not null default '',
+				start_offset int not null default 0,
+				end_offset int not null default 0,
+				note text not null default '',
+				created_at timestamp with time zone not null default now(),
+				updated_at timestamp with time zone not null default now(),
+				primary key (id),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (entry_id) references entries(id) on delete cascade
+			);
+			CREATE INDEX annotations_entry_id_idx ON annotations(entry_id);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN two_factor_secret text not null default '';
+			ALTER TABLE users ADD COLUMN two_factor_enabled boolean not null default 'f';
+			CREATE TABLE user_recovery_codes (
+				id bigserial not null,
+				user_id int not null,
+				code_hash text not null,
+				used_at timestamp with time zone,
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				unique (user_id, code_hash),
+				foreign key (user_id) references users(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE webauthn_credentials (
+				id bigserial not null,
+				user_id int not null,
+				handle bytea not null,
+				credential_id bytea not null,
+				public_key bytea not null,
+				attestation_type text not null default '',
+				aaguid bytea,
+				sign_count bigint not null default 0,
+				clone_warning boolean not null default 'f',
+				name text not null default '',
+				created_at timestamp with time zone not null default now(),
+				last_seen_at timestamp with time zone,
+				primary key (id),
+				unique (handle),
+				unique (credential_id),
+				foreign key (user_id) references users(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN permanent_redirect_url text not null default '';
+			ALTER TABLE feeds ADD COLUMN gone boolean not null default 'f';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE feeds ADD COLUMN polling_hints jsonb not null default '{}'`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE users ADD COLUMN two_factor_last_time_step bigint not null default 0`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN two_factor_failed_attempts int not null default 0;
+			ALTER TABLE users ADD COLUMN two_factor_locked_until bigint not null default 0;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		// The previous callback URLs were not protected, the feeds subscribe again at the next refresh.
+		sql := `
+			DELETE FROM websub_subscriptions;
+			ALTER TABLE websub_subscriptions ADD COLUMN callback_token text not null default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE users ADD COLUMN denied_by_groups boolean not null default false`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		// The URL of the newsletters contained the token that receives the emails.
+		sql := `
+			UPDATE feeds SET feed_url='newsletter:' || md5(random()::text || id::text) WHERE newsletter_token <> '';
+			UPDATE feeds SET site_url=feed_url WHERE newsletter_token <> '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+}
+
+// Order is important. Add new SQLite migrations at the end of the list.
+//
+// The first migration creates the schema equivalent to the PostgreSQL migrations above,
+// SQLite migrations added afterward must mirror the PostgreSQL ones.
+var sqliteMigrations = []func(tx *sql.Tx) error{
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE schema_version (
+				version text not null
+			);
+
+			CREATE TABLE users (
+				id integer not null primary key autoincrement,
+				username text not null unique,
+				password text,
+				is_admin boolean default 0,
+				language text default 'en_US',
+				timezone text default 'UTC',
+				theme text default 'light_serif',
+				last_login_at timestamp,
+				entry_direction text default 'asc' check (entry_direction in ('asc', 'desc')),
+				keyboard_shortcuts boolean default 1,
+				entries_per_page int default 100,
+				show_reading_time boolean default 1,
+				entry_swipe boolean default 1,
+				stylesheet text not null default '',
+				google_id text not null default '',
+				openid_connect_id text not null default '',
+				display_mode text default 'standalone' check (display_mode in ('fullscreen', 'standalone', 'minimal-ui', 'browser')),
+				entry_order text default 'published_at' check (entry_order in ('published_at', 'created_at')),
+				default_reading_speed int default 265,
+				cjk_reading_speed int default 500,
+				default_home_page text default 'unread',
+				categories_sorting_order text not null default 'unread_count',
+				double_tap boolean default 1,
+				filter_rules text not null default ''
+			);
+
+			CREATE UNIQUE INDEX users_google_id_idx ON users(google_id) WHERE google_id <> '';
+			CREATE UNIQUE INDEX users_openid_connect_id_idx ON users(openid_connect_id) WHERE openid_connect_id <> '';
+
+			CREATE TABLE user_sessions (
+				id integer not null primary key autoincrement,
+				user_id int not null,
+				token text not null unique,
+				created_at timestamp default (now()),
+				user_agent text,
+				ip text,
+				unique (user_id, token),
+				foreign key (user_id) references users(id) on delete cascade
+			);
+
+			CREATE TABLE sessions (
+				id text not null,
+				data text not null,
+				created_at timestamp not null default (now()),
+				primary key(id)
+			);
+
+			CREATE TABLE categories (
+				id integer not null primary key autoincrement,
+				user_id int not null,
+				title text not null,
+				hide_globally boolean not null default 0,
+				unique (user_id, title),
+				foreign key (user_id) references users(id) on delete cascade
+			);
+
+			CREATE TABLE feeds (
+				id integer not null primary key autoincrement,
+				user_id int not null,
+				category_id int not null,
+				title text not null,
+				feed_url text not null,
+				site_url text not null,
+				checked_at timestamp default (now()),
+				next_check_at timestamp default (now()),
+				etag_header text default '',
+				last_modified_header text default '',
+				parsing_error_msg text default '',
+				parsing_error_count int default 0,
+				scraper_rules text default '',
+				rewrite_rules text default '',
+				crawler boolean default 0,
+				username text default '',
+				password text default '',
+				user_agent text default '',
+				cookie text default '',
+				disabled boolean default 0,
+				ignore_http_cache boolean default 0,
+				fetch_via_proxy boolean default 0,
+				allow_self_signed_certificates boolean not null default 0,
+				hide_globally boolean not null default 0,
+				blocklist_rules text not null default '',
+				keeplist_rules text not null default '',
+				url_rewrite_rules text not null default '',
+				unique (user_id, feed_url),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (category_id) references categories(id) on delete cascade
+			);
+
+			CREATE INDEX feeds_user_category_idx ON feeds(user_id, category_id);
+
+			CREATE TABLE entries (
+				id integer not null primary key autoincrement,
+				user_id int not null,
+				feed_id bigint not null,
+				hash text not null,
+				published_at timestamp not null,
+				created_at timestamp not null default (now()),
+				changed_at timestamp not null,
+				title text not null,
+				url text not null,
+				comments_url text default '',
+				author text,
+				content text,
+				status text default 'unread' check (status in ('unread', 'read', 'removed')),
+				starred boolean default 0,
+				share_code text not null default '',
+				reading_time int not null default 0,
+				unique (feed_id, hash),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+
+			CREATE INDEX entries_feed_idx ON entries(feed_id);
+			CREATE INDEX entries_user_feed_idx ON entries(user_id, feed_id);
+			CREATE INDEX entries_user_status_idx ON entries(user_id, status);
+			CREATE INDEX entries_id_user_status_idx ON entries(id, user_id, status);
+			CREATE INDEX entries_feed_id_status_hash_idx ON entries(feed_id, status, hash);
+			CREATE INDEX entries_user_id_status_starred_idx ON entries(user_id, status, starred);
+			CREATE INDEX entries_feed_url_idx ON entries(feed_id, url);
+			CREATE INDEX entries_user_status_feed_idx ON entries(user_id, status, feed_id);
+			CREATE INDEX entries_user_status_changed_idx ON entries(user_id, status, changed_at);
+			CREATE UNIQUE INDEX entries_share_code_idx ON entries(share_code) WHERE share_code <> '';
+
+			CREATE TABLE enclosures (
+				id integer not null primary key autoincrement,
+				user_id int not null,
+				entry_id bigint not null,
+				url text not null,
+				size bigint default 0,
+				mime_type text default '',
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (entry_id) references entries(id) on delete cascade
+			);
+
+			CREATE INDEX enclosures_user_entry_url_idx ON enclosures(user_id, entry_id, url);
+
+			CREATE TABLE icons (
+				id integer not null primary key autoincrement,
+				hash text not null unique,
+				mime_type text not null,
+				content blob not null
+			);
+
+			CREATE TABLE feed_icons (
+				feed_id bigint not null,
+				icon_id bigint not null,
+				primary key(feed_id, icon_id),
+				foreign key (feed_id) references feeds(id) on delete cascade,
+				foreign key (icon_id) references icons(id) on delete cascade
+			);
+
+			CREATE TABLE integrations (
+				user_id int not null,
+				pinboard_enabled boolean default 0,
+				pinboard_token text default '',
+				pinboard_tags text default 'miniflux',
+				pinboard_mark_as_unread boolean default 0,
+				instapaper_enabled boolean default 0,
+				instapaper_username text default '',
+				instapaper_password text default '',
+				fever_enabled boolean default 0,
+				fever_username text default '',
+				fever_token text default '',
+				googlereader_enabled boolean default 0,
+				googlereader_username text default '',
+				googlereader_password text default '',
+				wallabag_enabled boolean default 0,
+				wallabag_only_url boolean default 0,
+				wallabag_url text default '',
+				wallabag_client_id text default '',
+				wallabag_client_secret text default '',
+				wallabag_username text default '',
+				wallabag_password text default '',
+				nunux_keeper_enabled boolean default 0,
+				nunux_keeper_url text default '',
+				nunux_keeper_api_key text default '',
+				espial_enabled boolean default 0,
+				espial_url text default '',
+				espial_api_key text default '',
+				espial_tags text default 'miniflux',
+				pocket_enabled boolean default 0,
+				pocket_access_token text default '',
+				pocket_consumer_key text default '',
+				telegram_bot_enabled boolean default 0,
+				telegram_bot_token text default '',
+				telegram_bot_chat_id text default '',
+				linkding_enabled boolean default 0,
+				linkding_url text default '',
+				linkding_api_key text default '',
+				matrix_bot_enabled boolean default 0,
+				matrix_bot_user text default '',
+				matrix_bot_password text default '',
+				matrix_bot_url text default '',
+				matrix_bot_chat_id text default '',
+				webhook_enabled boolean default 0,
+				webhook_url text default '',
+				webhook_secret text default '',
+				primary key(user_id)
+			);
+
+			CREATE TABLE api_keys (
+				id integer not null primary key autoincrement,
+				user_id int not null references users(id) on delete cascade,
+				token text not null unique,
+				description text not null,
+				last_used_at timestamp,
+				created_at timestamp default (now()),
+				unique (user_id, description)
+			);
+
+			CREATE TABLE acme_cache (
+				key varchar(400) not null primary key,
+				data blob not null,
+				updated_at timestamp not null
+			);
+
+			CREATE TABLE tags (
+				id integer not null primary key autoincrement,
+				user_id int not null,
+				title text not null,
+				unique (user_id, title),
+				foreign key (user_id) references users(id) on delete cascade
+			);
+
+			CREATE TABLE entry_tags (
+				entry_id bigint not null,
+				tag_id bigint not null,
+				primary key (entry_id, tag_id),
+				foreign key (entry_id) references entries(id) on delete cascade,
+				foreign key (tag_id) references tags(id) on delete cascade
+			);
+
+			CREATE INDEX entry_tags_tag_id_idx ON entry_tags(tag_id);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE websub_subscriptions (
+				feed_id bigint not null,
+				hub_url text not null,
+				topic_url text not null,
+				secret text not null,
+				state text not null default 'pending' check (state in ('pending', 'subscribed', 'unsubscribed', 'denied')),
+				lease_expires_at timestamp,
+				created_at timestamp not null default (now()),
+				primary key (feed_id),
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+
+			CREATE INDEX websub_subscriptions_lease_expires_at_idx ON websub_subscriptions(lease_expires_at) WHERE state = 'subscribed';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE feed_refreshes (
+				id integer not null primary key autoincrement,
+				feed_id bigint not null,
+				created_at timestamp not null default (now()),
+				status_code int not null default 0,
+				effective_url text not null default '',
+				response_size bigint not null default 0,
+				duration int not null default 0,
+				etag_header text not null default '',
+				not_modified boolean not null default 0,
+				new_entries int not null default 0,
+				updated_entries int not null default 0,
+				error_msg text not null default '',
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+
+			CREATE INDEX feed_refreshes_feed_id_created_at_idx ON feed_refreshes(feed_id, created_at);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE saved_searches (
+				id integer not null primary key autoincrement,
+				user_id int not null,
+				title text not null,
+				query text not null,
+				category_id int,
+				feed_id bigint,
+				status text check (status in ('unread', 'read')),
+				created_at timestamp not null default (now()),
+				unique (user_id, title),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (category_id) references categories(id) on delete cascade,
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN cache_media boolean not null default 0;
+
+			CREATE TABLE media (
+				id integer not null primary key autoincrement,
+				hash text not null unique,
+				mime_type text not null,
+				size bigint not null,
+				content blob not null,
+				created_at timestamp not null default (now())
+			);
+
+			CREATE TABLE entry_media (
+				entry_id bigint not null,
+				media_id bigint not null,
+				url_hash text not null,
+				primary key (entry_id, url_hash),
+				foreign key (entry_id) references entries(id) on delete cascade,
+				foreign key (media_id) references media(id) on delete cascade
+			);
+
+			CREATE INDEX entry_media_url_hash_idx ON entry_media(url_hash);
+			CREATE INDEX entry_media_media_id_idx ON entry_media(media_id);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE enclosures ADD COLUMN duration int not null default 0;
+			ALTER TABLE enclosures ADD COLUMN artwork_url text not null default '';
+			ALTER TABLE enclosures ADD COLUMN chapters text not null default '';
+			ALTER TABLE enclosures ADD COLUMN chapters_url text not null default '';
+			ALTER TABLE enclosures ADD COLUMN media_progression int not null default 0;
+			ALTER TABLE enclosures ADD COLUMN played boolean not null default 0;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN notify boolean not null default 0;
+			ALTER TABLE categories ADD COLUMN notify boolean not null default 0;
+
+			ALTER TABLE integrations ADD COLUMN ntfy_enabled boolean default 0;
+			ALTER TABLE integrations ADD COLUMN ntfy_url text default '';
+			ALTER TABLE integrations ADD COLUMN ntfy_token text default '';
+			ALTER TABLE integrations ADD COLUMN gotify_enabled boolean default 0;
+			ALTER TABLE integrations ADD COLUMN gotify_url text default '';
+			ALTER TABLE integrations ADD COLUMN gotify_token text default '';
+			ALTER TABLE integrations ADD COLUMN email_notification_enabled boolean default 0;
+			ALTER TABLE integrations ADD COLUMN email_notification_address text default '';
+
+			-- Telegram and Matrix used to notify for all feeds.
+			UPDATE feeds SET notify=1 WHERE user_id IN (
+				SELECT user_id FROM integrations WHERE telegram_bot_enabled=1 OR matrix_bot_enabled=1
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE categories ADD COLUMN exclude_from_digest boolean not null default 0;
+			ALTER TABLE integrations ADD COLUMN email_digest_frequency text default 'none';
+			ALTER TABLE integrations ADD COLUMN email_digest_sent_at timestamp;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN newsletter_token text not null default '';
+			CREATE UNIQUE INDEX feeds_newsletter_token_idx ON feeds(newsletter_token) WHERE newsletter_token <> '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN item_selector text not null default '';
+			ALTER TABLE feeds ADD COLUMN title_selector text not null default '';
+			ALTER TABLE feeds ADD COLUMN link_selector text not null default '';
+			ALTER TABLE feeds ADD COLUMN date_selector text not null default '';
+			ALTER TABLE feeds ADD COLUMN content_selector text not null default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE feed_outputs (
+				id integer not null primary key autoincrement,
+				user_id int not null,
+				kind text not null check (kind in ('category', 'starred', 'search')),
+				category_id int,
+				saved_search_id bigint,
+				token text not null unique,
+				last_used_at timestamp,
+				created_at timestamp not null default (now()),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (category_id) references categories(id) on delete cascade,
+				foreign key (saved_search_id) references saved_searches(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN share_comment text not null default '';
+			ALTER TABLE users ADD COLUMN shared_entries_code text not null default '';
+			CREATE UNIQUE INDEX users_shared_entries_code_idx ON users(shared_entries_code) WHERE shared_entries_code <> '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE annotations (
+				id integer not null primary key autoincrement,
+				user_id int not null,
+				entry_id bigint not null,
+				quote text not null default '',
+				start_offset int not null default 0,
+				end_offset int not null default 0,
+				note text not null default '',
+				created_at timestamp not null default (now()),
+				updated_at timestamp not null default (now()),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (entry_id) references entries(id) on delete cascade
+			);
+			CREATE INDEX annotations_entry_id_idx ON annotations(entry_id);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN two_factor_secret text not null default '';
+			ALTER TABLE users ADD COLUMN two_factor_enabled boolean not null default 0;
+			CREATE TABLE user_recovery_codes (
+				id integer not null primary key autoincrement,
+				user_id int not null,
+				code_hash text not null,
+				used_at timestamp,
+				created_at timestamp not null default (now()),
+				unique (user_id, code_hash),
+				foreign key (user_id) references users(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE webauthn_credentials (
+				id integer not null primary key autoincrement,
+				user_id int not null,
+				handle blob not null,
+				credential_id blob not null,
+				public_key blob not null,
+				attestation_type text not null default '',
+				aaguid blob,
+				sign_count bigint not null default 0,
+				clone_warning boolean not null default 0,
+				name text not null default '',
+				created_at timestamp not null default (now()),
+				last_seen_at timestamp,
+				unique (handle),
+				unique (credential_id),
+				foreign key (user_id) references users(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN permanent_redirect_url text not null default '';
+			ALTER TABLE feeds ADD COLUMN gone boolean not null default 0;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE feeds ADD COLUMN polling_hints text not null default '{}'`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE users ADD COLUMN two_factor_last_time_step integer not null default 0`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN two_factor_failed_attempts integer not null default 0;
+			ALTER TABLE users ADD COLUMN two_factor_locked_until integer not null default 0;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		// The previous callback URLs were not protected, the feeds subscribe again at the next refresh.
+		sql := `
+			DELETE FROM websub_subscriptions;
+			ALTER TABLE websub_subscriptions ADD COLUMN callback_token text not null default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE users ADD COLUMN denied_by_groups boolean not null default 0`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		// The URL of the newsletters contained the token that receives the emails.
+		sql := `
+			UPDATE feeds SET feed_url='newsletter:' || lower(hex(randomblob(16))) WHERE newsletter_token <> '';
+			UPDATE feeds SET site_url=feed_url WHERE newsletter_token <> '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
 }
@@ -0,0 +1,174 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package database // import "miniflux.app/database"
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+const (
+	sqliteDriverName = "miniflux_sqlite3"
+	sqliteURLPrefix  = "sqlite://"
+
+	// SQLiteTimestampFormat is the format used to store dates in SQLite databases.
+	SQLiteTimestampFormat = "2006-01-02 15:04:05.000000-07:00"
+)
+
+var (
+	sqliteAnyArrayRegex    = regexp.MustCompile(`(?i)\s*(!=|<>|=)\s*ANY\s*\(\s*\$(\d+)\s*\)`)
+	sqliteAnySubqueryRegex = regexp.MustCompile(`(?i)\s*=\s*ANY\s*\(\s*SELECT`)
+	sqlitePlaceholderRegex = regexp.MustCompile(`\$(\d+)`)
+	sqliteBooleanRegex     = regexp.MustCompile(`=\s*'(t|f)'`)
+	sqliteCastRegex        = regexp.MustCompile(`::(bytea|text|float|interval)\b`)
+)
+
+func init() {
+	sql.Register(sqliteDriverName, &sqliteDriver{
+		driver: &sqlite3.SQLiteDriver{ConnectHook: registerSQLiteFunctions},
+	})
+}
+
+// IsSQLiteURL returns true if the database URL points to a SQLite database.
+func IsSQLiteURL(dsn string) bool {
+	return strings.HasPrefix(dsn, sqliteURLPrefix)
+}
+
+// IsSQLite returns true if the connection pool uses the SQLite driver.
+func IsSQLite(db *sql.DB) bool {
+	_, ok := db.Driver().(*sqliteDriver)
+	return ok
+}
+
+// sqliteDataSource converts a "sqlite:///path/to/miniflux.db?options" URL to a go-sqlite3 data source.
+func sqliteDataSource(dsn string) string {
+	path, options, _ := strings.Cut(strings.TrimPrefix(dsn, sqliteURLPrefix), "?")
+
+	// Options given in the URL take precedence because go-sqlite3 only uses the first value.
+	defaults := "_foreign_keys=on&_busy_timeout=5000&_journal_mode=WAL&_txlock=immediate"
+	if options != "" {
+		options += "&" + defaults
+	} else {
+		options = defaults
+	}
+
+	return "file:" + path + "?" + options
+}
+
+// rebindSQLiteQuery translates the PostgreSQL syntax used in the storage package to SQLite.
+//
+// Only the constructs that have a direct equivalent are translated:
+// numbered placeholders, "= ANY($n)" with a Postgres array parameter,
+// "= ANY(SELECT ...)", boolean literals and type casts.
+func rebindSQLiteQuery(query string) string {
+	query = sqliteAnyArrayRegex.ReplaceAllStringFunc(query, func(match string) string {
+		parts := sqliteAnyArrayRegex.FindStringSubmatch(match)
+		operator := " IN"
+		if parts[1] != "=" {
+			operator = " NOT IN"
+		}
+		return operator + " (SELECT value FROM json_each(pg_array_to_json($" + parts[2] + ")))"
+	})
+	query = sqliteAnySubqueryRegex.ReplaceAllString(query, " IN (SELECT")
+	query = sqlitePlaceholderRegex.ReplaceAllString(query, "?$1")
+	query = sqliteBooleanRegex.ReplaceAllStringFunc(query, func(match string) string {
+		if strings.HasSuffix(match, "'t'") {
+			return "=1"
+		}
+		return "=0"
+	})
+	return sqliteCastRegex.ReplaceAllString(query, "")
+}
+
+func registerSQLiteFunctions(conn *sqlite3.SQLiteConn) error {
+	if err := conn.RegisterFunc("now", func() string {
+		return time.Now().UTC().Format(SQLiteTimestampFormat)
+	}, false); err != nil {
+		return err
+	}
+
+	return conn.RegisterFunc("pg_array_to_json", func(value string) (string, error) {
+		var values pq.StringArray
+		if err := values.Scan(value); err != nil {
+			return "", err
+		}
+
+		if values == nil {
+			values = pq.StringArray{}
+		}
+
+		data, err := json.Marshal(values)
+		return string(data), err
+	}, true)
+}
+
+func convertSQLiteArgs(args []driver.NamedValue) []driver.NamedValue {
+	for i := range args {
+		if t, ok := args[i].Value.(time.Time); ok {
+			args[i].Value = t.UTC().Format(SQLiteTimestampFormat)
+		}
+	}
+	return args
+}
+
+type sqliteDriver struct {
+	driver *sqlite3.SQLiteDriver
+}
+
+func (d *sqliteDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteConn{conn}, nil
+}
+
+type sqliteConn struct {
+	driver.Conn
+}
+
+func (c *sqliteConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *sqliteConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, rebindSQLiteQuery(query))
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteStmt{stmt}, nil
+}
+
+func (c *sqliteConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+func (c *sqliteConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, rebindSQLiteQuery(query), convertSQLiteArgs(args))
+}
+
+func (c *sqliteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.Conn.(driver.QueryerContext).QueryContext(ctx, rebindSQLiteQuery(query), convertSQLiteArgs(args))
+}
+
+type sqliteStmt struct {
+	driver.Stmt
+}
+
+func (s *sqliteStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.Stmt.(driver.StmtExecContext).ExecContext(ctx, convertSQLiteArgs(args))
+}
+
+func (s *sqliteStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.Stmt.(driver.StmtQueryContext).QueryContext(ctx, convertSQLiteArgs(args))
+}
@@ -26,6 +26,11 @@ func (l LocalizedError) Localize(printer *locale.Printer) string {
 	return printer.Printf(l.message, l.args...)
 }
 
+// HasMessage returns true if the error has been created with the given untranslated message.
+func (l LocalizedError) HasMessage(message string) bool {
+	return l.message == message
+}
+
 // NewLocalizedError returns a new LocalizedError.
 func NewLocalizedError(message string, args ...interface{}) *LocalizedError {
 	return &LocalizedError{message: message, args: args}
@@ -0,0 +1,74 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package event // import "miniflux.app/event"
+
+import "sync"
+
+// subscriberBufferSize is the number of events kept for a slow subscriber before dropping new ones.
+const subscriberBufferSize = 32
+
+// Broker dispatches events to the subscribers of each user.
+type Broker struct {
+	mutex       sync.RWMutex
+	subscribers map[int64]map[chan *Event]bool
+}
+
+// NewBroker returns a new event broker.
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[int64]map[chan *Event]bool)}
+}
+
+// Subscribe returns a channel that receives the events of the given user.
+func (b *Broker) Subscribe(userID int64) chan *Event {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	events := make(chan *Event, subscriberBufferSize)
+	if _, found := b.subscribers[userID]; !found {
+		b.subscribers[userID] = make(map[chan *Event]bool)
+	}
+
+	b.subscribers[userID][events] = true
+	return events
+}
+
+// Unsubscribe removes a subscriber and closes its channel.
+func (b *Broker) Unsubscribe(userID int64, events chan *Event) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, found := b.subscribers[userID][events]; !found {
+		return
+	}
+
+	delete(b.subscribers[userID], events)
+	if len(b.subscribers[userID]) == 0 {
+		delete(b.subscribers, userID)
+	}
+
+	close(events)
+}
+
+// HasSubscribers returns true if the user has at least one subscriber.
+func (b *Broker) HasSubscribers(userID int64) bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return len(b.subscribers[userID]) > 0
+}
+
+// Publish sends an event to all the subscribers of the given user.
+// Subscribers that are not able to keep up miss the event instead of blocking the publisher.
+func (b *Broker) Publish(userID int64, event *Event) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	for events := range b.subscribers[userID] {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
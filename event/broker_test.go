@@ -0,0 +1,65 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package event // import "miniflux.app/event"
+
+import "testing"
+
+func TestPublishToSubscriber(t *testing.T) {
+	broker := NewBroker()
+	events := broker.Subscribe(1)
+	otherEvents := broker.Subscribe(2)
+
+	broker.Publish(1, &Event{Type: EntriesStatusType})
+
+	select {
+	case e := <-events:
+		if e.Type != EntriesStatusType {
+			t.Fatalf(`Unexpected event type, got %q instead of %q`, e.Type, EntriesStatusType)
+		}
+	default:
+		t.Fatal(`The subscriber should receive the event`)
+	}
+
+	select {
+	case <-otherEvents:
+		t.Fatal(`Events should not be sent to other users`)
+	default:
+	}
+}
+
+func TestPublishDoesNotBlock(t *testing.T) {
+	broker := NewBroker()
+	events := broker.Subscribe(1)
+
+	for i := 0; i < subscriberBufferSize*2; i++ {
+		broker.Publish(1, &Event{Type: CountersType})
+	}
+
+	if len(events) != subscriberBufferSize {
+		t.Fatalf(`Unexpected number of buffered events, got %d instead of %d`, len(events), subscriberBufferSize)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	broker := NewBroker()
+	events := broker.Subscribe(1)
+
+	if !broker.HasSubscribers(1) {
+		t.Fatal(`The user should have a subscriber`)
+	}
+
+	broker.Unsubscribe(1, events)
+	broker.Unsubscribe(1, events)
+
+	if broker.HasSubscribers(1) {
+		t.Fatal(`The user should not have any subscriber`)
+	}
+
+	if _, open := <-events; open {
+		t.Fatal(`The channel should be closed`)
+	}
+
+	broker.Publish(1, &Event{Type: CountersType})
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package event // import "miniflux.app/event"
+
+// Event types.
+const (
+	NewEntriesType    = "new_entries"
+	EntriesStatusType = "entries_status"
+	BookmarkType      = "bookmark"
+	CountersType      = "counters"
+)
+
+// Event represents a change sent to the user.
+type Event struct {
+	Type string
+	Data interface{}
+}
+
+// NewEntries is sent when new entries are inserted during a feed refresh.
+type NewEntries struct {
+	FeedID   int64   `json:"feed_id"`
+	EntryIDs []int64 `json:"entry_ids"`
+}
+
+// EntriesStatus is sent when the status of entries changes.
+// EntryIDs is empty when all the entries of a feed, a category or the user are changed.
+type EntriesStatus struct {
+	EntryIDs []int64 `json:"entry_ids"`
+	Status   string  `json:"status"`
+}
+
+// Bookmark is sent when entries are starred or unstarred.
+type Bookmark struct {
+	EntryIDs []int64 `json:"entry_ids"`
+	Starred  bool    `json:"starred"`
+}
+
+// Counters is sent after each change with the up-to-date entry counters.
+type Counters struct {
+	Unread  int           `json:"unread"`
+	Reads   map[int64]int `json:"reads"`
+	Unreads map[int64]int `json:"unreads"`
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package event // import "miniflux.app/event"
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MaxPayloadSize is the size limit of a serialized event, PostgreSQL notifications are limited to 8000 bytes.
+const MaxPayloadSize = 7900
+
+type payload struct {
+	UserID int64           `json:"user_id"`
+	Type   string          `json:"type"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// Marshal serializes an event to share it with the other processes.
+//
+// The list of entries is removed when the payload is too large, the web browsers only use the counters sent after each event.
+func Marshal(userID int64, e *Event) ([]byte, error) {
+	data, err := marshal(userID, e)
+	if err != nil || len(data) <= MaxPayloadSize {
+		return data, err
+	}
+
+	switch v := e.Data.(type) {
+	case *NewEntries:
+		e = &Event{Type: e.Type, Data: &NewEntries{FeedID: v.FeedID}}
+	case *EntriesStatus:
+		e = &Event{Type: e.Type, Data: &EntriesStatus{Status: v.Status}}
+	case *Bookmark:
+		e = &Event{Type: e.Type, Data: &Bookmark{Starred: v.Starred}}
+	}
+
+	return marshal(userID, e)
+}
+
+func marshal(userID int64, e *Event) ([]byte, error) {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return nil, fmt.Errorf("event: unable to serialize %q: %v", e.Type, err)
+	}
+
+	return json.Marshal(&payload{UserID: userID, Type: e.Type, Data: data})
+}
+
+// Unmarshal returns the user and the event of a payload created by Marshal.
+func Unmarshal(data []byte) (int64, *Event, error) {
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return 0, nil, fmt.Errorf("event: unable to parse payload: %v", err)
+	}
+
+	e := &Event{Type: p.Type}
+	switch p.Type {
+	case NewEntriesType:
+		e.Data = &NewEntries{}
+	case EntriesStatusType:
+		e.Data = &EntriesStatus{}
+	case BookmarkType:
+		e.Data = &Bookmark{}
+	case CountersType:
+		e.Data = &Counters{}
+	default:
+		return 0, nil, fmt.Errorf("event: unknown event type %q", p.Type)
+	}
+
+	if err := json.Unmarshal(p.Data, e.Data); err != nil {
+		return 0, nil, fmt.Errorf("event: unable to parse %q: %v", p.Type, err)
+	}
+
+	return p.UserID, e, nil
+}
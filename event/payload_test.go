@@ -0,0 +1,59 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package event // import "miniflux.app/event"
+
+import "testing"
+
+func TestMarshalAndUnmarshal(t *testing.T) {
+	data, err := Marshal(42, &Event{Type: EntriesStatusType, Data: &EntriesStatus{EntryIDs: []int64{1, 2}, Status: "read"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userID, e, err := Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if userID != 42 || e.Type != EntriesStatusType {
+		t.Fatalf(`Unexpected event, got user #%d and type %q`, userID, e.Type)
+	}
+
+	status, ok := e.Data.(*EntriesStatus)
+	if !ok || len(status.EntryIDs) != 2 || status.EntryIDs[1] != 2 || status.Status != "read" {
+		t.Fatalf(`Unexpected event data, got %#v`, e.Data)
+	}
+}
+
+func TestMarshalLargeEvent(t *testing.T) {
+	entryIDs := make([]int64, 2000)
+	for i := range entryIDs {
+		entryIDs[i] = int64(1000000 + i)
+	}
+
+	data, err := Marshal(1, &Event{Type: NewEntriesType, Data: &NewEntries{FeedID: 7, EntryIDs: entryIDs}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(data) > MaxPayloadSize {
+		t.Fatalf(`The payload should fit in a notification, got %d bytes`, len(data))
+	}
+
+	_, e, err := Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if newEntries := e.Data.(*NewEntries); newEntries.FeedID != 7 || len(newEntries.EntryIDs) != 0 {
+		t.Fatalf(`The entries should be removed from large events, got %#v`, newEntries)
+	}
+}
+
+func TestUnmarshalUnknownType(t *testing.T) {
+	if _, _, err := Unmarshal([]byte(`{"user_id":1,"type":"unknown","data":{}}`)); err == nil {
+		t.Fatal(`Unknown events should be rejected`)
+	}
+}
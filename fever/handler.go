@@ -78,6 +78,8 @@ an is_spark equal to 0.
 
 The “Sparks” super group is not included in this response and is composed of all feeds with an
 is_spark equal to 1.
+
+The saved searches are not listed: a group is a list of feeds, it cannot represent a search query.
 */
 func (h *handler) handleGroups(w http.ResponseWriter, r *http.Request) {
 	userID := request.UserID(r)
@@ -310,7 +312,7 @@ func (h *handler) handleItems(w http.ResponseWriter, r *http.Request) {
 			FeedID:    entry.FeedID,
 			Title:     entry.Title,
 			Author:    entry.Author,
-			HTML:      proxy.AbsoluteImageProxyRewriter(h.router, r.Host, entry.Content),
+			HTML:      proxy.AbsoluteEntryImageProxyRewriter(h.router, r.Host, entry),
 			URL:       entry.URL,
 			IsSaved:   isSaved,
 			IsRead:    isRead,
@@ -8,6 +8,7 @@ import (
 	"context"
 	"net/http"
 
+	"miniflux.app/config"
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/json"
 	"miniflux.app/logger"
@@ -45,6 +46,12 @@ func (m *middleware) serve(next http.Handler) http.Handler {
 			return
 		}
 
+		if len(config.Opts.AuthRequiredGroups()) > 0 && m.store.IsDeniedByGroups(user.ID) {
+			logger.Info("[Fever] [ClientIP=%s] User #%d is not member of the required groups", clientIP, user.ID)
+			json.OK(w, r, newAuthFailureResponse())
+			return
+		}
+
 		logger.Info("[Fever] [ClientIP=%s] User #%d is authenticated with user agent %q", clientIP, user.ID, r.UserAgent())
 		m.store.SetLastLogin(user.ID)
 
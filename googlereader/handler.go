@@ -352,6 +352,20 @@ func checkAndSimplifyTags(addTags []Stream, removeTags []Stream) (map[StreamType
 	return tags, nil
 }
 
+// splitLabelStreams separates label streams, which are mapped to entry tags, from state streams.
+func splitLabelStreams(streams []Stream) ([]Stream, []string) {
+	states := make([]Stream, 0, len(streams))
+	labels := make([]string, 0)
+	for _, s := range streams {
+		if s.Type == LabelStream {
+			labels = append(labels, s.ID)
+		} else {
+			states = append(states, s)
+		}
+	}
+	return states, labels
+}
+
 func getItemIDs(r *http.Request) ([]int64, error) {
 	items := r.Form[ParamItemIDs]
 	if len(items) == 0 {
@@ -423,6 +437,8 @@ func (h *handler) editTag(w http.ResponseWriter, r *http.Request) {
 		json.ServerError(w, r, err)
 		return
 	}
+	addTags, addLabels := splitLabelStreams(addTags)
+	removeTags, removeLabels := splitLabelStreams(removeTags)
 	tags, err := checkAndSimplifyTags(addTags, removeTags)
 	if err != nil {
 		logger.Error("[GoogleReader][/edit-tag] [ClientIP=%s] %v", clientIP, err)
@@ -451,11 +467,13 @@ func (h *handler) editTag(w http.ResponseWriter, r *http.Request) {
 	}
 
 	n := 0
+	entryIDs := make([]int64, 0)
 	readEntryIDs := make([]int64, 0)
 	unreadEntryIDs := make([]int64, 0)
 	starredEntryIDs := make([]int64, 0)
 	unstarredEntryIDs := make([]int64, 0)
 	for _, entry := range entries {
+		entryIDs = append(entryIDs, entry.ID)
 		if read, exists := tags[ReadStream]; exists {
 			if read && entry.Status == model.EntryStatusUnread {
 				readEntryIDs = append(readEntryIDs, entry.ID)
@@ -511,6 +529,24 @@ func (h *handler) editTag(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if len(entryIDs) > 0 {
+		for _, label := range addLabels {
+			if err := h.store.AddEntriesTag(userID, entryIDs, label); err != nil {
+				logger.Error("[GoogleReader][/edit-tag] [ClientIP=%s] %v", clientIP, err)
+				json.ServerError(w, r, err)
+				return
+			}
+		}
+
+		for _, label := range removeLabels {
+			if err := h.store.RemoveEntriesTag(userID, entryIDs, label); err != nil {
+				logger.Error("[GoogleReader][/edit-tag] [ClientIP=%s] %v", clientIP, err)
+				json.ServerError(w, r, err)
+				return
+			}
+		}
+	}
+
 	if len(entries) > 0 {
 		settings, err := h.store.Integration(userID)
 		if err != nil {
@@ -841,11 +877,17 @@ func (h *handler) streamItemContents(w http.ResponseWriter, r *http.Request) {
 			categories = append(categories, userStarred)
 		}
 
-		entry.Content = proxy.AbsoluteImageProxyRewriter(h.router, r.Host, entry.Content)
+		for _, tag := range entry.Tags {
+			categories = append(categories, fmt.Sprintf(UserLabelPrefix, userID)+tag)
+		}
+
+		entry.Content = proxy.AbsoluteEntryImageProxyRewriter(h.router, r.Host, entry)
 		proxyImage := config.Opts.ProxyImages()
 
 		for i := range entry.Enclosures {
-			if strings.HasPrefix(entry.Enclosures[i].MimeType, "image/") && (proxyImage == "all" || proxyImage != "none" && !url.IsHTTPS(entry.Enclosures[i].URL)) {
+			if entry.Feed.IsNewsletter() || (entry.Feed.CacheMedia && config.Opts.HasMediaCacheEnclosures()) {
+				entry.Enclosures[i].URL = proxy.AbsoluteProxifyCachedURL(h.router, r.Host, entry.ID, entry.Enclosures[i].URL)
+			} else if strings.HasPrefix(entry.Enclosures[i].MimeType, "image/") && (proxyImage == "all" || proxyImage != "none" && !url.IsHTTPS(entry.Enclosures[i].URL)) {
 				entry.Enclosures[i].URL = proxy.AbsoluteProxifyURL(h.router, r.Host, entry.Enclosures[i].URL)
 			}
 		}
@@ -1017,6 +1059,33 @@ func (h *handler) tagList(w http.ResponseWriter, r *http.Request) {
 			Type:  "folder",
 		})
 	}
+
+	// Saved searches are exposed as labels so clients can display them as virtual feeds.
+	searches, err := h.store.SavedSearches(userID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+	for _, search := range searches {
+		result.Tags = append(result.Tags, subscriptionCategory{
+			ID:    fmt.Sprintf(UserLabelPrefix, userID) + search.Title,
+			Label: search.Title,
+			Type:  "tag",
+		})
+	}
+
+	tags, err := h.store.Tags(userID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+	for _, tag := range tags {
+		result.Tags = append(result.Tags, subscriptionCategory{
+			ID:    fmt.Sprintf(UserLabelPrefix, userID) + tag.Title,
+			Label: tag.Title,
+			Type:  "tag",
+		})
+	}
 	json.OK(w, r, result)
 }
 
@@ -1114,6 +1183,8 @@ func (h *handler) streamItemIDs(w http.ResponseWriter, r *http.Request) {
 		h.handleReadStream(w, r, rm)
 	case FeedStream:
 		h.handleFeedStream(w, r, rm)
+	case LabelStream:
+		h.handleLabelStream(w, r, rm)
 	default:
 		dump, _ := httputil.DumpRequest(r, true)
 		logger.Info("[GoogleReader][/stream/items/ids] [ClientIP=%s] Unknown Stream: %s", clientIP, dump)
@@ -1308,3 +1379,78 @@ func (h *handler) handleFeedStream(w http.ResponseWriter, r *http.Request, rm Re
 
 	json.OK(w, r, streamIDResponse{itemRefs, continuation})
 }
+
+func (h *handler) handleLabelStream(w http.ResponseWriter, r *http.Request, rm RequestModifiers) {
+	clientIP := request.ClientIP(r)
+
+	builder := h.store.NewEntryQueryBuilder(rm.UserID)
+
+	// Labels are shared between categories (folders), saved searches and entry tags, in this order of precedence.
+	category, err := h.store.CategoryByTitle(rm.UserID, rm.Streams[0].ID)
+	if err != nil {
+		logger.Error("[GoogleReader][/stream/items/ids#label] [ClientIP=%s] %v", clientIP, err)
+		json.ServerError(w, r, err)
+		return
+	}
+
+	search, err := h.store.SavedSearchByTitle(rm.UserID, rm.Streams[0].ID)
+	if err != nil {
+		logger.Error("[GoogleReader][/stream/items/ids#label] [ClientIP=%s] %v", clientIP, err)
+		json.ServerError(w, r, err)
+		return
+	}
+
+	switch {
+	case category != nil:
+		builder.WithCategoryID(category.ID)
+	case search != nil:
+		builder.WithSavedSearch(search)
+	default:
+		builder.WithTags([]string{rm.Streams[0].ID})
+	}
+
+	for _, s := range rm.ExcludeTargets {
+		switch s.Type {
+		case ReadStream:
+			builder.WithStatus(model.EntryStatusUnread)
+		default:
+			logger.Info("[GoogleReader][LabelStreamIDs][ClientIP=%s] xt filter type: %#v", clientIP, s)
+		}
+	}
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithLimit(rm.Count)
+	builder.WithOffset(rm.Offset)
+	builder.WithOrder(model.DefaultSortingOrder)
+	builder.WithDirection(rm.SortDirection)
+	if rm.StartTime > 0 {
+		builder.AfterDate(time.Unix(rm.StartTime, 0))
+	}
+	if rm.StopTime > 0 {
+		builder.BeforeDate(time.Unix(rm.StopTime, 0))
+	}
+
+	rawEntryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		logger.Error("[GoogleReader][/stream/items/ids#label] [ClientIP=%s] %v", clientIP, err)
+		json.ServerError(w, r, err)
+		return
+	}
+	var itemRefs = make([]itemRef, 0)
+	for _, entryID := range rawEntryIDs {
+		formattedID := strconv.FormatInt(entryID, 10)
+		itemRefs = append(itemRefs, itemRef{ID: formattedID})
+	}
+
+	totalEntries, err := builder.CountEntries()
+	if err != nil {
+		logger.Error("[GoogleReader][/stream/items/ids#label] [ClientIP=%s] %v", clientIP, err)
+		json.ServerError(w, r, err)
+		return
+	}
+	continuation := 0
+	if len(itemRefs)+rm.Offset < totalEntries {
+		continuation = len(itemRefs) + rm.Offset
+	}
+
+	json.OK(w, r, streamIDResponse{itemRefs, continuation})
+}
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"strings"
 
+	"miniflux.app/config"
 	"miniflux.app/http/request"
 	"miniflux.app/http/response"
 	"miniflux.app/http/response/json"
@@ -62,6 +63,12 @@ func (m *middleware) clientLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if len(config.Opts.AuthRequiredGroups()) > 0 && m.store.IsDeniedByGroups(integration.UserID) {
+		logger.Error("[GoogleReader][Login] [ClientIP=%s] User not member of the required groups: %s", clientIP, username)
+		json.Forbidden(w, r)
+		return
+	}
+
 	m.store.SetLastLogin(integration.UserID)
 
 	token := getAuthToken(integration.GoogleReaderUsername, integration.GoogleReaderPassword)
@@ -188,6 +195,12 @@ func (m *middleware) apiKeyAuth(next http.Handler) http.Handler {
 			return
 		}
 
+		if len(config.Opts.AuthRequiredGroups()) > 0 && m.store.IsDeniedByGroups(integration.UserID) {
+			logger.Error("[GoogleReader][Auth] [ClientIP=%s] User not member of the required groups: %s", clientIP, parts[0])
+			Unauthorized(w, r)
+			return
+		}
+
 		m.store.SetLastLogin(integration.UserID)
 
 		ctx := r.Context()
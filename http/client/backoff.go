@@ -0,0 +1,110 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package client // import "miniflux.app/http/client"
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultHostBackoff = 5 * time.Minute
+	maxHostBackoff     = 24 * time.Hour
+)
+
+var errHostBackoff = "The server %s asked to slow down, no request will be sent before %s"
+
+type hostBackoff struct {
+	until    time.Time
+	failures int
+}
+
+// The hosts that replied with a 429 or 503 status code are shared by all the clients of the process.
+var hostBackoffs = struct {
+	sync.Mutex
+	hosts map[string]*hostBackoff
+}{hosts: make(map[string]*hostBackoff)}
+
+// HostBackoffUntil returns the time until which no request is sent to the host of the given URL.
+// The zero time is returned when the host accepts requests.
+func HostBackoffUntil(rawURL string) time.Time {
+	return hostBackoffUntil(hostname(rawURL))
+}
+
+func hostBackoffUntil(host string) time.Time {
+	hostBackoffs.Lock()
+	defer hostBackoffs.Unlock()
+
+	if backoff, found := hostBackoffs.hosts[host]; found && time.Now().Before(backoff.until) {
+		return backoff.until
+	}
+
+	return time.Time{}
+}
+
+// registerHostBackoff postpones the requests to the host, the delay doubles after each throttled response
+// unless the server gives one with the Retry-After header.
+func registerHostBackoff(host string, retryDelay time.Duration) time.Time {
+	hostBackoffs.Lock()
+	defer hostBackoffs.Unlock()
+
+	backoff, found := hostBackoffs.hosts[host]
+	if !found {
+		backoff = &hostBackoff{}
+		hostBackoffs.hosts[host] = backoff
+	}
+
+	delay := retryDelay
+	if delay <= 0 {
+		delay = maxHostBackoff
+		if backoff.failures < 16 {
+			delay = defaultHostBackoff << backoff.failures
+		}
+	}
+
+	if delay > maxHostBackoff {
+		delay = maxHostBackoff
+	}
+
+	backoff.failures++
+	backoff.until = time.Now().Add(delay)
+	return backoff.until
+}
+
+func countHostBackoffs() int {
+	hostBackoffs.Lock()
+	defer hostBackoffs.Unlock()
+
+	count := 0
+	now := time.Now()
+	for _, backoff := range hostBackoffs.hosts {
+		if now.Before(backoff.until) {
+			count++
+		}
+	}
+	return count
+}
+
+func resetHostBackoff(host string) {
+	hostBackoffs.Lock()
+	defer hostBackoffs.Unlock()
+
+	delete(hostBackoffs.hosts, host)
+}
+
+// hostname returns the host of the URL without the port, the limits and backoffs are shared by all the ports of a host.
+func hostname(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return normalizedHostname(u)
+}
+
+func normalizedHostname(u *url.URL) string {
+	return strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+}
@@ -6,6 +6,7 @@ package client // import "miniflux.app/http/client"
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
@@ -26,12 +27,14 @@ import (
 const (
 	defaultHTTPClientTimeout     = 20
 	defaultHTTPClientMaxBodySize = 15 * 1024 * 1024
+	maxRedirects                 = 10
 )
 
 var (
 	errInvalidCertificate = "Invalid SSL certificate (original error: %q)"
 	errNetworkOperation   = "This website is unreachable (original error: %q)"
 	errRequestTimeout     = "Website unreachable, the request timed out after %d seconds"
+	errHostLimit          = "Too many requests are waiting for the server %s, the request has been cancelled after %d seconds"
 )
 
 // Client builds and executes HTTP requests.
@@ -48,6 +51,7 @@ type Client struct {
 
 	useProxy             bool
 	doNotFollowRedirects bool
+	hostLimitFunc        func(hostname string) config.HostLimit
 
 	ClientTimeout               int
 	ClientMaxBodySize           int64
@@ -72,6 +76,7 @@ func NewClientWithConfig(url string, opts *config.Options) *Client {
 		ClientTimeout:     opts.HTTPClientTimeout(),
 		ClientMaxBodySize: opts.HTTPClientMaxBodySize(),
 		ClientProxyURL:    opts.HTTPClientProxy(),
+		hostLimitFunc:     opts.HTTPClientHostLimit,
 	}
 }
 
@@ -192,7 +197,53 @@ func (c *Client) executeRequest(request *http.Request) (*Response, error) {
 		c.String(),
 	)
 
+	// Hosts that asked to slow down don't receive any request until the end of their backoff period.
+	host := normalizedHostname(request.URL)
+	if backoffUntil := hostBackoffUntil(host); !backoffUntil.IsZero() {
+		return nil, errors.NewLocalizedError(errHostBackoff, host, backoffUntil.UTC().Format(time.RFC1123))
+	}
+
+	// The time spent waiting for the per-host limits counts against the timeout of the request.
+	ctx, cancel := context.WithTimeout(request.Context(), time.Duration(c.ClientTimeout)*time.Second)
+	defer cancel()
+	request = request.WithContext(ctx)
+
+	release, err := c.acquireHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release() }()
+
 	client := c.buildClient()
+
+	// Only an uninterrupted chain of permanent redirects from the requested URL gives the new location of the resource.
+	permanentRedirectURL := ""
+	if !c.doNotFollowRedirects {
+		permanentRedirectsOnly := true
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("client: stopped after %d redirects", maxRedirects)
+			}
+
+			// Each hop is a new request, the limits of its host apply.
+			release()
+			release = func() {}
+			hopRelease, err := c.acquireHost(req.Context(), normalizedHostname(req.URL))
+			if err != nil {
+				return err
+			}
+			release = hopRelease
+
+			if permanentRedirectsOnly && isPermanentRedirect(req.Response.StatusCode) {
+				permanentRedirectURL = req.URL.String()
+			} else {
+				permanentRedirectsOnly = false
+			}
+
+			return nil
+		}
+	}
+
 	resp, err := client.Do(request)
 	if resp != nil {
 		defer resp.Body.Close()
@@ -201,6 +252,8 @@ func (c *Client) executeRequest(request *http.Request) (*Response, error) {
 	if err != nil {
 		if uerr, ok := err.(*url.Error); ok {
 			switch uerr.Err.(type) {
+			case *errors.LocalizedError:
+				err = uerr.Err
 			case x509.CertificateInvalidError, x509.HostnameError:
 				err = errors.NewLocalizedError(errInvalidCertificate, uerr.Err)
 			case *net.OpError:
@@ -232,8 +285,12 @@ func (c *Client) executeRequest(request *http.Request) (*Response, error) {
 		LastModified:  resp.Header.Get("Last-Modified"),
 		ETag:          resp.Header.Get("ETag"),
 		Expires:       resp.Header.Get("Expires"),
+		CacheControl:  resp.Header.Get("Cache-Control"),
+		RetryAfter:    resp.Header.Get("Retry-After"),
 		ContentType:   resp.Header.Get("Content-Type"),
 		ContentLength: resp.ContentLength,
+
+		PermanentRedirectURL: permanentRedirectURL,
 	}
 
 	logger.Debug("[HttpClient:After] Method=%s %s; Response => %s",
@@ -242,6 +299,13 @@ func (c *Client) executeRequest(request *http.Request) (*Response, error) {
 		response,
 	)
 
+	if response.IsThrottled() {
+		backoffUntil := registerHostBackoff(host, response.RetryDelay())
+		logger.Info("[HttpClient] %s replied with status code %d, no request will be sent before %v", host, response.StatusCode, backoffUntil)
+	} else if !response.HasServerFailure() {
+		resetHostBackoff(host)
+	}
+
 	// Ignore caching headers for feeds that do not want any cache.
 	if resp.Header.Get("Expires") == "0" {
 		logger.Debug("[HttpClient] Ignore caching headers for %q", response.EffectiveURL)
@@ -252,6 +316,20 @@ func (c *Client) executeRequest(request *http.Request) (*Response, error) {
 	return response, err
 }
 
+// acquireHost waits for the per-host limits, nothing is limited for clients created without the application config.
+func (c *Client) acquireHost(ctx context.Context, host string) (func(), error) {
+	if c.hostLimitFunc == nil {
+		return func() {}, nil
+	}
+
+	release, err := hostLimits.acquire(ctx, host, c.hostLimitFunc(host))
+	if err != nil {
+		return nil, errors.NewLocalizedError(errHostLimit, host, c.ClientTimeout)
+	}
+
+	return release, nil
+}
+
 func (c *Client) buildRequest(method string, body io.Reader) (*http.Request, error) {
 	request, err := http.NewRequest(method, c.inputURL, body)
 	if err != nil {
@@ -341,3 +419,7 @@ func (c *Client) buildHeaders() http.Header {
 	headers.Add("Connection", "close")
 	return headers
 }
+
+func isPermanentRedirect(statusCode int) bool {
+	return statusCode == http.StatusMovedPermanently || statusCode == http.StatusPermanentRedirect
+}
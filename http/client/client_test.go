@@ -5,7 +5,10 @@
 package client // import "miniflux.app/http/client"
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestClientWithDelay(t *testing.T) {
@@ -51,3 +54,115 @@ func TestClientWithBasicAuth(t *testing.T) {
 		t.Fatalf(`The client should be authenticated successfully: %v`, err)
 	}
 }
+
+func TestClientWithPermanentRedirects(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/moved", http.StatusMovedPermanently)
+		case "/moved":
+			http.Redirect(w, r, "/new", http.StatusPermanentRedirect)
+		case "/temporary":
+			http.Redirect(w, r, "/new", http.StatusFound)
+		case "/mixed":
+			http.Redirect(w, r, "/temporary", http.StatusMovedPermanently)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer ts.Close()
+
+	scenarios := map[string]string{
+		"/old":       ts.URL + "/new",
+		"/moved":     ts.URL + "/new",
+		"/temporary": "",
+		"/mixed":     ts.URL + "/temporary",
+		"/new":       "",
+	}
+
+	for path, expected := range scenarios {
+		response, err := New(ts.URL + path).Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if response.EffectiveURL != ts.URL+"/new" {
+			t.Errorf(`Unexpected effective URL for %q: %q`, path, response.EffectiveURL)
+		}
+
+		if response.PermanentRedirectURL != expected {
+			t.Errorf(`Unexpected permanent redirect URL for %q: got %q instead of %q`, path, response.PermanentRedirectURL, expected)
+		}
+	}
+}
+
+func TestClientWithTooManyRedirects(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusMovedPermanently)
+	}))
+	defer ts.Close()
+
+	if _, err := New(ts.URL).Get(); err == nil {
+		t.Fatal(`The client should stop after too many redirects`)
+	}
+}
+
+func TestClientWithThrottledHost(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	response, err := New(ts.URL).Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !response.IsThrottled() {
+		t.Errorf(`The response should be throttled, got status code %d`, response.StatusCode)
+	}
+
+	backoffUntil := HostBackoffUntil(ts.URL + "/feed.xml")
+	if backoffUntil.Before(time.Now().Add(110*time.Second)) || backoffUntil.After(time.Now().Add(120*time.Second)) {
+		t.Errorf(`The host backoff should follow the Retry-After header, got %v`, backoffUntil)
+	}
+
+	if _, err := New(ts.URL + "/feed.xml").Get(); err == nil {
+		t.Error(`The client should not send requests to a throttled host`)
+	}
+
+	if requests != 1 {
+		t.Errorf(`Only one request should reach the server, got %d`, requests)
+	}
+
+	resetHostBackoff(hostname(ts.URL))
+	if !HostBackoffUntil(ts.URL).IsZero() {
+		t.Error(`The host backoff should be reset`)
+	}
+}
+
+func TestRegisterHostBackoffWithoutRetryAfter(t *testing.T) {
+	host := "backoff.example.org"
+	defer resetHostBackoff(host)
+
+	first := registerHostBackoff(host, 0)
+	if first.Before(time.Now().Add(defaultHostBackoff - time.Minute)) {
+		t.Errorf(`Unexpected first backoff: %v`, first)
+	}
+
+	second := registerHostBackoff(host, 0)
+	if second.Before(time.Now().Add(2*defaultHostBackoff - time.Minute)) {
+		t.Errorf(`The backoff should double after each throttled response: %v`, second)
+	}
+
+	for i := 0; i < 100; i++ {
+		registerHostBackoff(host, 0)
+	}
+
+	if until := hostBackoffUntil(host); until.After(time.Now().Add(maxHostBackoff)) || until.Before(time.Now().Add(maxHostBackoff-time.Minute)) {
+		t.Errorf(`The backoff should not exceed the maximum delay: %v`, until)
+	}
+}
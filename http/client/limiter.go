@@ -0,0 +1,186 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package client // import "miniflux.app/http/client"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/errors"
+)
+
+type hostState struct {
+	activeRequests  int
+	waitingRequests int
+	nextRequestAt   time.Time
+
+	// released is closed and replaced each time a request is done, to wake up the waiting requests.
+	released chan struct{}
+}
+
+func (s *hostState) isIdle(now time.Time) bool {
+	return s.activeRequests == 0 && s.waitingRequests == 0 && now.After(s.nextRequestAt)
+}
+
+// hostLimiter spaces out and limits the simultaneous requests sent to each host.
+type hostLimiter struct {
+	mu    sync.Mutex
+	hosts map[string]*hostState
+
+	delayedRequests int64
+	waitDuration    time.Duration
+}
+
+func newHostLimiter() *hostLimiter {
+	return &hostLimiter{hosts: make(map[string]*hostState)}
+}
+
+// The limits are shared by all the clients of the process.
+var hostLimits = newHostLimiter()
+
+// acquire blocks until a request can be sent to the host, the returned function must be called once the request is done.
+//
+// The wait is bounded by the context: an error is returned when the request cannot start before its deadline,
+// this way the callers are never parked behind a busy host for longer than their own timeout.
+func (l *hostLimiter) acquire(ctx context.Context, host string, limit config.HostLimit) (func(), error) {
+	if limit == (config.HostLimit{}) {
+		return func() {}, nil
+	}
+
+	startTime := time.Now()
+
+	l.mu.Lock()
+	state, found := l.hosts[host]
+	if !found {
+		l.removeIdleHosts()
+		state = &hostState{released: make(chan struct{})}
+		l.hosts[host] = state
+	}
+
+	for limit.MaxConcurrency > 0 && state.activeRequests >= limit.MaxConcurrency {
+		released := state.released
+		state.waitingRequests++
+		l.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+		}
+
+		l.mu.Lock()
+		state.waitingRequests--
+		if err := ctx.Err(); err != nil {
+			l.mu.Unlock()
+			return nil, err
+		}
+	}
+
+	// Each request reserves its own time slot, the next one cannot start before the end of the interval.
+	now := time.Now()
+	requestAt := now
+	if state.nextRequestAt.After(now) {
+		requestAt = state.nextRequestAt
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && requestAt.After(deadline) {
+		l.mu.Unlock()
+		return nil, context.DeadlineExceeded
+	}
+
+	state.activeRequests++
+	state.nextRequestAt = requestAt.Add(time.Duration(limit.RequestInterval) * time.Millisecond)
+
+	if waitDuration := requestAt.Sub(startTime); waitDuration > time.Millisecond {
+		l.delayedRequests++
+		l.waitDuration += waitDuration
+	}
+	l.mu.Unlock()
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() { l.release(host) })
+	}
+
+	if wait := time.Until(requestAt); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+
+	return release, nil
+}
+
+func (l *hostLimiter) release(host string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if state, found := l.hosts[host]; found {
+		state.activeRequests--
+		close(state.released)
+		state.released = make(chan struct{})
+	}
+}
+
+// removeIdleHosts keeps the registry small, the caller must hold the lock.
+func (l *hostLimiter) removeIdleHosts() {
+	now := time.Now()
+	for host, state := range l.hosts {
+		if state.isIdle(now) {
+			delete(l.hosts, host)
+		}
+	}
+}
+
+func (l *hostLimiter) stats() HostLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := HostLimiterStats{
+		DelayedRequests: l.delayedRequests,
+		WaitDuration:    l.waitDuration,
+	}
+
+	for _, state := range l.hosts {
+		if state.activeRequests > 0 {
+			stats.ActiveHosts++
+			stats.ActiveRequests += state.activeRequests
+		}
+		stats.WaitingRequests += state.waitingRequests
+	}
+
+	return stats
+}
+
+// IsHostLimitError returns true if the request has not been sent because too many requests were waiting for its host.
+func IsHostLimitError(err *errors.LocalizedError) bool {
+	return err != nil && err.HasMessage(errHostLimit)
+}
+
+// HostLimiterStats contains the statistics of the per-host request limits.
+type HostLimiterStats struct {
+	ActiveHosts     int
+	ActiveRequests  int
+	WaitingRequests int
+	ThrottledHosts  int
+
+	// Totals since the start of the process.
+	DelayedRequests int64
+	WaitDuration    time.Duration
+}
+
+// HostStats returns the statistics of the per-host request limits and backoffs.
+func HostStats() HostLimiterStats {
+	stats := hostLimits.stats()
+	stats.ThrottledHosts = countHostBackoffs()
+	return stats
+}
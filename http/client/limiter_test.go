@@ -0,0 +1,220 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package client // import "miniflux.app/http/client"
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/errors"
+)
+
+func mustAcquire(t *testing.T, limiter *hostLimiter, host string, limit config.HostLimit) func() {
+	release, err := limiter.acquire(context.Background(), host, limit)
+	if err != nil {
+		t.Fatalf(`Unable to acquire %q: %v`, host, err)
+	}
+	return release
+}
+
+func TestHostLimiterConcurrency(t *testing.T) {
+	limiter := newHostLimiter()
+	limit := config.HostLimit{MaxConcurrency: 2}
+
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			release := mustAcquire(t, limiter, "example.org", limit)
+			defer release()
+
+			mu.Lock()
+			active++
+			if active > maxActive {
+				maxActive = active
+			}
+			mu.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+
+			mu.Lock()
+			active--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if maxActive != 2 {
+		t.Errorf(`Unexpected number of simultaneous requests, got %d instead of 2`, maxActive)
+	}
+
+	if stats := limiter.stats(); stats.ActiveRequests != 0 || stats.WaitingRequests != 0 {
+		t.Errorf(`All the requests should be released, got %+v`, stats)
+	}
+}
+
+func TestHostLimiterRequestInterval(t *testing.T) {
+	limiter := newHostLimiter()
+	limit := config.HostLimit{RequestInterval: 50}
+
+	startTime := time.Now()
+	for i := 0; i < 3; i++ {
+		mustAcquire(t, limiter, "example.org", limit)()
+	}
+
+	// The first request is sent immediately, the next ones wait for the interval.
+	if elapsed := time.Since(startTime); elapsed < 100*time.Millisecond {
+		t.Errorf(`The requests should be spaced out, all of them were sent in %v`, elapsed)
+	}
+
+	if stats := limiter.stats(); stats.DelayedRequests != 2 || stats.WaitDuration < 90*time.Millisecond {
+		t.Errorf(`Unexpected statistics: %+v`, stats)
+	}
+
+	// Other hosts are not affected.
+	startTime = time.Now()
+	mustAcquire(t, limiter, "example.com", limit)()
+	if elapsed := time.Since(startTime); elapsed > 25*time.Millisecond {
+		t.Errorf(`The request to another host should not wait, got %v`, elapsed)
+	}
+}
+
+func TestHostLimiterWithDeadline(t *testing.T) {
+	limiter := newHostLimiter()
+	limit := config.HostLimit{MaxConcurrency: 1}
+
+	release := mustAcquire(t, limiter, "example.org", limit)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := limiter.acquire(ctx, "example.org", limit); err == nil {
+		t.Fatal(`The request should give up when the host is busy until its deadline`)
+	}
+
+	if stats := limiter.stats(); stats.WaitingRequests != 0 || stats.ActiveRequests != 1 {
+		t.Errorf(`The cancelled request should not be counted, got %+v`, stats)
+	}
+
+	release()
+	mustAcquire(t, limiter, "example.org", limit)()
+}
+
+func TestHostLimiterIntervalAfterDeadline(t *testing.T) {
+	limiter := newHostLimiter()
+	limit := config.HostLimit{RequestInterval: 60000}
+
+	mustAcquire(t, limiter, "example.org", limit)()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	startTime := time.Now()
+	if _, err := limiter.acquire(ctx, "example.org", limit); err == nil {
+		t.Fatal(`The request should give up when its time slot is after its deadline`)
+	}
+
+	if elapsed := time.Since(startTime); elapsed > 100*time.Millisecond {
+		t.Errorf(`The request should give up immediately, got %v`, elapsed)
+	}
+}
+
+func TestHostLimiterRemovesIdleHosts(t *testing.T) {
+	limiter := newHostLimiter()
+	mustAcquire(t, limiter, "example.org", config.HostLimit{MaxConcurrency: 1})()
+	mustAcquire(t, limiter, "example.com", config.HostLimit{MaxConcurrency: 1})()
+
+	if len(limiter.hosts) != 1 {
+		t.Errorf(`Idle hosts should be removed, got %d hosts`, len(limiter.hosts))
+	}
+}
+
+func TestClientWithHostLimitAndRedirects(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusFound)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	var mu sync.Mutex
+	var hostnames []string
+
+	clt := New(ts.URL + "/old")
+	clt.hostLimitFunc = func(hostname string) config.HostLimit {
+		mu.Lock()
+		defer mu.Unlock()
+		hostnames = append(hostnames, hostname)
+
+		// A single slot per host, the redirect would wait forever if the first hop was not released.
+		return config.HostLimit{MaxConcurrency: 1}
+	}
+
+	if _, err := clt.Get(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(hostnames) != 2 || hostnames[0] != "127.0.0.1" || hostnames[1] != "127.0.0.1" {
+		t.Errorf(`The limits should apply to each hop by hostname, got %v`, hostnames)
+	}
+}
+
+func TestClientWithBusyHost(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	limit := config.HostLimit{MaxConcurrency: 1}
+	release := mustAcquire(t, hostLimits, "127.0.0.1", limit)
+	defer release()
+
+	clt := New(ts.URL)
+	clt.ClientTimeout = 1
+	clt.hostLimitFunc = func(hostname string) config.HostLimit { return limit }
+
+	_, err := clt.Get()
+	localizedErr, ok := err.(*errors.LocalizedError)
+	if !ok || !IsHostLimitError(localizedErr) {
+		t.Errorf(`The request should be cancelled because the host is busy, got %v`, err)
+	}
+}
+
+func TestNewClientWithConfigHostLimit(t *testing.T) {
+	if limit := NewClientWithConfig("https://example.org:8443/feed.xml", config.NewOptions()).hostLimitFunc("example.org"); limit == (config.HostLimit{}) {
+		t.Errorf(`Clients with the application config should be limited, got %v`, limit)
+	}
+
+	if New("https://example.org/").hostLimitFunc != nil {
+		t.Error(`Clients without configuration should not be limited`)
+	}
+}
+
+func TestHostname(t *testing.T) {
+	scenarios := map[string]string{
+		"https://Example.org:8443/feed.xml": "example.org",
+		"https://example.org./feed.xml":     "example.org",
+		"http://127.0.0.1:8080/":            "127.0.0.1",
+		"http://[::1]:8080/":                "::1",
+	}
+
+	for input, expected := range scenarios {
+		if result := hostname(input); result != expected {
+			t.Errorf(`Unexpected hostname for %q, got %q instead of %q`, input, result, expected)
+		}
+	}
+}
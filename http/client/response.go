@@ -8,8 +8,11 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"golang.org/x/net/html/charset"
@@ -25,18 +28,26 @@ type Response struct {
 	LastModified  string
 	ETag          string
 	Expires       string
+	CacheControl  string
+	RetryAfter    string
 	ContentType   string
 	ContentLength int64
+
+	// PermanentRedirectURL is the new location of the resource when the server replied with 301 or 308 redirects.
+	PermanentRedirectURL string
 }
 
 func (r *Response) String() string {
 	return fmt.Sprintf(
-		`StatusCode=%d EffectiveURL=%q LastModified=%q ETag=%s Expires=%s ContentType=%q ContentLength=%d`,
+		`StatusCode=%d EffectiveURL=%q PermanentRedirectURL=%q LastModified=%q ETag=%s Expires=%s CacheControl=%q RetryAfter=%q ContentType=%q ContentLength=%d`,
 		r.StatusCode,
 		r.EffectiveURL,
+		r.PermanentRedirectURL,
 		r.LastModified,
 		r.ETag,
 		r.Expires,
+		r.CacheControl,
+		r.RetryAfter,
 		r.ContentType,
 		r.ContentLength,
 	)
@@ -47,6 +58,65 @@ func (r *Response) IsNotFound() bool {
 	return r.StatusCode == 404 || r.StatusCode == 410
 }
 
+// IsGone returns true if the resource has been removed permanently.
+func (r *Response) IsGone() bool {
+	return r.StatusCode == 410
+}
+
+// IsThrottled returns true if the server asks to slow down.
+func (r *Response) IsThrottled() bool {
+	return r.StatusCode == 429 || r.StatusCode == 503
+}
+
+// CacheLifetime returns for how long the response can be cached according to the Cache-Control and Expires headers.
+func (r *Response) CacheLifetime() time.Duration {
+	if r.CacheControl != "" {
+		for _, directive := range strings.Split(r.CacheControl, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			switch strings.ToLower(name) {
+			case "no-cache", "no-store":
+				return 0
+			case "max-age":
+				if seconds, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil && seconds > 0 {
+					return time.Duration(seconds) * time.Second
+				}
+				return 0
+			}
+		}
+	}
+
+	if expires, err := http.ParseTime(r.Expires); err == nil {
+		if lifetime := time.Until(expires); lifetime > 0 {
+			return lifetime
+		}
+	}
+
+	return 0
+}
+
+// RetryDelay returns how long to wait before the next request according to the Retry-After header.
+func (r *Response) RetryDelay() time.Duration {
+	value := strings.TrimSpace(r.RetryAfter)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		return 0
+	}
+
+	if retryAt, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(retryAt); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
+
 // IsNotAuthorized returns true if the resource require authentication.
 func (r *Response) IsNotAuthorized() bool {
 	return r.StatusCode == 401
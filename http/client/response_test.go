@@ -6,9 +6,11 @@ package client // import "miniflux.app/http/client"
 
 import (
 	"bytes"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
+	"time"
 	"unicode/utf8"
 )
 
@@ -29,6 +31,23 @@ func TestIsNotFound(t *testing.T) {
 	}
 }
 
+func TestIsGone(t *testing.T) {
+	scenarios := map[int]bool{
+		200: false,
+		404: false,
+		410: true,
+	}
+
+	for input, expected := range scenarios {
+		r := &Response{StatusCode: input}
+		actual := r.IsGone()
+
+		if actual != expected {
+			t.Errorf(`Unexpected result, got %v instead of %v for status code %d`, actual, expected, input)
+		}
+	}
+}
+
 func TestIsNotAuthorized(t *testing.T) {
 	scenarios := map[int]bool{
 		200: false,
@@ -147,3 +166,71 @@ func TestEnsureUnicodeWithHTMLDocuments(t *testing.T) {
 		}
 	}
 }
+
+func TestIsThrottled(t *testing.T) {
+	scenarios := map[int]bool{
+		200: false,
+		404: false,
+		429: true,
+		500: false,
+		503: true,
+	}
+
+	for input, expected := range scenarios {
+		r := &Response{StatusCode: input}
+		if actual := r.IsThrottled(); actual != expected {
+			t.Errorf(`Unexpected result for status code %d, got %v instead of %v`, input, actual, expected)
+		}
+	}
+}
+
+func TestCacheLifetime(t *testing.T) {
+	expires := time.Now().Add(2 * time.Hour).UTC().Format(http.TimeFormat)
+
+	scenarios := []struct {
+		cacheControl string
+		expires      string
+		min          time.Duration
+		max          time.Duration
+	}{
+		{"", "", 0, 0},
+		{"max-age=3600", "", time.Hour, time.Hour},
+		{"public, Max-Age=\"600\"", "", 10 * time.Minute, 10 * time.Minute},
+		{"no-cache, max-age=3600", "", 0, 0},
+		{"max-age=invalid", expires, 0, 0},
+		{"public", expires, 119 * time.Minute, 2 * time.Hour},
+		{"", "0", 0, 0},
+		{"", "Thu, 01 Jan 1970 00:00:00 GMT", 0, 0},
+	}
+
+	for _, scenario := range scenarios {
+		r := &Response{CacheControl: scenario.cacheControl, Expires: scenario.expires}
+		if lifetime := r.CacheLifetime(); lifetime < scenario.min || lifetime > scenario.max {
+			t.Errorf(`Unexpected cache lifetime for %q/%q, got %v`, scenario.cacheControl, scenario.expires, lifetime)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	retryAt := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+
+	scenarios := []struct {
+		retryAfter string
+		min        time.Duration
+		max        time.Duration
+	}{
+		{"", 0, 0},
+		{"120", 2 * time.Minute, 2 * time.Minute},
+		{"-5", 0, 0},
+		{"invalid", 0, 0},
+		{retryAt, 59 * time.Minute, time.Hour},
+		{"Thu, 01 Jan 1970 00:00:00 GMT", 0, 0},
+	}
+
+	for _, scenario := range scenarios {
+		r := &Response{RetryAfter: scenario.retryAfter}
+		if delay := r.RetryDelay(); delay < scenario.min || delay > scenario.max {
+			t.Errorf(`Unexpected retry delay for %q, got %v`, scenario.retryAfter, delay)
+		}
+	}
+}
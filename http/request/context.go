@@ -26,6 +26,8 @@ const (
 	PocketRequestTokenContextKey
 	ClientIPContextKey
 	GoogleReaderToken
+	TwoFactorUserIDContextKey
+	WebAuthnSessionContextKey
 )
 
 // GoolgeReaderToken returns the google reader token if it exists.
@@ -110,6 +112,16 @@ func PocketRequestToken(r *http.Request) string {
 	return getContextStringValue(r, PocketRequestTokenContextKey)
 }
 
+// TwoFactorUserID returns the user who entered a valid password but not the one-time code yet.
+func TwoFactorUserID(r *http.Request) int64 {
+	return getContextInt64Value(r, TwoFactorUserIDContextKey)
+}
+
+// WebAuthnSession returns the JSON encoded state of the ongoing WebAuthn ceremony.
+func WebAuthnSession(r *http.Request) string {
+	return getContextStringValue(r, WebAuthnSessionContextKey)
+}
+
 // ClientIP returns the client IP address stored in the context.
 func ClientIP(r *http.Request) string {
 	return getContextStringValue(r, ClientIPContextKey)
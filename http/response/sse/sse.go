@@ -0,0 +1,102 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sse // import "miniflux.app/http/response/sse"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/event"
+	"miniflux.app/logger"
+)
+
+const (
+	keepAliveInterval = 30 * time.Second
+
+	// Time left to close the stream cleanly before the write timeout of the HTTP server.
+	streamTimeoutMargin = 30 * time.Second
+
+	// Number of milliseconds the web browser waits before reconnecting.
+	retryDelay = 3000
+)
+
+// Stream sends the events of the given user until the client disconnects.
+func Stream(w http.ResponseWriter, r *http.Request, broker *event.Broker, userID int64) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	events := broker.Subscribe(userID)
+	defer broker.Unsubscribe(userID, events)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", retryDelay)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	// A nil channel never fires, the stream stays open when the HTTP server has no timeout.
+	var timeout <-chan time.Time
+	if duration := maxStreamDuration(); duration > 0 {
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-timeout:
+			return
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case e := <-events:
+			if err := writeEvent(w, e); err != nil {
+				logger.Error("[SSE] Unable to send event %q to user #%d: %v", e.Type, userID, err)
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
+
+// maxStreamDuration returns how long a stream stays open, it is closed before the write timeout of the HTTP server
+// and the web browser reconnects automatically. Zero means no limit.
+func maxStreamDuration() time.Duration {
+	writeTimeout := time.Duration(config.Opts.HTTPServerTimeout()) * time.Second
+	if writeTimeout <= 0 {
+		return 0
+	}
+
+	if writeTimeout > 2*streamTimeoutMargin {
+		return writeTimeout - streamTimeoutMargin
+	}
+	return writeTimeout / 2
+}
+
+func writeEvent(w io.Writer, e *event.Event) error {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+	return err
+}
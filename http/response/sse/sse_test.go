@@ -0,0 +1,99 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sse // import "miniflux.app/http/response/sse"
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/event"
+)
+
+func TestStream(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	broker := event.NewBroker()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Stream(w, r, broker, 1)
+	}))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf(`Unexpected content type, got %q instead of %q`, contentType, "text/event-stream")
+	}
+
+	for !broker.HasSubscribers(1) {
+		time.Sleep(time.Millisecond)
+	}
+
+	broker.Publish(1, &event.Event{Type: event.EntriesStatusType, Data: &event.EntriesStatus{EntryIDs: []int64{42}, Status: "read"}})
+
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for len(lines) < 2 {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if line == "\n" || line[0] != 'e' && line[0] != 'd' {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	if lines[0] != "event: entries_status\n" {
+		t.Fatalf(`Unexpected event line, got %q`, lines[0])
+	}
+
+	if lines[1] != "data: {\"entry_ids\":[42],\"status\":\"read\"}\n" {
+		t.Fatalf(`Unexpected data line, got %q`, lines[1])
+	}
+
+	resp.Body.Close()
+
+	for i := 0; broker.HasSubscribers(1); i++ {
+		if i > 1000 {
+			t.Fatal(`The subscriber should be removed when the client disconnects`)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestMaxStreamDuration(t *testing.T) {
+	scenarios := map[string]time.Duration{
+		"300": 270 * time.Second,
+		"60":  30 * time.Second,
+		"40":  20 * time.Second,
+		"0":   0,
+		"-1":  0,
+	}
+
+	for timeout, expected := range scenarios {
+		os.Clearenv()
+		os.Setenv("HTTP_SERVER_TIMEOUT", timeout)
+
+		var err error
+		config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+		if err != nil {
+			t.Fatalf(`Parsing failure: %v`, err)
+		}
+
+		if duration := maxStreamDuration(); duration != expected {
+			t.Errorf(`Unexpected stream duration for a timeout of %s seconds, got %v instead of %v`, timeout, duration, expected)
+		}
+	}
+}
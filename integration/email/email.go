@@ -0,0 +1,160 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package email // import "miniflux.app/integration/email"
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/model"
+	"miniflux.app/version"
+)
+
+// Client represents a SMTP client.
+type Client struct {
+	host        string
+	port        int
+	username    string
+	password    string
+	fromAddress string
+}
+
+// Message represents an email, the HTML body is optional.
+type Message struct {
+	To       string
+	Subject  string
+	Body     string
+	HTMLBody string
+}
+
+// NewClient returns a new SMTP client, the credentials are optional.
+func NewClient(host string, port int, username, password, fromAddress string) *Client {
+	return &Client{host: host, port: port, username: username, password: password, fromAddress: fromAddress}
+}
+
+// SendEntries sends an email about the new entries of a feed.
+func (c *Client) SendEntries(to string, feed *model.Feed, entries model.Entries) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var builder strings.Builder
+	for _, entry := range entries {
+		builder.WriteString(entry.Title + "\n" + entry.URL + "\n\n")
+	}
+
+	subject := fmt.Sprintf("%s: %d new entries", feed.Title, len(entries))
+	if len(entries) == 1 {
+		subject = feed.Title + ": " + entries[0].Title
+	}
+
+	return c.Send(&Message{To: to, Subject: subject, Body: builder.String()})
+}
+
+// Send sends an email with the SMTP server, STARTTLS is used when the server supports it.
+func (c *Client) Send(message *Message) error {
+	if c.host == "" || c.fromAddress == "" {
+		return fmt.Errorf(`email: the SMTP server is not configured`)
+	}
+
+	if message.To == "" {
+		return fmt.Errorf(`email: missing recipient`)
+	}
+
+	data, err := c.buildMessage(message, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if c.username != "" {
+		auth = smtp.PlainAuth("", c.username, c.password, c.host)
+	}
+
+	address := net.JoinHostPort(c.host, strconv.Itoa(c.port))
+	if err := smtp.SendMail(address, auth, c.fromAddress, []string{message.To}, data); err != nil {
+		return fmt.Errorf(`email: unable to send email to %q: %v`, message.To, err)
+	}
+
+	return nil
+}
+
+func (c *Client) buildMessage(message *Message, date time.Time) ([]byte, error) {
+	if strings.ContainsAny(message.To, "\r\n") {
+		return nil, fmt.Errorf(`email: invalid recipient %q`, message.To)
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("From: " + c.fromAddress + "\r\n")
+	buffer.WriteString("To: " + message.To + "\r\n")
+	buffer.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", strings.Join(strings.Fields(message.Subject), " ")) + "\r\n")
+	buffer.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	buffer.WriteString("MIME-Version: 1.0\r\n")
+	buffer.WriteString("X-Mailer: Miniflux/" + version.Version + "\r\n")
+
+	if message.HTMLBody == "" {
+		buffer.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+		buffer.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+		buffer.WriteString("\r\n")
+
+		if err := writeQuotedPrintable(&buffer, message.Body); err != nil {
+			return nil, err
+		}
+
+		return buffer.Bytes(), nil
+	}
+
+	writer := multipart.NewWriter(&buffer)
+	buffer.WriteString("Content-Type: multipart/alternative; boundary=" + writer.Boundary() + "\r\n")
+	buffer.WriteString("\r\n")
+
+	parts := []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", message.Body},
+		{"text/html; charset=utf-8", message.HTMLBody},
+	}
+
+	for _, part := range parts {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf(`email: unable to create the message part: %v`, err)
+		}
+
+		if err := writeQuotedPrintable(partWriter, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf(`email: unable to encode the message: %v`, err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, body string) error {
+	writer := quotedprintable.NewWriter(w)
+	if _, err := writer.Write([]byte(body)); err != nil {
+		return fmt.Errorf(`email: unable to encode the message: %v`, err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf(`email: unable to encode the message: %v`, err)
+	}
+
+	return nil
+}
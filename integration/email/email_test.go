@@ -0,0 +1,76 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package email // import "miniflux.app/integration/email"
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildMessage(t *testing.T) {
+	client := NewClient("smtp.example.org", 25, "", "", "miniflux@example.org")
+	date := time.Date(2023, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	data, err := client.buildMessage(&Message{To: "me@example.org", Subject: "Café\r\nBcc: x", Body: "Entrée\nhttps://example.org/"}, date)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := string(data)
+	expectedHeaders := []string{
+		"From: miniflux@example.org\r\n",
+		"To: me@example.org\r\n",
+		"Subject: =?utf-8?q?Caf=C3=A9_Bcc:_x?=\r\n",
+		"Date: Wed, 01 Mar 2023 10:00:00 +0000\r\n",
+		"Content-Type: text/plain; charset=utf-8\r\n",
+	}
+	for _, header := range expectedHeaders {
+		if !strings.Contains(message, header) {
+			t.Errorf(`The header %q is missing from the message: %q`, header, message)
+		}
+	}
+
+	if !strings.HasSuffix(message, "\r\n\r\nEntr=C3=A9e\r\nhttps://example.org/") {
+		t.Errorf(`Unexpected body: %q`, message)
+	}
+}
+
+func TestBuildMessageWithHTMLBody(t *testing.T) {
+	client := NewClient("smtp.example.org", 25, "", "", "miniflux@example.org")
+
+	data, err := client.buildMessage(&Message{To: "me@example.org", Subject: "Digest", Body: "Entrée", HTMLBody: "<p>Entrée</p>"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := string(data)
+	expectedParts := []string{
+		"Content-Type: multipart/alternative; boundary=",
+		"Content-Type: text/plain; charset=utf-8\r\n",
+		"Content-Type: text/html; charset=utf-8\r\n",
+		"\r\n\r\nEntr=C3=A9e\r\n",
+		"\r\n\r\n<p>Entr=C3=A9e</p>\r\n",
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(message, part) {
+			t.Errorf(`%q is missing from the message: %q`, part, message)
+		}
+	}
+}
+
+func TestBuildMessageWithInvalidRecipient(t *testing.T) {
+	client := NewClient("smtp.example.org", 25, "", "", "miniflux@example.org")
+	if _, err := client.buildMessage(&Message{To: "me@example.org\r\nBcc: other@example.org"}, time.Now()); err == nil {
+		t.Fatal(`Recipients with line breaks should be rejected`)
+	}
+}
+
+func TestSendWithoutServer(t *testing.T) {
+	client := NewClient("", 25, "", "", "")
+	if err := client.Send(&Message{To: "me@example.org"}); err == nil {
+		t.Fatal(`An error should be returned when the SMTP server is not configured`)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package gotify // import "miniflux.app/integration/gotify"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"miniflux.app/model"
+	"miniflux.app/version"
+)
+
+const (
+	defaultClientTimeout = 10 * time.Second
+	defaultPriority      = 5
+)
+
+// Client represents a Gotify client, any server implementing the Gotify message API is supported.
+type Client struct {
+	serverURL string
+	token     string
+}
+
+type message struct {
+	Title    string                 `json:"title"`
+	Message  string                 `json:"message"`
+	Priority int                    `json:"priority"`
+	Extras   map[string]interface{} `json:"extras,omitempty"`
+}
+
+// NewClient returns a new Gotify client, the token is an application token.
+func NewClient(serverURL, token string) *Client {
+	return &Client{serverURL: serverURL, token: token}
+}
+
+// SendEntries sends a message about the new entries of a feed.
+func (c *Client) SendEntries(feed *model.Feed, entries model.Entries) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if c.serverURL == "" || c.token == "" {
+		return fmt.Errorf(`gotify: missing server URL or application token`)
+	}
+
+	// The server can be installed in a subfolder.
+	endpoint := strings.TrimSuffix(c.serverURL, "/") + "/message"
+
+	requestBody, err := json.Marshal(newMessage(feed, entries))
+	if err != nil {
+		return fmt.Errorf("gotify: unable to encode request body: %v", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(requestBody))
+	if err != nil {
+		return fmt.Errorf("gotify: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+	request.Header.Set("X-Gotify-Key", c.token)
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("gotify: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("gotify: incorrect response status code %d for url %s", response.StatusCode, endpoint)
+	}
+
+	return nil
+}
+
+func newMessage(feed *model.Feed, entries model.Entries) *message {
+	var builder strings.Builder
+	for _, entry := range entries {
+		builder.WriteString(fmt.Sprintf("- [%s](%s)\n", entry.Title, entry.URL))
+	}
+
+	title := feed.Title
+	if len(entries) > 1 {
+		title = fmt.Sprintf("%s (%d)", feed.Title, len(entries))
+	}
+
+	msg := &message{
+		Title:    title,
+		Message:  strings.TrimSpace(builder.String()),
+		Priority: defaultPriority,
+		Extras: map[string]interface{}{
+			"client::display": map[string]string{"contentType": "text/markdown"},
+		},
+	}
+
+	if len(entries) == 1 {
+		msg.Extras["client::notification"] = map[string]interface{}{
+			"click": map[string]string{"url": entries[0].URL},
+		}
+	}
+
+	return msg
+}
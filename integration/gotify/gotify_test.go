@@ -0,0 +1,61 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package gotify // import "miniflux.app/integration/gotify"
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestSendEntries(t *testing.T) {
+	var received message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gotify/message" {
+			t.Errorf(`Unexpected path, got %q`, r.URL.Path)
+		}
+
+		if token := r.Header.Get("X-Gotify-Key"); token != "app-token" {
+			t.Errorf(`Unexpected token, got %q`, token)
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	feed := &model.Feed{Title: "Feed"}
+	entries := model.Entries{
+		&model.Entry{Title: "Entry 1", URL: "https://example.org/1"},
+		&model.Entry{Title: "Entry 2", URL: "https://example.org/2"},
+	}
+	if err := NewClient(server.URL+"/gotify/", "app-token").SendEntries(feed, entries); err != nil {
+		t.Fatal(err)
+	}
+
+	if received.Title != "Feed (2)" {
+		t.Errorf(`Unexpected title, got %q`, received.Title)
+	}
+
+	if received.Message != "- [Entry 1](https://example.org/1)\n- [Entry 2](https://example.org/2)" {
+		t.Errorf(`Unexpected message, got %q`, received.Message)
+	}
+
+	if received.Priority != defaultPriority {
+		t.Errorf(`Unexpected priority, got %d`, received.Priority)
+	}
+}
+
+func TestSendEntriesWithoutToken(t *testing.T) {
+	feed := &model.Feed{Title: "Feed"}
+	entries := model.Entries{&model.Entry{Title: "Entry"}}
+	if err := NewClient("https://gotify.example.org", "").SendEntries(feed, entries); err == nil {
+		t.Fatal(`A missing token should be returned as an error`)
+	}
+}
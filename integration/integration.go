@@ -6,15 +6,19 @@ package integration // import "miniflux.app/integration"
 
 import (
 	"miniflux.app/config"
+	"miniflux.app/integration/email"
 	"miniflux.app/integration/espial"
+	"miniflux.app/integration/gotify"
 	"miniflux.app/integration/instapaper"
 	"miniflux.app/integration/linkding"
 	"miniflux.app/integration/matrixbot"
+	"miniflux.app/integration/ntfy"
 	"miniflux.app/integration/nunuxkeeper"
 	"miniflux.app/integration/pinboard"
 	"miniflux.app/integration/pocket"
 	"miniflux.app/integration/telegrambot"
 	"miniflux.app/integration/wallabag"
+	"miniflux.app/integration/webhook"
 	"miniflux.app/logger"
 	"miniflux.app/model"
 )
@@ -109,10 +113,45 @@ func SendEntry(entry *model.Entry, integration *model.Integration) {
 			logger.Error("[Integration] UserID #%d: %v", integration.UserID, err)
 		}
 	}
+
+	if integration.WebhookEnabled {
+		logger.Debug("[Integration] Sending Entry #%d %q for User #%d to Webhook URL: %s", entry.ID, entry.URL, integration.UserID, integration.WebhookURL)
+
+		client := webhook.NewClient(integration.WebhookURL, integration.WebhookSecret)
+		if err := client.SendSaveEntryWebhookEvent(entry); err != nil {
+			logger.Error("[Integration] UserID #%d: %v", integration.UserID, err)
+		}
+	}
 }
 
 // PushEntries pushes an entry array to third-party providers during feed refreshes.
-func PushEntries(entries model.Entries, integration *model.Integration) {
+func PushEntries(feed *model.Feed, entries model.Entries, integration *model.Integration) {
+	if integration.WebhookEnabled {
+		logger.Debug("[Integration] Sending %d entries for User #%d to Webhook URL: %s", len(entries), integration.UserID, integration.WebhookURL)
+
+		client := webhook.NewClient(integration.WebhookURL, integration.WebhookSecret)
+		if err := client.SendNewEntriesWebhookEvent(feed, entries); err != nil {
+			logger.Error("[Integration] sending entries to webhook failed: %v", err)
+		}
+	}
+}
+
+// SendNotifications notifies the user about the new entries of the feeds and categories marked as "notify".
+func SendNotifications(feed *model.Feed, entries model.Entries, integration *model.Integration) {
+	if len(entries) == 0 || !(feed.Notify || (feed.Category != nil && feed.Category.Notify)) {
+		return
+	}
+
+	if integration.TelegramBotEnabled {
+		logger.Debug("[Integration] Sending %d entries for User #%d to Telegram", len(entries), integration.UserID)
+
+		for _, entry := range entries {
+			if err := telegrambot.PushEntry(entry, integration.TelegramBotToken, integration.TelegramBotChatID); err != nil {
+				logger.Error("[Integration] push entry to telegram bot failed: %v", err)
+			}
+		}
+	}
+
 	if integration.MatrixBotEnabled {
 		logger.Debug("[Integration] Sending %d entries for User #%d to Matrix", len(entries), integration.UserID)
 
@@ -121,16 +160,37 @@ func PushEntries(entries model.Entries, integration *model.Integration) {
 			logger.Error("[Integration] push entries to matrix bot failed: %v", err)
 		}
 	}
-}
 
-// PushEntry pushes an entry to third-party providers during feed refreshes.
-func PushEntry(entry *model.Entry, integration *model.Integration) {
-	if integration.TelegramBotEnabled {
-		logger.Debug("[Integration] Sending Entry %q for User #%d to Telegram", entry.URL, integration.UserID)
+	if integration.NtfyEnabled {
+		logger.Debug("[Integration] Sending %d entries for User #%d to ntfy", len(entries), integration.UserID)
 
-		err := telegrambot.PushEntry(entry, integration.TelegramBotToken, integration.TelegramBotChatID)
-		if err != nil {
-			logger.Error("[Integration] push entry to telegram bot failed: %v", err)
+		client := ntfy.NewClient(integration.NtfyURL, integration.NtfyToken)
+		if err := client.SendEntries(feed, entries); err != nil {
+			logger.Error("[Integration] sending entries to ntfy failed: %v", err)
+		}
+	}
+
+	if integration.GotifyEnabled {
+		logger.Debug("[Integration] Sending %d entries for User #%d to Gotify", len(entries), integration.UserID)
+
+		client := gotify.NewClient(integration.GotifyURL, integration.GotifyToken)
+		if err := client.SendEntries(feed, entries); err != nil {
+			logger.Error("[Integration] sending entries to gotify failed: %v", err)
+		}
+	}
+
+	if integration.EmailNotificationEnabled && config.Opts.HasSMTP() {
+		logger.Debug("[Integration] Sending %d entries for User #%d by email", len(entries), integration.UserID)
+
+		client := email.NewClient(
+			config.Opts.SMTPHost(),
+			config.Opts.SMTPPort(),
+			config.Opts.SMTPUsername(),
+			config.Opts.SMTPPassword(),
+			config.Opts.SMTPFromAddress(),
+		)
+		if err := client.SendEntries(integration.EmailNotificationAddress, feed, entries); err != nil {
+			logger.Error("[Integration] sending entries by email failed: %v", err)
 		}
 	}
 }
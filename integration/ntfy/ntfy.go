@@ -0,0 +1,84 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ntfy // import "miniflux.app/integration/ntfy"
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"miniflux.app/model"
+	"miniflux.app/version"
+)
+
+const defaultClientTimeout = 10 * time.Second
+
+// Client represents a ntfy client, any server implementing the ntfy publishing API is supported.
+type Client struct {
+	topicURL string
+	token    string
+}
+
+// NewClient returns a new ntfy client. The token is optional and only required by protected topics.
+func NewClient(topicURL, token string) *Client {
+	return &Client{topicURL: topicURL, token: token}
+}
+
+// SendEntries publishes a notification about the new entries of a feed.
+func (c *Client) SendEntries(feed *model.Feed, entries model.Entries) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if c.topicURL == "" {
+		return fmt.Errorf(`ntfy: missing topic URL`)
+	}
+
+	title, message, clickURL := notificationContent(feed, entries)
+
+	request, err := http.NewRequest(http.MethodPost, c.topicURL, strings.NewReader(message))
+	if err != nil {
+		return fmt.Errorf("ntfy: unable to create request: %v", err)
+	}
+
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+	request.Header.Set("Title", title)
+	if clickURL != "" {
+		request.Header.Set("Click", clickURL)
+	}
+
+	if c.token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("ntfy: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("ntfy: incorrect response status code %d for url %s", response.StatusCode, c.topicURL)
+	}
+
+	return nil
+}
+
+// notificationContent returns the title, the message and the link of the notification.
+// A single entry is opened when clicking on the notification.
+func notificationContent(feed *model.Feed, entries model.Entries) (title, message, clickURL string) {
+	if len(entries) == 1 {
+		return feed.Title, entries[0].Title, entries[0].URL
+	}
+
+	var builder strings.Builder
+	for _, entry := range entries {
+		builder.WriteString(entry.Title + "\n" + entry.URL + "\n")
+	}
+
+	return fmt.Sprintf("%s (%d)", feed.Title, len(entries)), strings.TrimSpace(builder.String()), ""
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ntfy // import "miniflux.app/integration/ntfy"
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestSendSingleEntry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/miniflux" {
+			t.Errorf(`Unexpected topic, got %q`, r.URL.Path)
+		}
+
+		if header := r.Header.Get("Authorization"); header != "Bearer tk_secret" {
+			t.Errorf(`Unexpected authorization header, got %q`, header)
+		}
+
+		if title := r.Header.Get("Title"); title != "Feed" {
+			t.Errorf(`Unexpected title, got %q`, title)
+		}
+
+		if click := r.Header.Get("Click"); click != "https://example.org/entry" {
+			t.Errorf(`Unexpected click URL, got %q`, click)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "Entry" {
+			t.Errorf(`Unexpected message, got %q`, body)
+		}
+	}))
+	defer server.Close()
+
+	feed := &model.Feed{Title: "Feed"}
+	entries := model.Entries{&model.Entry{Title: "Entry", URL: "https://example.org/entry"}}
+	if err := NewClient(server.URL+"/miniflux", "tk_secret").SendEntries(feed, entries); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSendMultipleEntries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if header := r.Header.Get("Authorization"); header != "" {
+			t.Errorf(`The authorization header should not be sent without token, got %q`, header)
+		}
+
+		if title := r.Header.Get("Title"); title != "Feed (2)" {
+			t.Errorf(`Unexpected title, got %q`, title)
+		}
+
+		if click := r.Header.Get("Click"); click != "" {
+			t.Errorf(`The click URL should not be set for multiple entries, got %q`, click)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "Entry 1\nhttps://example.org/1\nEntry 2\nhttps://example.org/2" {
+			t.Errorf(`Unexpected message, got %q`, body)
+		}
+	}))
+	defer server.Close()
+
+	feed := &model.Feed{Title: "Feed"}
+	entries := model.Entries{
+		&model.Entry{Title: "Entry 1", URL: "https://example.org/1"},
+		&model.Entry{Title: "Entry 2", URL: "https://example.org/2"},
+	}
+	if err := NewClient(server.URL+"/miniflux", "").SendEntries(feed, entries); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSendEntriesWithErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	feed := &model.Feed{Title: "Feed"}
+	entries := model.Entries{&model.Entry{Title: "Entry"}}
+	if err := NewClient(server.URL, "").SendEntries(feed, entries); err == nil {
+		t.Fatal(`An error status should be returned as an error`)
+	}
+}
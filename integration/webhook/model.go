@@ -0,0 +1,103 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package webhook // import "miniflux.app/integration/webhook"
+
+import (
+	"time"
+
+	"miniflux.app/model"
+)
+
+// Feed represents the feed sent in webhook payloads.
+type Feed struct {
+	ID         int64     `json:"id"`
+	UserID     int64     `json:"user_id"`
+	CategoryID int64     `json:"category_id"`
+	FeedURL    string    `json:"feed_url"`
+	SiteURL    string    `json:"site_url"`
+	Title      string    `json:"title"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+// Entry represents the entry sent in webhook payloads.
+type Entry struct {
+	ID          int64               `json:"id"`
+	UserID      int64               `json:"user_id"`
+	FeedID      int64               `json:"feed_id"`
+	Status      string              `json:"status"`
+	Hash        string              `json:"hash"`
+	Title       string              `json:"title"`
+	URL         string              `json:"url"`
+	CommentsURL string              `json:"comments_url"`
+	Date        time.Time           `json:"published_at"`
+	CreatedAt   time.Time           `json:"created_at"`
+	ChangedAt   time.Time           `json:"changed_at"`
+	Content     string              `json:"content"`
+	Author      string              `json:"author"`
+	ShareCode   string              `json:"share_code"`
+	Starred     bool                `json:"starred"`
+	ReadingTime int                 `json:"reading_time"`
+	Enclosures  model.EnclosureList `json:"enclosures"`
+	Tags        []string            `json:"tags"`
+	Feed        *Feed               `json:"feed,omitempty"`
+}
+
+// NewEntriesEvent is the payload sent when new entries are discovered during a feed refresh.
+type NewEntriesEvent struct {
+	EventType string   `json:"event_type"`
+	Feed      *Feed    `json:"feed"`
+	Entries   []*Entry `json:"entries"`
+}
+
+// SaveEntryEvent is the payload sent when the user saves an entry.
+type SaveEntryEvent struct {
+	EventType string `json:"event_type"`
+	Entry     *Entry `json:"entry"`
+}
+
+func newFeed(feed *model.Feed) *Feed {
+	if feed == nil {
+		return nil
+	}
+
+	webhookFeed := &Feed{
+		ID:        feed.ID,
+		UserID:    feed.UserID,
+		FeedURL:   feed.FeedURL,
+		SiteURL:   feed.SiteURL,
+		Title:     feed.Title,
+		CheckedAt: feed.CheckedAt,
+	}
+
+	if feed.Category != nil {
+		webhookFeed.CategoryID = feed.Category.ID
+	}
+
+	return webhookFeed
+}
+
+func newEntry(entry *model.Entry) *Entry {
+	return &Entry{
+		ID:          entry.ID,
+		UserID:      entry.UserID,
+		FeedID:      entry.FeedID,
+		Status:      entry.Status,
+		Hash:        entry.Hash,
+		Title:       entry.Title,
+		URL:         entry.URL,
+		CommentsURL: entry.CommentsURL,
+		Date:        entry.Date,
+		CreatedAt:   entry.CreatedAt,
+		ChangedAt:   entry.ChangedAt,
+		Content:     entry.Content,
+		Author:      entry.Author,
+		ShareCode:   entry.ShareCode,
+		Starred:     entry.Starred,
+		ReadingTime: entry.ReadingTime,
+		Enclosures:  entry.Enclosures,
+		Tags:        entry.Tags,
+		Feed:        newFeed(entry.Feed),
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package webhook // import "miniflux.app/integration/webhook"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"miniflux.app/crypto"
+	"miniflux.app/model"
+	"miniflux.app/version"
+)
+
+// Webhook event types.
+const (
+	NewEntriesEventType = "new_entries"
+	SaveEntryEventType  = "save_entry"
+)
+
+const defaultClientTimeout = 10 * time.Second
+
+// Client represents a Webhook client.
+type Client struct {
+	webhookURL    string
+	webhookSecret string
+}
+
+// NewClient returns a new Webhook client.
+func NewClient(webhookURL, webhookSecret string) *Client {
+	return &Client{webhookURL: webhookURL, webhookSecret: webhookSecret}
+}
+
+// SendSaveEntryWebhookEvent sends a "save_entry" event to the webhook endpoint.
+func (c *Client) SendSaveEntryWebhookEvent(entry *model.Entry) error {
+	return c.makeRequest(SaveEntryEventType, &SaveEntryEvent{
+		EventType: SaveEntryEventType,
+		Entry:     newEntry(entry),
+	})
+}
+
+// SendNewEntriesWebhookEvent sends a "new_entries" event to the webhook endpoint.
+func (c *Client) SendNewEntriesWebhookEvent(feed *model.Feed, entries model.Entries) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	webhookEntries := make([]*Entry, 0, len(entries))
+	for _, entry := range entries {
+		webhookEntries = append(webhookEntries, newEntry(entry))
+	}
+
+	return c.makeRequest(NewEntriesEventType, &NewEntriesEvent{
+		EventType: NewEntriesEventType,
+		Feed:      newFeed(feed),
+		Entries:   webhookEntries,
+	})
+}
+
+func (c *Client) makeRequest(eventType string, payload interface{}) error {
+	if c.webhookURL == "" {
+		return fmt.Errorf(`webhook: missing webhook URL`)
+	}
+
+	requestBody, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("webhook: unable to encode request body: %v", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, c.webhookURL, bytes.NewReader(requestBody))
+	if err != nil {
+		return fmt.Errorf("webhook: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+	request.Header.Set("X-Miniflux-Signature", crypto.GenerateSHA256Hmac(c.webhookSecret, requestBody))
+	request.Header.Set("X-Miniflux-Event-Type", eventType)
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("webhook: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("webhook: incorrect response status code %d for url %s", response.StatusCode, c.webhookURL)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package webhook // import "miniflux.app/integration/webhook"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestSendNewEntriesWebhookEventSignature(t *testing.T) {
+	secret := "my-secret"
+
+	var receivedEvent NewEntriesEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		expectedSignature := hex.EncodeToString(mac.Sum(nil))
+
+		if signature := r.Header.Get("X-Miniflux-Signature"); signature != expectedSignature {
+			t.Errorf(`Unexpected signature, got %q instead of %q`, signature, expectedSignature)
+		}
+
+		if eventType := r.Header.Get("X-Miniflux-Event-Type"); eventType != NewEntriesEventType {
+			t.Errorf(`Unexpected event type, got %q instead of %q`, eventType, NewEntriesEventType)
+		}
+
+		if err := json.Unmarshal(body, &receivedEvent); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	feed := &model.Feed{ID: 1, Title: "Feed"}
+	entries := model.Entries{&model.Entry{Title: "Entry 1"}, &model.Entry{Title: "Entry 2"}}
+
+	client := NewClient(server.URL, secret)
+	if err := client.SendNewEntriesWebhookEvent(feed, entries); err != nil {
+		t.Fatal(err)
+	}
+
+	if receivedEvent.EventType != NewEntriesEventType {
+		t.Errorf(`Unexpected event type in payload: %q`, receivedEvent.EventType)
+	}
+
+	if len(receivedEvent.Entries) != 2 {
+		t.Errorf(`Unexpected number of entries: %d`, len(receivedEvent.Entries))
+	}
+
+	if receivedEvent.Feed == nil || receivedEvent.Feed.Title != "Feed" {
+		t.Errorf(`Unexpected feed in payload: %v`, receivedEvent.Feed)
+	}
+}
+
+func TestSendSaveEntryWebhookEventWithServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "secret")
+	if err := client.SendSaveEntryWebhookEvent(&model.Entry{Title: "Entry"}); err == nil {
+		t.Error(`An error should be returned when the server fails`)
+	}
+}
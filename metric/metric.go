@@ -7,6 +7,7 @@ package metric // import "miniflux.app/metric"
 import (
 	"time"
 
+	"miniflux.app/http/client"
 	"miniflux.app/logger"
 	"miniflux.app/storage"
 
@@ -134,6 +135,54 @@ var (
 			Help:      "The total number of connections closed due to SetConnMaxLifetime",
 		},
 	)
+
+	httpClientActiveHostsGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "http_client_active_hosts",
+			Help:      "The number of hosts currently receiving requests",
+		},
+	)
+
+	httpClientActiveRequestsGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "http_client_active_requests",
+			Help:      "The number of requests currently sent to external hosts",
+		},
+	)
+
+	httpClientWaitingRequestsGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "http_client_waiting_requests",
+			Help:      "The number of requests waiting for the concurrency limit of their host",
+		},
+	)
+
+	httpClientThrottledHostsGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "http_client_throttled_hosts",
+			Help:      "The number of hosts that asked to slow down with a 429 or 503 status code",
+		},
+	)
+
+	httpClientDelayedRequestsGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "http_client_delayed_requests",
+			Help:      "The total number of requests delayed by the per-host limits",
+		},
+	)
+
+	httpClientWaitDurationGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "http_client_wait_duration_seconds",
+			Help:      "The total time requests waited because of the per-host limits",
+		},
+	)
 )
 
 // Collector represents a metric collector.
@@ -158,11 +207,17 @@ func NewCollector(store *storage.Storage, refreshInterval int) *Collector {
 	prometheus.MustRegister(dbConnectionsMaxIdleClosedGauge)
 	prometheus.MustRegister(dbConnectionsMaxIdleTimeClosedGauge)
 	prometheus.MustRegister(dbConnectionsMaxLifetimeClosedGauge)
+	prometheus.MustRegister(httpClientActiveHostsGauge)
+	prometheus.MustRegister(httpClientActiveRequestsGauge)
+	prometheus.MustRegister(httpClientWaitingRequestsGauge)
+	prometheus.MustRegister(httpClientThrottledHostsGauge)
+	prometheus.MustRegister(httpClientDelayedRequestsGauge)
+	prometheus.MustRegister(httpClientWaitDurationGauge)
 
 	return &Collector{store, refreshInterval}
 }
 
-// GatherStorageMetrics polls the database to fetch metrics.
+// GatherStorageMetrics polls the database and the HTTP client to fetch metrics.
 func (c *Collector) GatherStorageMetrics() {
 	for range time.Tick(time.Duration(c.refreshInterval) * time.Second) {
 		logger.Debug("[Metric] Collecting database metrics")
@@ -188,5 +243,13 @@ func (c *Collector) GatherStorageMetrics() {
 		dbConnectionsMaxIdleClosedGauge.Set(float64(dbStats.MaxIdleClosed))
 		dbConnectionsMaxIdleTimeClosedGauge.Set(float64(dbStats.MaxIdleTimeClosed))
 		dbConnectionsMaxLifetimeClosedGauge.Set(float64(dbStats.MaxLifetimeClosed))
+
+		hostStats := client.HostStats()
+		httpClientActiveHostsGauge.Set(float64(hostStats.ActiveHosts))
+		httpClientActiveRequestsGauge.Set(float64(hostStats.ActiveRequests))
+		httpClientWaitingRequestsGauge.Set(float64(hostStats.WaitingRequests))
+		httpClientThrottledHostsGauge.Set(float64(hostStats.ThrottledHosts))
+		httpClientDelayedRequestsGauge.Set(float64(hostStats.DelayedRequests))
+		httpClientWaitDurationGauge.Set(hostStats.WaitDuration.Seconds())
 	}
 }
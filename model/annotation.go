@@ -0,0 +1,48 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"fmt"
+	"time"
+)
+
+// Annotation represents a highlight or a note attached to an entry.
+//
+// The offsets are character positions in the text of the entry content, they are zero when the annotation is only a note.
+type Annotation struct {
+	ID          int64     `json:"id"`
+	UserID      int64     `json:"user_id"`
+	EntryID     int64     `json:"entry_id"`
+	Quote       string    `json:"quote"`
+	StartOffset int       `json:"start_offset"`
+	EndOffset   int       `json:"end_offset"`
+	Note        string    `json:"note"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (a *Annotation) String() string {
+	return fmt.Sprintf("ID=%d, UserID=%d, EntryID=%d, Quote=%q", a.ID, a.UserID, a.EntryID, a.Quote)
+}
+
+// Annotations represents a list of annotations.
+type Annotations []*Annotation
+
+// AnnotationRequest represents the request to create or update an annotation.
+type AnnotationRequest struct {
+	Quote       string `json:"quote"`
+	StartOffset int    `json:"start_offset"`
+	EndOffset   int    `json:"end_offset"`
+	Note        string `json:"note"`
+}
+
+// Patch updates annotation fields.
+func (r *AnnotationRequest) Patch(annotation *Annotation) {
+	annotation.Quote = r.Quote
+	annotation.StartOffset = r.StartOffset
+	annotation.EndOffset = r.EndOffset
+	annotation.Note = r.Note
+}
@@ -20,11 +20,15 @@ type SessionData struct {
 	Language           string `json:"language"`
 	Theme              string `json:"theme"`
 	PocketRequestToken string `json:"pocket_request_token"`
+	WebAuthnSession    string `json:"webauthn_session"`
+
+	// The session fields are updated as text, the numbers are encoded as JSON strings.
+	TwoFactorUserID int64 `json:"two_factor_user_id,string"`
 }
 
 func (s SessionData) String() string {
-	return fmt.Sprintf(`CSRF=%q, OAuth2State=%q, FlashMsg=%q, FlashErrMsg=%q, Lang=%q, Theme=%q, PocketTkn=%q`,
-		s.CSRF, s.OAuth2State, s.FlashMessage, s.FlashErrorMessage, s.Language, s.Theme, s.PocketRequestToken)
+	return fmt.Sprintf(`CSRF=%q, OAuth2State=%q, FlashMsg=%q, FlashErrMsg=%q, Lang=%q, Theme=%q, PocketTkn=%q, TwoFactorUserID=%d`,
+		s.CSRF, s.OAuth2State, s.FlashMessage, s.FlashErrorMessage, s.Language, s.Theme, s.PocketRequestToken, s.TwoFactorUserID)
 }
 
 // Value converts the session data to JSON.
@@ -35,8 +39,13 @@ func (s SessionData) Value() (driver.Value, error) {
 
 // Scan converts raw JSON data.
 func (s *SessionData) Scan(src interface{}) error {
-	source, ok := src.([]byte)
-	if !ok {
+	var source []byte
+	switch v := src.(type) {
+	case []byte:
+		source = v
+	case string:
+		source = []byte(v)
+	default:
 		return errors.New("session: unable to assert type of src")
 	}
 
@@ -8,12 +8,14 @@ import "fmt"
 
 // Category represents a feed category.
 type Category struct {
-	ID           int64  `json:"id"`
-	Title        string `json:"title"`
-	UserID       int64  `json:"user_id"`
-	HideGlobally bool   `json:"hide_globally"`
-	FeedCount    int    `json:"-"`
-	TotalUnread  int    `json:"-"`
+	ID                int64  `json:"id"`
+	Title             string `json:"title"`
+	UserID            int64  `json:"user_id"`
+	HideGlobally      bool   `json:"hide_globally"`
+	Notify            bool   `json:"notify"`
+	ExcludeFromDigest bool   `json:"exclude_from_digest"`
+	FeedCount         int    `json:"-"`
+	TotalUnread       int    `json:"-"`
 }
 
 func (c *Category) String() string {
@@ -22,14 +24,18 @@ func (c *Category) String() string {
 
 // CategoryRequest represents the request to create or update a category.
 type CategoryRequest struct {
-	Title        string `json:"title"`
-	HideGlobally string `json:"hide_globally"`
+	Title             string `json:"title"`
+	HideGlobally      string `json:"hide_globally"`
+	Notify            string `json:"notify"`
+	ExcludeFromDigest string `json:"exclude_from_digest"`
 }
 
 // Patch updates category fields.
 func (cr *CategoryRequest) Patch(category *Category) {
 	category.Title = cr.Title
 	category.HideGlobally = cr.HideGlobally != ""
+	category.Notify = cr.Notify != ""
+	category.ExcludeFromDigest = cr.ExcludeFromDigest != ""
 }
 
 // Categories represents a list of categories.
@@ -0,0 +1,33 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import "time"
+
+// Email digest frequencies.
+const (
+	DigestFrequencyNone   = "none"
+	DigestFrequencyDaily  = "daily"
+	DigestFrequencyWeekly = "weekly"
+)
+
+// DigestFrequencies returns the list of available digest frequencies.
+func DigestFrequencies() map[string]string {
+	return map[string]string{
+		DigestFrequencyNone:   "form.integration.email_digest_frequency.none",
+		DigestFrequencyDaily:  "form.integration.email_digest_frequency.daily",
+		DigestFrequencyWeekly: "form.integration.email_digest_frequency.weekly",
+	}
+}
+
+// DigestRecipient represents a user who receives the email digest.
+type DigestRecipient struct {
+	UserID    int64
+	Language  string
+	Timezone  string
+	Address   string
+	Frequency string
+	SentAt    *time.Time
+}
@@ -6,13 +6,46 @@ package model // import "miniflux.app/model"
 
 // Enclosure represents an attachment.
 type Enclosure struct {
-	ID       int64  `json:"id"`
-	UserID   int64  `json:"user_id"`
-	EntryID  int64  `json:"entry_id"`
-	URL      string `json:"url"`
-	MimeType string `json:"mime_type"`
-	Size     int64  `json:"size"`
+	ID               int64             `json:"id"`
+	UserID           int64             `json:"user_id"`
+	EntryID          int64             `json:"entry_id"`
+	URL              string            `json:"url"`
+	MimeType         string            `json:"mime_type"`
+	Size             int64             `json:"size"`
+	Duration         int               `json:"duration"`
+	ArtworkURL       string            `json:"artwork_url"`
+	Chapters         EnclosureChapters `json:"chapters"`
+	ChaptersURL      string            `json:"chapters_url"`
+	MediaProgression int               `json:"media_progression"`
+	Played           bool              `json:"played"`
 }
 
+// EnclosureChapter represents a chapter of an audio or video attachment.
+type EnclosureChapter struct {
+	// Start is the offset of the chapter in seconds.
+	Start int    `json:"start"`
+	Title string `json:"title"`
+}
+
+// EnclosureChapters represents a list of chapters.
+type EnclosureChapters []*EnclosureChapter
+
 // EnclosureList represents a list of attachments.
 type EnclosureList []*Enclosure
+
+// EnclosureUpdateRequest represents a request to update the playback state of an attachment.
+type EnclosureUpdateRequest struct {
+	MediaProgression *int  `json:"media_progression"`
+	Played           *bool `json:"played"`
+}
+
+// Patch updates the playback state of the attachment.
+func (e *EnclosureUpdateRequest) Patch(enclosure *Enclosure) {
+	if e.MediaProgression != nil {
+		enclosure.MediaProgression = *e.MediaProgression
+	}
+
+	if e.Played != nil {
+		enclosure.Played = *e.Played
+	}
+}
@@ -19,24 +19,27 @@ const (
 
 // Entry represents a feed item in the system.
 type Entry struct {
-	ID          int64         `json:"id"`
-	UserID      int64         `json:"user_id"`
-	FeedID      int64         `json:"feed_id"`
-	Status      string        `json:"status"`
-	Hash        string        `json:"hash"`
-	Title       string        `json:"title"`
-	URL         string        `json:"url"`
-	CommentsURL string        `json:"comments_url"`
-	Date        time.Time     `json:"published_at"`
-	CreatedAt   time.Time     `json:"created_at"`
-	ChangedAt   time.Time     `json:"changed_at"`
-	Content     string        `json:"content"`
-	Author      string        `json:"author"`
-	ShareCode   string        `json:"share_code"`
-	Starred     bool          `json:"starred"`
-	ReadingTime int           `json:"reading_time"`
-	Enclosures  EnclosureList `json:"enclosures"`
-	Feed        *Feed         `json:"feed,omitempty"`
+	ID           int64         `json:"id"`
+	UserID       int64         `json:"user_id"`
+	FeedID       int64         `json:"feed_id"`
+	Status       string        `json:"status"`
+	Hash         string        `json:"hash"`
+	Title        string        `json:"title"`
+	URL          string        `json:"url"`
+	CommentsURL  string        `json:"comments_url"`
+	Date         time.Time     `json:"published_at"`
+	CreatedAt    time.Time     `json:"created_at"`
+	ChangedAt    time.Time     `json:"changed_at"`
+	Content      string        `json:"content"`
+	Author       string        `json:"author"`
+	ShareCode    string        `json:"share_code"`
+	ShareComment string        `json:"share_comment"`
+	Starred      bool          `json:"starred"`
+	ReadingTime  int           `json:"reading_time"`
+	Enclosures   EnclosureList `json:"enclosures"`
+	Tags         []string      `json:"tags"`
+	Annotations  Annotations   `json:"annotations,omitempty"`
+	Feed         *Feed         `json:"feed,omitempty"`
 }
 
 // Entries represents a list of entries.
@@ -17,6 +17,9 @@ import (
 const (
 	SchedulerRoundRobin     = "round_robin"
 	SchedulerEntryFrequency = "entry_frequency"
+	SchedulerAdaptive       = "adaptive"
+	// The URL of a newsletter identifies the feed, it doesn't contain the token that receives the emails.
+	NewsletterURLPrefix = "newsletter:"
 	// Default settings for the feed query builder
 	DefaultFeedSorting          = "parsing_error_count"
 	DefaultFeedSortingDirection = "desc"
@@ -24,37 +27,52 @@ const (
 
 // Feed represents a feed in the application.
 type Feed struct {
-	ID                          int64     `json:"id"`
-	UserID                      int64     `json:"user_id"`
-	FeedURL                     string    `json:"feed_url"`
-	SiteURL                     string    `json:"site_url"`
-	Title                       string    `json:"title"`
-	CheckedAt                   time.Time `json:"checked_at"`
-	NextCheckAt                 time.Time `json:"next_check_at"`
-	EtagHeader                  string    `json:"etag_header"`
-	LastModifiedHeader          string    `json:"last_modified_header"`
-	ParsingErrorMsg             string    `json:"parsing_error_message"`
-	ParsingErrorCount           int       `json:"parsing_error_count"`
-	ScraperRules                string    `json:"scraper_rules"`
-	RewriteRules                string    `json:"rewrite_rules"`
-	Crawler                     bool      `json:"crawler"`
-	BlocklistRules              string    `json:"blocklist_rules"`
-	KeeplistRules               string    `json:"keeplist_rules"`
-	UrlRewriteRules             string    `json:"urlrewrite_rules"`
-	UserAgent                   string    `json:"user_agent"`
-	Cookie                      string    `json:"cookie"`
-	Username                    string    `json:"username"`
-	Password                    string    `json:"password"`
-	Disabled                    bool      `json:"disabled"`
-	IgnoreHTTPCache             bool      `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates bool      `json:"allow_self_signed_certificates"`
-	FetchViaProxy               bool      `json:"fetch_via_proxy"`
-	Category                    *Category `json:"category,omitempty"`
-	Entries                     Entries   `json:"entries,omitempty"`
-	Icon                        *FeedIcon `json:"icon"`
-	HideGlobally                bool      `json:"hide_globally"`
-	UnreadCount                 int       `json:"-"`
-	ReadCount                   int       `json:"-"`
+	ID                          int64        `json:"id"`
+	UserID                      int64        `json:"user_id"`
+	FeedURL                     string       `json:"feed_url"`
+	SiteURL                     string       `json:"site_url"`
+	Title                       string       `json:"title"`
+	CheckedAt                   time.Time    `json:"checked_at"`
+	NextCheckAt                 time.Time    `json:"next_check_at"`
+	EtagHeader                  string       `json:"etag_header"`
+	LastModifiedHeader          string       `json:"last_modified_header"`
+	ParsingErrorMsg             string       `json:"parsing_error_message"`
+	ParsingErrorCount           int          `json:"parsing_error_count"`
+	ScraperRules                string       `json:"scraper_rules"`
+	RewriteRules                string       `json:"rewrite_rules"`
+	Crawler                     bool         `json:"crawler"`
+	BlocklistRules              string       `json:"blocklist_rules"`
+	KeeplistRules               string       `json:"keeplist_rules"`
+	UrlRewriteRules             string       `json:"urlrewrite_rules"`
+	UserAgent                   string       `json:"user_agent"`
+	Cookie                      string       `json:"cookie"`
+	Username                    string       `json:"username"`
+	Password                    string       `json:"password"`
+	Disabled                    bool         `json:"disabled"`
+	IgnoreHTTPCache             bool         `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates bool         `json:"allow_self_signed_certificates"`
+	FetchViaProxy               bool         `json:"fetch_via_proxy"`
+	Category                    *Category    `json:"category,omitempty"`
+	Entries                     Entries      `json:"entries,omitempty"`
+	Icon                        *FeedIcon    `json:"icon"`
+	HideGlobally                bool         `json:"hide_globally"`
+	CacheMedia                  bool         `json:"cache_media"`
+	Notify                      bool         `json:"notify"`
+	NewsletterToken             string       `json:"-"`
+	ItemSelector                string       `json:"item_selector"`
+	TitleSelector               string       `json:"title_selector"`
+	LinkSelector                string       `json:"link_selector"`
+	DateSelector                string       `json:"date_selector"`
+	ContentSelector             string       `json:"content_selector"`
+	PermanentRedirectURL        string       `json:"permanent_redirect_url"`
+	Gone                        bool         `json:"gone"`
+	PollingHints                PollingHints `json:"-"`
+	UnreadCount                 int          `json:"-"`
+	ReadCount                   int          `json:"-"`
+
+	// WebSub hub and topic advertised by the feed document, they are not stored in the database.
+	HubURL   string `json:"-"`
+	TopicURL string `json:"-"`
 }
 
 type FeedCounters struct {
@@ -73,11 +91,50 @@ func (f *Feed) String() string {
 	)
 }
 
+// IsNewsletter returns true if the entries of the feed are received by email instead of being polled.
+func (f *Feed) IsNewsletter() bool {
+	return f.NewsletterToken != ""
+}
+
+// NewsletterAddress returns the email address that receives the newsletter.
+func (f *Feed) NewsletterAddress() string {
+	if !f.IsNewsletter() {
+		return ""
+	}
+	return f.NewsletterToken + "@" + config.Opts.NewsletterDomain()
+}
+
+// IsScraped returns true if the entries of the feed are extracted from a web page with CSS selectors.
+func (f *Feed) IsScraped() bool {
+	return f.ItemSelector != ""
+}
+
 // WithClientResponse updates feed attributes from an HTTP request.
 func (f *Feed) WithClientResponse(response *client.Response) {
+	f.WithCacheHeaders(response)
+	f.FeedURL = response.EffectiveURL
+}
+
+// WithCacheHeaders updates the caching headers of the feed from an HTTP request.
+func (f *Feed) WithCacheHeaders(response *client.Response) {
 	f.EtagHeader = response.ETag
 	f.LastModifiedHeader = response.LastModified
-	f.FeedURL = response.EffectiveURL
+}
+
+// WithHealthStatus records whether the feed has been removed or moved permanently by its publisher.
+func (f *Feed) WithHealthStatus(response *client.Response) {
+	f.Gone = response.IsGone()
+	f.PermanentRedirectURL = response.PermanentRedirectURL
+}
+
+// MigrateURL moves the feed to a new URL, the caching headers and the health status of the previous URL are discarded.
+func (f *Feed) MigrateURL(feedURL string) {
+	f.FeedURL = feedURL
+	f.EtagHeader = ""
+	f.LastModifiedHeader = ""
+	f.PermanentRedirectURL = ""
+	f.Gone = false
+	f.ResetErrorCounter()
 }
 
 // WithCategoryID initializes the category attribute of the feed.
@@ -110,20 +167,48 @@ func (f *Feed) CheckedNow() {
 func (f *Feed) ScheduleNextCheck(weeklyCount int) {
 	switch config.Opts.PollingScheduler() {
 	case SchedulerEntryFrequency:
-		var intervalMinutes int
-		if weeklyCount == 0 {
-			intervalMinutes = config.Opts.SchedulerEntryFrequencyMaxInterval()
-		} else {
-			intervalMinutes = int(math.Round(float64(7*24*60) / float64(weeklyCount)))
+		f.NextCheckAt = time.Now().Add(time.Minute * time.Duration(entryFrequencyInterval(weeklyCount)))
+	case SchedulerAdaptive:
+		// The hints of the publisher are lower bounds, but the feed is always polled within the maximum interval.
+		interval := time.Minute * time.Duration(entryFrequencyInterval(weeklyCount))
+		maxInterval := time.Minute * time.Duration(config.Opts.SchedulerEntryFrequencyMaxInterval())
+		if minInterval := f.PollingHints.MinInterval(); minInterval > interval {
+			interval = minInterval
 		}
-		intervalMinutes = int(math.Min(float64(intervalMinutes), float64(config.Opts.SchedulerEntryFrequencyMaxInterval())))
-		intervalMinutes = int(math.Max(float64(intervalMinutes), float64(config.Opts.SchedulerEntryFrequencyMinInterval())))
-		f.NextCheckAt = time.Now().Add(time.Minute * time.Duration(intervalMinutes))
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+		f.NextCheckAt = f.PollingHints.NextAllowedTime(time.Now().Add(interval))
 	default:
 		f.NextCheckAt = time.Now()
 	}
 }
 
+// DelayNextCheck postpones the next check of a feed updated by push notifications.
+func (f *Feed) DelayNextCheck(intervalMinutes int) {
+	f.DelayNextCheckUntil(time.Now().Add(time.Minute * time.Duration(intervalMinutes)))
+}
+
+// DelayNextCheckUntil postpones the next check of a feed to the given time.
+func (f *Feed) DelayNextCheckUntil(nextCheckAt time.Time) {
+	if nextCheckAt.After(f.NextCheckAt) {
+		f.NextCheckAt = nextCheckAt
+	}
+}
+
+// entryFrequencyInterval returns the average number of minutes between two entries, within the configured limits.
+func entryFrequencyInterval(weeklyCount int) int {
+	var intervalMinutes int
+	if weeklyCount == 0 {
+		intervalMinutes = config.Opts.SchedulerEntryFrequencyMaxInterval()
+	} else {
+		intervalMinutes = int(math.Round(float64(7*24*60) / float64(weeklyCount)))
+	}
+	intervalMinutes = int(math.Min(float64(intervalMinutes), float64(config.Opts.SchedulerEntryFrequencyMaxInterval())))
+	intervalMinutes = int(math.Max(float64(intervalMinutes), float64(config.Opts.SchedulerEntryFrequencyMinInterval())))
+	return intervalMinutes
+}
+
 // FeedCreationRequest represents the request to create a feed.
 type FeedCreationRequest struct {
 	FeedURL                     string `json:"feed_url"`
@@ -143,6 +228,13 @@ type FeedCreationRequest struct {
 	KeeplistRules               string `json:"keeplist_rules"`
 	HideGlobally                bool   `json:"hide_globally"`
 	UrlRewriteRules             string `json:"urlrewrite_rules"`
+	CacheMedia                  bool   `json:"cache_media"`
+	Notify                      bool   `json:"notify"`
+	ItemSelector                string `json:"item_selector"`
+	TitleSelector               string `json:"title_selector"`
+	LinkSelector                string `json:"link_selector"`
+	DateSelector                string `json:"date_selector"`
+	ContentSelector             string `json:"content_selector"`
 }
 
 // FeedModificationRequest represents the request to update a feed.
@@ -166,6 +258,13 @@ type FeedModificationRequest struct {
 	AllowSelfSignedCertificates *bool   `json:"allow_self_signed_certificates"`
 	FetchViaProxy               *bool   `json:"fetch_via_proxy"`
 	HideGlobally                *bool   `json:"hide_globally"`
+	CacheMedia                  *bool   `json:"cache_media"`
+	Notify                      *bool   `json:"notify"`
+	ItemSelector                *string `json:"item_selector"`
+	TitleSelector               *string `json:"title_selector"`
+	LinkSelector                *string `json:"link_selector"`
+	DateSelector                *string `json:"date_selector"`
+	ContentSelector             *string `json:"content_selector"`
 }
 
 // Patch updates a feed with modified values.
@@ -245,6 +344,35 @@ func (f *FeedModificationRequest) Patch(feed *Feed) {
 	if f.HideGlobally != nil {
 		feed.HideGlobally = *f.HideGlobally
 	}
+
+	if f.CacheMedia != nil {
+		feed.CacheMedia = *f.CacheMedia
+	}
+
+	if f.Notify != nil {
+		feed.Notify = *f.Notify
+	}
+
+	// The item selector can't be removed, a scraped feed can't be converted into a regular feed.
+	if f.ItemSelector != nil && *f.ItemSelector != "" && feed.IsScraped() {
+		feed.ItemSelector = *f.ItemSelector
+	}
+
+	if f.TitleSelector != nil {
+		feed.TitleSelector = *f.TitleSelector
+	}
+
+	if f.LinkSelector != nil {
+		feed.LinkSelector = *f.LinkSelector
+	}
+
+	if f.DateSelector != nil {
+		feed.DateSelector = *f.DateSelector
+	}
+
+	if f.ContentSelector != nil {
+		feed.ContentSelector = *f.ContentSelector
+	}
 }
 
 // Feeds is a list of feed
@@ -0,0 +1,92 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"sort"
+	"time"
+)
+
+// List of feed health statuses, from the most to the least urgent.
+const (
+	FeedHealthGone       = "gone"
+	FeedHealthRedirected = "redirected"
+	FeedHealthFailing    = "failing"
+	FeedHealthStale      = "stale"
+)
+
+var feedHealthPriorities = map[string]int{
+	FeedHealthGone:       0,
+	FeedHealthRedirected: 1,
+	FeedHealthFailing:    2,
+	FeedHealthStale:      3,
+}
+
+// A feed is failing when its last refreshes failed at least this number of times in a row.
+const feedHealthFailingErrorCount = 2
+
+// FeedHealth represents a feed that requires the attention of the user.
+type FeedHealth struct {
+	Feed        *Feed      `json:"feed"`
+	Status      string     `json:"status"`
+	LastEntryAt *time.Time `json:"last_entry_at"`
+}
+
+// IsGone returns true if the publisher has removed the feed.
+func (f *FeedHealth) IsGone() bool {
+	return f.Status == FeedHealthGone
+}
+
+// IsRedirected returns true if the feed has moved permanently to another URL.
+func (f *FeedHealth) IsRedirected() bool {
+	return f.Status == FeedHealthRedirected
+}
+
+// IsFailing returns true if the feed cannot be refreshed anymore.
+func (f *FeedHealth) IsFailing() bool {
+	return f.Status == FeedHealthFailing
+}
+
+// IsStale returns true if the feed did not publish anything recently.
+func (f *FeedHealth) IsStale() bool {
+	return f.Status == FeedHealthStale
+}
+
+// CheckFeedHealth classifies a feed, nil is returned when the feed is healthy.
+//
+// Disabled feeds are not refreshed, they are always considered healthy.
+// A feed without any entry is stale.
+func CheckFeedHealth(feed *Feed, lastEntryAt *time.Time, staleDays int) *FeedHealth {
+	if feed.Disabled {
+		return nil
+	}
+
+	health := &FeedHealth{Feed: feed, LastEntryAt: lastEntryAt}
+
+	switch {
+	case feed.Gone:
+		health.Status = FeedHealthGone
+	case feed.PermanentRedirectURL != "":
+		health.Status = FeedHealthRedirected
+	case feed.ParsingErrorCount >= feedHealthFailingErrorCount:
+		health.Status = FeedHealthFailing
+	case staleDays > 0 && (lastEntryAt == nil || lastEntryAt.Before(time.Now().AddDate(0, 0, -staleDays))):
+		health.Status = FeedHealthStale
+	default:
+		return nil
+	}
+
+	return health
+}
+
+// FeedHealthReport represents the list of feeds that require the attention of the user.
+type FeedHealthReport []*FeedHealth
+
+// SortByPriority sorts the report from the most to the least urgent status, the order of feeds with the same status is preserved.
+func (r FeedHealthReport) SortByPriority() {
+	sort.SliceStable(r, func(i, j int) bool {
+		return feedHealthPriorities[r[i].Status] < feedHealthPriorities[r[j].Status]
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckFeedHealth(t *testing.T) {
+	recent := time.Now().AddDate(0, 0, -1)
+	old := time.Now().AddDate(0, 0, -100)
+
+	scenarios := []struct {
+		name        string
+		feed        *Feed
+		lastEntryAt *time.Time
+		expected    string
+	}{
+		{"healthy", &Feed{}, &recent, ""},
+		{"gone", &Feed{Gone: true, PermanentRedirectURL: "https://example.org/new", ParsingErrorCount: 5}, &old, FeedHealthGone},
+		{"redirected", &Feed{PermanentRedirectURL: "https://example.org/new", ParsingErrorCount: 5}, &old, FeedHealthRedirected},
+		{"failing", &Feed{ParsingErrorCount: 2}, &old, FeedHealthFailing},
+		{"single error", &Feed{ParsingErrorCount: 1}, &recent, ""},
+		{"stale", &Feed{}, &old, FeedHealthStale},
+		{"without entries", &Feed{}, nil, FeedHealthStale},
+		{"disabled", &Feed{Disabled: true, Gone: true}, nil, ""},
+	}
+
+	for _, scenario := range scenarios {
+		health := CheckFeedHealth(scenario.feed, scenario.lastEntryAt, 30)
+
+		if scenario.expected == "" {
+			if health != nil {
+				t.Errorf(`The feed %q should be healthy, got %q`, scenario.name, health.Status)
+			}
+			continue
+		}
+
+		if health == nil {
+			t.Errorf(`The feed %q should not be healthy`, scenario.name)
+		} else if health.Status != scenario.expected {
+			t.Errorf(`Unexpected status for the feed %q, got %q instead of %q`, scenario.name, health.Status, scenario.expected)
+		}
+	}
+}
+
+func TestCheckFeedHealthWithoutStaleDays(t *testing.T) {
+	if health := CheckFeedHealth(&Feed{}, nil, 0); health != nil {
+		t.Errorf(`Feeds should never be stale when the number of days is not defined, got %q`, health.Status)
+	}
+}
+
+func TestFeedHealthReportSortByPriority(t *testing.T) {
+	report := FeedHealthReport{
+		{Feed: &Feed{ID: 1}, Status: FeedHealthStale},
+		{Feed: &Feed{ID: 2}, Status: FeedHealthFailing},
+		{Feed: &Feed{ID: 3}, Status: FeedHealthGone},
+		{Feed: &Feed{ID: 4}, Status: FeedHealthStale},
+		{Feed: &Feed{ID: 5}, Status: FeedHealthRedirected},
+	}
+	report.SortByPriority()
+
+	expected := []int64{3, 5, 2, 1, 4}
+	for i, health := range report {
+		if health.Feed.ID != expected[i] {
+			t.Fatalf(`Unexpected feed at position %d, got #%d instead of #%d`, i, health.Feed.ID, expected[i])
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"time"
+
+	"miniflux.app/crypto"
+)
+
+// Kinds of feed outputs.
+const (
+	FeedOutputCategory    = "category"
+	FeedOutputStarred     = "starred"
+	FeedOutputSavedSearch = "search"
+)
+
+// FeedOutput represents a secret token giving access to a stream of entries as Atom, RSS or JSON Feed.
+type FeedOutput struct {
+	ID            int64
+	UserID        int64
+	Kind          string
+	CategoryID    int64
+	SavedSearchID int64
+	Title         string
+	Token         string
+	LastUsedAt    *time.Time
+	CreatedAt     time.Time
+}
+
+// NewFeedOutput initializes a new FeedOutput, the target is ignored for starred entries.
+func NewFeedOutput(userID int64, kind string, targetID int64) *FeedOutput {
+	output := &FeedOutput{
+		UserID: userID,
+		Kind:   kind,
+		Token:  crypto.GenerateRandomStringHex(20),
+	}
+
+	switch kind {
+	case FeedOutputCategory:
+		output.CategoryID = targetID
+	case FeedOutputSavedSearch:
+		output.SavedSearchID = targetID
+	}
+
+	return output
+}
+
+// TargetID returns the category or saved search of the output.
+func (f *FeedOutput) TargetID() int64 {
+	switch f.Kind {
+	case FeedOutputCategory:
+		return f.CategoryID
+	case FeedOutputSavedSearch:
+		return f.SavedSearchID
+	default:
+		return 0
+	}
+}
+
+// FeedOutputs represents a list of feed outputs.
+type FeedOutputs []*FeedOutput
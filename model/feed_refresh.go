@@ -0,0 +1,47 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"time"
+
+	"miniflux.app/http/client"
+)
+
+// FeedRefresh represents the diagnostic of a feed refresh.
+type FeedRefresh struct {
+	ID             int64     `json:"id"`
+	FeedID         int64     `json:"feed_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	StatusCode     int       `json:"status_code"`
+	EffectiveURL   string    `json:"effective_url"`
+	ResponseSize   int64     `json:"response_size"`
+	Duration       int       `json:"duration"`
+	EtagHeader     string    `json:"etag_header"`
+	NotModified    bool      `json:"not_modified"`
+	NewEntries     int       `json:"new_entries"`
+	UpdatedEntries int       `json:"updated_entries"`
+	ErrorMsg       string    `json:"error_message"`
+}
+
+// NewFeedRefresh initializes the diagnostic of a feed refresh.
+func NewFeedRefresh(feedID int64) *FeedRefresh {
+	return &FeedRefresh{FeedID: feedID, CreatedAt: time.Now()}
+}
+
+// WithClientResponse records the attributes of the HTTP response.
+func (f *FeedRefresh) WithClientResponse(response *client.Response) {
+	f.StatusCode = response.StatusCode
+	f.EffectiveURL = response.EffectiveURL
+	f.EtagHeader = response.ETag
+}
+
+// Finished records the duration of the refresh in milliseconds.
+func (f *FeedRefresh) Finished() {
+	f.Duration = int(time.Since(f.CreatedAt).Milliseconds())
+}
+
+// FeedRefreshes represents the refresh history of a feed.
+type FeedRefreshes []*FeedRefresh
@@ -5,8 +5,10 @@
 package model // import "miniflux.app/model"
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -33,6 +35,73 @@ func TestFeedWithResponse(t *testing.T) {
 	}
 }
 
+func TestFeedWithCacheHeaders(t *testing.T) {
+	response := &client.Response{ETag: "Some etag", LastModified: "Some date", EffectiveURL: "Some URL"}
+
+	feed := &Feed{FeedURL: "Original URL"}
+	feed.WithCacheHeaders(response)
+
+	if feed.EtagHeader != "Some etag" || feed.LastModifiedHeader != "Some date" {
+		t.Fatal(`The caching headers should be set`)
+	}
+
+	if feed.FeedURL != "Original URL" {
+		t.Fatal(`The Feed URL should not be changed`)
+	}
+}
+
+func TestFeedWithHealthStatus(t *testing.T) {
+	feed := &Feed{FeedURL: "https://example.org/feed"}
+	feed.WithHealthStatus(&client.Response{StatusCode: 410, PermanentRedirectURL: "https://example.org/new"})
+
+	if !feed.Gone {
+		t.Error(`The feed should be gone`)
+	}
+
+	if feed.PermanentRedirectURL != "https://example.org/new" {
+		t.Errorf(`Unexpected permanent redirect URL: %q`, feed.PermanentRedirectURL)
+	}
+
+	if feed.FeedURL != "https://example.org/feed" {
+		t.Error(`The Feed URL should not be changed`)
+	}
+
+	feed.WithHealthStatus(&client.Response{StatusCode: 200})
+
+	if feed.Gone || feed.PermanentRedirectURL != "" {
+		t.Error(`The health status should be reset`)
+	}
+}
+
+func TestFeedMigrateURL(t *testing.T) {
+	feed := &Feed{
+		FeedURL:              "https://example.org/feed",
+		EtagHeader:           "Some etag",
+		LastModifiedHeader:   "Some date",
+		PermanentRedirectURL: "https://example.org/new",
+		Gone:                 true,
+		ParsingErrorCount:    3,
+		ParsingErrorMsg:      "Some error",
+	}
+	feed.MigrateURL("https://example.org/new")
+
+	if feed.FeedURL != "https://example.org/new" {
+		t.Errorf(`Unexpected Feed URL: %q`, feed.FeedURL)
+	}
+
+	if feed.EtagHeader != "" || feed.LastModifiedHeader != "" {
+		t.Error(`The caching headers should be removed`)
+	}
+
+	if feed.PermanentRedirectURL != "" || feed.Gone {
+		t.Error(`The health status should be reset`)
+	}
+
+	if feed.ParsingErrorCount != 0 || feed.ParsingErrorMsg != "" {
+		t.Error(`The errors should be reset`)
+	}
+}
+
 func TestFeedCategorySetter(t *testing.T) {
 	feed := &Feed{}
 	feed.WithCategoryID(int64(123))
@@ -153,3 +222,102 @@ func TestFeedScheduleNextCheckEntryCountBasedMinInterval(t *testing.T) {
 		t.Error(`The next_check_at should not be before the now + min interval`)
 	}
 }
+
+func TestFeedDelayNextCheck(t *testing.T) {
+	feed := &Feed{}
+	feed.DelayNextCheck(60)
+
+	if feed.NextCheckAt.Before(time.Now().Add(time.Minute * 59)) {
+		t.Error(`The next_check_at should be delayed by the given interval`)
+	}
+}
+
+func TestFeedDelayNextCheckKeepsLaterCheck(t *testing.T) {
+	nextCheckAt := time.Now().Add(time.Hour * 48)
+	feed := &Feed{NextCheckAt: nextCheckAt}
+	feed.DelayNextCheck(60)
+
+	if !feed.NextCheckAt.Equal(nextCheckAt) {
+		t.Error(`The next_check_at should not be moved earlier`)
+	}
+}
+
+func TestFeedScheduleNextCheckAdaptiveWithPollingHints(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("POLLING_SCHEDULER", "adaptive")
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL", "1440")
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MIN_INTERVAL", "5")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	// One entry per hour, but the publisher asks to wait three hours.
+	feed := &Feed{PollingHints: PollingHints{UpdateInterval: 180}}
+	feed.ScheduleNextCheck(7 * 24)
+
+	if feed.NextCheckAt.Before(time.Now().Add(time.Minute * 179)) {
+		t.Error(`The next_check_at should honor the update interval of the publisher`)
+	}
+
+	// The publisher hints never postpone the refresh after the maximum interval.
+	feed = &Feed{PollingHints: PollingHints{CacheLifetime: 7 * 24 * 60}}
+	feed.ScheduleNextCheck(7 * 24)
+
+	if feed.NextCheckAt.After(time.Now().Add(time.Minute * 1440)) {
+		t.Error(`The next_check_at should not be after the now + max interval`)
+	}
+}
+
+func TestFeedScheduleNextCheckAdaptiveWithoutPollingHints(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("POLLING_SCHEDULER", "adaptive")
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MIN_INTERVAL", "5")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	feed := &Feed{}
+	feed.ScheduleNextCheck(7 * 24)
+
+	if feed.NextCheckAt.After(time.Now().Add(time.Minute * 61)) {
+		t.Error(`The next_check_at should follow the entry frequency`)
+	}
+}
+
+func TestFeedDelayNextCheckUntil(t *testing.T) {
+	feed := &Feed{NextCheckAt: time.Now()}
+	until := time.Now().Add(time.Hour)
+	feed.DelayNextCheckUntil(until)
+
+	if !feed.NextCheckAt.Equal(until) {
+		t.Error(`The next_check_at should be delayed until the given time`)
+	}
+
+	feed.DelayNextCheckUntil(time.Now())
+	if !feed.NextCheckAt.Equal(until) {
+		t.Error(`The next_check_at should not be moved earlier`)
+	}
+}
+
+func TestFeedJSONWithoutInternalFields(t *testing.T) {
+	feed := &Feed{NewsletterToken: "0123456789abcdef", PollingHints: PollingHints{UpdateInterval: 60}}
+
+	data, err := json.Marshal(feed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, field := range []string{"newsletter_token", "0123456789abcdef", "polling_hints"} {
+		if strings.Contains(string(data), field) {
+			t.Errorf(`The JSON document should not contain %q`, field)
+		}
+	}
+}
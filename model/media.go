@@ -0,0 +1,14 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+// Media represents a file downloaded in the media cache, files are identified by the checksum of their content.
+type Media struct {
+	ID       int64
+	Hash     string
+	MimeType string
+	Size     int64
+	Content  []byte
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var syndicationPeriods = map[string]int{
+	"hourly":  60,
+	"daily":   24 * 60,
+	"weekly":  7 * 24 * 60,
+	"monthly": 30 * 24 * 60,
+	"yearly":  365 * 24 * 60,
+}
+
+// PollingHints represents the refresh hints published by a feed and its web server.
+//
+// Intervals are lower bounds, polling the feed more often would not give anything new.
+// Skip hours and days are expressed in GMT, as defined by the RSS specification.
+type PollingHints struct {
+	UpdateInterval int            `json:"update_interval,omitempty"`
+	CacheLifetime  int            `json:"cache_lifetime,omitempty"`
+	SkipHours      []int          `json:"skip_hours,omitempty"`
+	SkipDays       []time.Weekday `json:"skip_days,omitempty"`
+}
+
+// WithTTL records the number of minutes the feed can be cached, from the RSS "ttl" element.
+func (h *PollingHints) WithTTL(value string) {
+	if ttl, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && ttl > h.UpdateInterval {
+		h.UpdateInterval = ttl
+	}
+}
+
+// WithSyndication records the update schedule from the "sy:updatePeriod" and "sy:updateFrequency" elements.
+func (h *PollingHints) WithSyndication(period, frequency string) {
+	periodMinutes, found := syndicationPeriods[strings.ToLower(strings.TrimSpace(period))]
+	if !found {
+		return
+	}
+
+	// The default frequency is once per period.
+	updates := 1
+	if n, err := strconv.Atoi(strings.TrimSpace(frequency)); err == nil && n > 0 {
+		updates = n
+	}
+
+	if interval := periodMinutes / updates; interval > h.UpdateInterval {
+		h.UpdateInterval = interval
+	}
+}
+
+// WithSkipHours records the hours when the feed should not be polled, invalid values are ignored.
+func (h *PollingHints) WithSkipHours(values []string) {
+	h.SkipHours = nil
+	for _, value := range values {
+		hour, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || hour < 0 || hour > 24 {
+			continue
+		}
+
+		// Some publishers use 24 for midnight.
+		h.SkipHours = append(h.SkipHours, hour%24)
+	}
+}
+
+// WithSkipDays records the days when the feed should not be polled, invalid values are ignored.
+func (h *PollingHints) WithSkipDays(values []string) {
+	h.SkipDays = nil
+	for _, value := range values {
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.EqualFold(strings.TrimSpace(value), day.String()) {
+				h.SkipDays = append(h.SkipDays, day)
+			}
+		}
+	}
+}
+
+// WithCacheLifetime records for how long the HTTP response can be cached.
+func (h *PollingHints) WithCacheLifetime(lifetime time.Duration) {
+	h.CacheLifetime = int(lifetime.Minutes())
+}
+
+// MinInterval returns the shortest interval between two refreshes advertised by the publisher.
+func (h *PollingHints) MinInterval() time.Duration {
+	minutes := h.UpdateInterval
+	if h.CacheLifetime > minutes {
+		minutes = h.CacheLifetime
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// NextAllowedTime returns the first time from the given one that is not in the skip hours or days.
+func (h *PollingHints) NextAllowedTime(t time.Time) time.Time {
+	if len(h.SkipHours) == 0 && len(h.SkipDays) == 0 {
+		return t
+	}
+
+	next := t
+	for i := 0; i < 7*24; i++ {
+		if !h.isSkipped(next) {
+			return next
+		}
+		next = next.UTC().Truncate(time.Hour).Add(time.Hour)
+	}
+
+	// Everything is skipped, the hints are ignored.
+	return t
+}
+
+func (h *PollingHints) isSkipped(t time.Time) bool {
+	t = t.UTC()
+
+	for _, hour := range h.SkipHours {
+		if t.Hour() == hour {
+			return true
+		}
+	}
+
+	for _, day := range h.SkipDays {
+		if t.Weekday() == day {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Value converts the polling hints to JSON.
+func (h PollingHints) Value() (driver.Value, error) {
+	return json.Marshal(h)
+}
+
+// Scan converts raw JSON data.
+func (h *PollingHints) Scan(src interface{}) error {
+	var source []byte
+	switch v := src.(type) {
+	case []byte:
+		source = v
+	case string:
+		source = []byte(v)
+	default:
+		return errors.New("polling hints: unable to assert type of src")
+	}
+
+	if err := json.Unmarshal(source, h); err != nil {
+		return fmt.Errorf("polling hints: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPollingHintsWithTTL(t *testing.T) {
+	var hints PollingHints
+	hints.WithTTL(" 60 ")
+	if hints.UpdateInterval != 60 {
+		t.Errorf(`Unexpected update interval, got %d instead of 60`, hints.UpdateInterval)
+	}
+
+	hints.WithTTL("invalid")
+	if hints.UpdateInterval != 60 {
+		t.Errorf(`Invalid values should be ignored, got %d`, hints.UpdateInterval)
+	}
+}
+
+func TestPollingHintsWithSyndication(t *testing.T) {
+	scenarios := []struct {
+		period    string
+		frequency string
+		expected  int
+	}{
+		{"hourly", "", 60},
+		{"daily", "2", 720},
+		{"Weekly", "1", 10080},
+		{"monthly", "0", 43200},
+		{"yearly", "invalid", 525600},
+		{"invalid", "1", 0},
+		{"", "", 0},
+	}
+
+	for _, scenario := range scenarios {
+		var hints PollingHints
+		hints.WithSyndication(scenario.period, scenario.frequency)
+		if hints.UpdateInterval != scenario.expected {
+			t.Errorf(`Unexpected update interval for %q/%q, got %d instead of %d`, scenario.period, scenario.frequency, hints.UpdateInterval, scenario.expected)
+		}
+	}
+}
+
+func TestPollingHintsKeepLongestUpdateInterval(t *testing.T) {
+	var hints PollingHints
+	hints.WithSyndication("daily", "1")
+	hints.WithTTL("60")
+	if hints.UpdateInterval != 1440 {
+		t.Errorf(`The longest interval should be kept, got %d`, hints.UpdateInterval)
+	}
+}
+
+func TestPollingHintsWithSkipHoursAndDays(t *testing.T) {
+	var hints PollingHints
+	hints.WithSkipHours([]string{"0", " 5", "24", "25", "invalid"})
+	hints.WithSkipDays([]string{"Saturday", "sunday ", "Someday"})
+
+	if expected := []int{0, 5, 0}; !reflect.DeepEqual(hints.SkipHours, expected) {
+		t.Errorf(`Unexpected skip hours, got %v instead of %v`, hints.SkipHours, expected)
+	}
+
+	if expected := []time.Weekday{time.Saturday, time.Sunday}; !reflect.DeepEqual(hints.SkipDays, expected) {
+		t.Errorf(`Unexpected skip days, got %v instead of %v`, hints.SkipDays, expected)
+	}
+}
+
+func TestPollingHintsMinInterval(t *testing.T) {
+	hints := PollingHints{UpdateInterval: 30}
+	hints.WithCacheLifetime(2 * time.Hour)
+
+	if interval := hints.MinInterval(); interval != 2*time.Hour {
+		t.Errorf(`Unexpected minimum interval, got %v`, interval)
+	}
+
+	hints.WithCacheLifetime(0)
+	if interval := hints.MinInterval(); interval != 30*time.Minute {
+		t.Errorf(`Unexpected minimum interval, got %v`, interval)
+	}
+}
+
+func TestPollingHintsNextAllowedTime(t *testing.T) {
+	// Friday.
+	friday := time.Date(2023, time.March, 3, 22, 30, 0, 0, time.UTC)
+
+	hints := PollingHints{}
+	if next := hints.NextAllowedTime(friday); !next.Equal(friday) {
+		t.Errorf(`The time should not change without hints, got %v`, next)
+	}
+
+	hints = PollingHints{SkipHours: []int{22, 23}}
+	if next, expected := hints.NextAllowedTime(friday), time.Date(2023, time.March, 4, 0, 0, 0, 0, time.UTC); !next.Equal(expected) {
+		t.Errorf(`Unexpected next allowed time, got %v instead of %v`, next, expected)
+	}
+
+	hints = PollingHints{SkipHours: []int{22, 23}, SkipDays: []time.Weekday{time.Saturday, time.Sunday}}
+	if next, expected := hints.NextAllowedTime(friday), time.Date(2023, time.March, 6, 0, 0, 0, 0, time.UTC); !next.Equal(expected) {
+		t.Errorf(`Unexpected next allowed time, got %v instead of %v`, next, expected)
+	}
+
+	hints = PollingHints{SkipDays: []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}}
+	if next := hints.NextAllowedTime(friday); !next.Equal(friday) {
+		t.Errorf(`The hints should be ignored when every day is skipped, got %v`, next)
+	}
+}
+
+func TestPollingHintsValueAndScan(t *testing.T) {
+	hints := PollingHints{UpdateInterval: 60, CacheLifetime: 10, SkipHours: []int{1}, SkipDays: []time.Weekday{time.Sunday}}
+
+	value, err := hints.Value()
+	if err != nil {
+		t.Fatalf(`Unable to convert the hints: %v`, err)
+	}
+
+	var scanned PollingHints
+	if err := scanned.Scan(value); err != nil {
+		t.Fatalf(`Unable to scan the hints: %v`, err)
+	}
+
+	if !reflect.DeepEqual(hints, scanned) {
+		t.Errorf(`Unexpected hints, got %+v instead of %+v`, scanned, hints)
+	}
+
+	var empty PollingHints
+	if err := empty.Scan("{}"); err != nil || empty.MinInterval() != 0 {
+		t.Errorf(`Unable to scan empty hints: %v`, err)
+	}
+
+	if err := empty.Scan(42); err == nil {
+		t.Error(`Scanning an invalid type should fail`)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"fmt"
+	"time"
+)
+
+// SavedSearch represents a search query saved as a virtual feed.
+//
+// The search can be restricted to a category, a feed or an entry status, zero values mean no restriction.
+type SavedSearch struct {
+	ID          int64     `json:"id"`
+	UserID      int64     `json:"user_id"`
+	Title       string    `json:"title"`
+	Query       string    `json:"query"`
+	CategoryID  int64     `json:"category_id"`
+	FeedID      int64     `json:"feed_id"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	TotalUnread int       `json:"total_unread"`
+}
+
+func (s *SavedSearch) String() string {
+	return fmt.Sprintf("ID=%d, UserID=%d, Title=%s, Query=%s", s.ID, s.UserID, s.Title, s.Query)
+}
+
+// SavedSearches represents a list of saved searches.
+type SavedSearches []*SavedSearch
+
+// SavedSearchRequest represents the request to create or update a saved search.
+type SavedSearchRequest struct {
+	Title      string `json:"title"`
+	Query      string `json:"query"`
+	CategoryID int64  `json:"category_id"`
+	FeedID     int64  `json:"feed_id"`
+	Status     string `json:"status"`
+}
+
+// Patch updates saved search fields.
+func (r *SavedSearchRequest) Patch(search *SavedSearch) {
+	search.Title = r.Title
+	search.Query = r.Query
+	search.CategoryID = r.CategoryID
+	search.FeedID = r.FeedID
+	search.Status = r.Status
+}
@@ -0,0 +1,28 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import "fmt"
+
+// Tag represents a user-defined label attached to entries.
+type Tag struct {
+	ID          int64  `json:"id"`
+	UserID      int64  `json:"user_id"`
+	Title       string `json:"title"`
+	EntryCount  int    `json:"entry_count"`
+	TotalUnread int    `json:"total_unread"`
+}
+
+func (t *Tag) String() string {
+	return fmt.Sprintf("ID=%d, UserID=%d, Title=%s", t.ID, t.UserID, t.Title)
+}
+
+// Tags represents a list of tags.
+type Tags []*Tag
+
+// EntryTagsRequest represents the request to replace the tags of an entry.
+type EntryTagsRequest struct {
+	Tags []string `json:"tags"`
+}
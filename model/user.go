@@ -35,6 +35,7 @@ type User struct {
 	CJKReadingSpeed        int        `json:"cjk_reading_speed"`
 	DefaultHomePage        string     `json:"default_home_page"`
 	CategoriesSortingOrder string     `json:"categories_sorting_order"`
+	FilterRules            string     `json:"filter_rules"`
 }
 
 // UserCreationRequest represents the request to create a user.
@@ -69,6 +70,7 @@ type UserModificationRequest struct {
 	CJKReadingSpeed        *int    `json:"cjk_reading_speed"`
 	DefaultHomePage        *string `json:"default_home_page"`
 	CategoriesSortingOrder *string `json:"categories_sorting_order"`
+	FilterRules            *string `json:"filter_rules"`
 }
 
 // Patch updates the User object with the modification request.
@@ -156,6 +158,10 @@ func (u *UserModificationRequest) Patch(user *User) {
 	if u.CategoriesSortingOrder != nil {
 		user.CategoriesSortingOrder = *u.CategoriesSortingOrder
 	}
+
+	if u.FilterRules != nil {
+		user.FilterRules = *u.FilterRules
+	}
 }
 
 // UseTimezone converts last login date to the given timezone.
@@ -0,0 +1,32 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// WebAuthnCredential represents a passkey or a security key registered by a user.
+//
+// Each credential has its own random handle, the authenticator returns it during a passkey login to identify the user.
+type WebAuthnCredential struct {
+	ID         int64
+	UserID     int64
+	Handle     []byte
+	Name       string
+	Credential webauthn.Credential
+	CreatedAt  time.Time
+	LastSeenAt *time.Time
+}
+
+func (c *WebAuthnCredential) String() string {
+	return fmt.Sprintf("ID=%d, UserID=%d, Name=%q", c.ID, c.UserID, c.Name)
+}
+
+// WebAuthnCredentials represents a list of WebAuthn credentials.
+type WebAuthnCredentials []*WebAuthnCredential
@@ -0,0 +1,52 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"time"
+
+	"miniflux.app/crypto"
+)
+
+// WebSub subscription states.
+const (
+	WebSubStatePending      = "pending"
+	WebSubStateSubscribed   = "subscribed"
+	WebSubStateUnsubscribed = "unsubscribed"
+	WebSubStateDenied       = "denied"
+)
+
+// WebSubSubscription represents the subscription of a feed to a WebSub hub.
+type WebSubSubscription struct {
+	FeedID         int64
+	UserID         int64
+	HubURL         string
+	TopicURL       string
+	Secret         string
+	CallbackToken  string
+	State          string
+	LeaseExpiresAt *time.Time
+	CreatedAt      time.Time
+}
+
+// NewWebSubSubscription initializes a pending subscription with a new secret.
+func NewWebSubSubscription(feedID int64, hubURL, topicURL string) *WebSubSubscription {
+	return &WebSubSubscription{
+		FeedID:        feedID,
+		HubURL:        hubURL,
+		TopicURL:      topicURL,
+		Secret:        crypto.GenerateRandomStringHex(32),
+		CallbackToken: crypto.GenerateRandomStringHex(16),
+		State:         WebSubStatePending,
+	}
+}
+
+// IsActive returns true if the hub confirmed the subscription and the lease is not expired.
+func (s *WebSubSubscription) IsActive() bool {
+	return s.State == WebSubStateSubscribed && s.LeaseExpiresAt != nil && s.LeaseExpiresAt.After(time.Now())
+}
+
+// WebSubSubscriptions represents a list of WebSub subscriptions.
+type WebSubSubscriptions []*WebSubSubscription
@@ -6,6 +6,7 @@ package oauth2 // import "miniflux.app/oauth2"
 
 import (
 	"context"
+	"strings"
 
 	"miniflux.app/model"
 
@@ -17,6 +18,8 @@ type oidcProvider struct {
 	clientID     string
 	clientSecret string
 	redirectURL  string
+	groupsClaim  string
+	extraScopes  []string
 	provider     *oidc.Provider
 }
 
@@ -41,9 +44,46 @@ func (o *oidcProvider) GetProfile(ctx context.Context, code string) (*Profile, e
 	}
 
 	profile := &Profile{Key: o.GetUserExtraKey(), ID: userInfo.Subject, Username: userInfo.Email}
+
+	if o.groupsClaim != "" {
+		if profile.Groups, err = o.groups(ctx, token, userInfo); err != nil {
+			return nil, err
+		}
+	}
+
 	return profile, nil
 }
 
+// The groups are read from the userinfo response first, some providers only put them in the ID token.
+func (o *oidcProvider) groups(ctx context.Context, token *oauth2.Token, userInfo *oidc.UserInfo) ([]string, error) {
+	var claims map[string]interface{}
+	if err := userInfo.Claims(&claims); err != nil {
+		return nil, err
+	}
+
+	if groups, found := groupsFromClaims(claims, o.groupsClaim); found {
+		return groups, nil
+	}
+
+	rawIDToken, found := token.Extra("id_token").(string)
+	if !found {
+		return []string{}, nil
+	}
+
+	idToken, err := o.provider.Verifier(&oidc.Config{ClientID: o.clientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, err
+	}
+
+	claims = nil
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, err
+	}
+
+	groups, _ := groupsFromClaims(claims, o.groupsClaim)
+	return groups, nil
+}
+
 func (o *oidcProvider) PopulateUserCreationWithProfileID(user *model.UserCreationRequest, profile *Profile) {
 	user.OpenIDConnectID = profile.ID
 }
@@ -61,16 +101,60 @@ func (o *oidcProvider) config() *oauth2.Config {
 		RedirectURL:  o.redirectURL,
 		ClientID:     o.clientID,
 		ClientSecret: o.clientSecret,
-		Scopes:       []string{"openid", "email"},
+		Scopes:       append([]string{"openid", "email"}, o.extraScopes...),
 		Endpoint:     o.provider.Endpoint(),
 	}
 }
 
-func newOidcProvider(ctx context.Context, clientID, clientSecret, redirectURL, discoveryEndpoint string) (*oidcProvider, error) {
+func newOidcProvider(ctx context.Context, clientID, clientSecret, redirectURL, discoveryEndpoint, groupsClaim string, extraScopes []string) (*oidcProvider, error) {
 	provider, err := oidc.NewProvider(ctx, discoveryEndpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	return &oidcProvider{clientID: clientID, clientSecret: clientSecret, redirectURL: redirectURL, provider: provider}, nil
+	return &oidcProvider{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		groupsClaim:  groupsClaim,
+		extraScopes:  extraScopes,
+		provider:     provider,
+	}, nil
+}
+
+// groupsFromClaims returns the groups stored in the given claim, as a list or as a single string.
+//
+// Nested claims like "realm_access.roles" are supported when no claim has the exact name.
+func groupsFromClaims(claims map[string]interface{}, name string) ([]string, bool) {
+	value, found := claims[name]
+	if !found {
+		var current interface{} = claims
+		for _, key := range strings.Split(name, ".") {
+			object, isObject := current.(map[string]interface{})
+			if !isObject {
+				return []string{}, false
+			}
+
+			if current, found = object[key]; !found {
+				return []string{}, false
+			}
+		}
+		value = current
+	}
+
+	groups := []string{}
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			groups = append(groups, v)
+		}
+	case []interface{}:
+		for _, item := range v {
+			if group, isString := item.(string); isString && group != "" {
+				groups = append(groups, group)
+			}
+		}
+	}
+
+	return groups, true
 }
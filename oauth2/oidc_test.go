@@ -0,0 +1,86 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package oauth2 // import "miniflux.app/oauth2"
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func parseClaims(t *testing.T, data string) map[string]interface{} {
+	var claims map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &claims); err != nil {
+		t.Fatal(err)
+	}
+	return claims
+}
+
+func TestGroupsFromClaimsWithList(t *testing.T) {
+	claims := parseClaims(t, `{"sub": "123", "groups": ["readers", "admins", 42, ""]}`)
+
+	groups, found := groupsFromClaims(claims, "groups")
+	if !found {
+		t.Fatal(`The claim should be found`)
+	}
+
+	if expected := []string{"readers", "admins"}; !reflect.DeepEqual(groups, expected) {
+		t.Errorf(`Unexpected groups, got %v instead of %v`, groups, expected)
+	}
+}
+
+func TestGroupsFromClaimsWithString(t *testing.T) {
+	claims := parseClaims(t, `{"role": "admins"}`)
+
+	groups, found := groupsFromClaims(claims, "role")
+	if !found {
+		t.Fatal(`The claim should be found`)
+	}
+
+	if expected := []string{"admins"}; !reflect.DeepEqual(groups, expected) {
+		t.Errorf(`Unexpected groups, got %v instead of %v`, groups, expected)
+	}
+}
+
+func TestGroupsFromClaimsWithNestedClaim(t *testing.T) {
+	claims := parseClaims(t, `{"realm_access": {"roles": ["miniflux-admin"]}}`)
+
+	groups, found := groupsFromClaims(claims, "realm_access.roles")
+	if !found {
+		t.Fatal(`The nested claim should be found`)
+	}
+
+	if expected := []string{"miniflux-admin"}; !reflect.DeepEqual(groups, expected) {
+		t.Errorf(`Unexpected groups, got %v instead of %v`, groups, expected)
+	}
+}
+
+func TestGroupsFromClaimsWithDottedClaimName(t *testing.T) {
+	claims := parseClaims(t, `{"https://example.org/groups": ["readers"]}`)
+
+	groups, found := groupsFromClaims(claims, "https://example.org/groups")
+	if !found {
+		t.Fatal(`The claim should be found`)
+	}
+
+	if expected := []string{"readers"}; !reflect.DeepEqual(groups, expected) {
+		t.Errorf(`Unexpected groups, got %v instead of %v`, groups, expected)
+	}
+}
+
+func TestGroupsFromClaimsWithMissingClaim(t *testing.T) {
+	claims := parseClaims(t, `{"sub": "123", "realm_access": "invalid"}`)
+
+	for _, name := range []string{"groups", "realm_access.roles"} {
+		groups, found := groupsFromClaims(claims, name)
+		if found {
+			t.Errorf(`The claim %q should not be found`, name)
+		}
+
+		if len(groups) != 0 {
+			t.Errorf(`No groups should be returned for %q, got %v`, name, groups)
+		}
+	}
+}
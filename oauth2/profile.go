@@ -13,8 +13,11 @@ type Profile struct {
 	Key      string
 	ID       string
 	Username string
+
+	// Groups is nil when the provider is not configured to read the groups of the user.
+	Groups []string
 }
 
 func (p Profile) String() string {
-	return fmt.Sprintf(`Key=%s ; ID=%s ; Username=%s`, p.Key, p.ID, p.Username)
+	return fmt.Sprintf(`Key=%s ; ID=%s ; Username=%s ; Groups=%v`, p.Key, p.ID, p.Username, p.Groups)
 }
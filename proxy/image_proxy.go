@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"miniflux.app/config"
+	"miniflux.app/model"
 	"miniflux.app/reader/sanitizer"
 	"miniflux.app/url"
 
@@ -19,7 +20,7 @@ type urlProxyRewriter func(router *mux.Router, url string) string
 
 // ImageProxyRewriter replaces image URLs with internal proxy URLs.
 func ImageProxyRewriter(router *mux.Router, data string) string {
-	return genericImageProxyRewriter(router, ProxifyURL, data)
+	return genericImageProxyRewriter(router, ProxifyURL, config.Opts.ProxyImages(), data)
 }
 
 // AbsoluteImageProxyRewriter do the same as ImageProxyRewriter except it uses absolute URLs.
@@ -27,11 +28,35 @@ func AbsoluteImageProxyRewriter(router *mux.Router, host, data string) string {
 	proxifyFunction := func(router *mux.Router, url string) string {
 		return AbsoluteProxifyURL(router, host, url)
 	}
-	return genericImageProxyRewriter(router, proxifyFunction, data)
+	return genericImageProxyRewriter(router, proxifyFunction, config.Opts.ProxyImages(), data)
 }
 
-func genericImageProxyRewriter(router *mux.Router, proxifyFunction urlProxyRewriter, data string) string {
-	proxyImages := config.Opts.ProxyImages()
+// MediaCacheRewriter replaces all image URLs of the entry content with internal proxy URLs, the proxy serves the images from the media cache.
+func MediaCacheRewriter(router *mux.Router, entryID int64, data string) string {
+	proxifyFunction := func(router *mux.Router, url string) string {
+		return ProxifyCachedURL(router, entryID, url)
+	}
+	return genericImageProxyRewriter(router, proxifyFunction, "all", data)
+}
+
+// AbsoluteMediaCacheRewriter do the same as MediaCacheRewriter except it uses absolute URLs.
+func AbsoluteMediaCacheRewriter(router *mux.Router, host string, entryID int64, data string) string {
+	proxifyFunction := func(router *mux.Router, url string) string {
+		return AbsoluteProxifyCachedURL(router, host, entryID, url)
+	}
+	return genericImageProxyRewriter(router, proxifyFunction, "all", data)
+}
+
+// AbsoluteEntryImageProxyRewriter rewrites the images of the entry content with absolute URLs,
+// the images of the feeds with a media cache are always served by the internal proxy.
+func AbsoluteEntryImageProxyRewriter(router *mux.Router, host string, entry *model.Entry) string {
+	if entry.Feed != nil && entry.Feed.CacheMedia {
+		return AbsoluteMediaCacheRewriter(router, host, entry.ID, entry.Content)
+	}
+	return AbsoluteImageProxyRewriter(router, host, entry.Content)
+}
+
+func genericImageProxyRewriter(router *mux.Router, proxifyFunction urlProxyRewriter, proxyImages, data string) string {
 	if proxyImages == "none" {
 		return data
 	}
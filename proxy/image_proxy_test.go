@@ -5,6 +5,9 @@
 package proxy // import "miniflux.app/proxy"
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
 	"net/http"
 	"os"
 	"testing"
@@ -358,3 +361,76 @@ func TestImageProxyWithImageSourceDataURL(t *testing.T) {
 		t.Errorf(`Not expected output: got %s`, output)
 	}
 }
+
+func TestMediaCacheRewriterIgnoresProxyImagesOption(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("PROXY_IMAGES", "none")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	r := mux.NewRouter()
+	r.HandleFunc("/media/{entryID}/{encodedURL}", func(w http.ResponseWriter, r *http.Request) {}).Name("mediaProxy")
+
+	input := `<p><img src="https://website/folder/image.png" alt="Test"/></p>`
+	output := MediaCacheRewriter(r, 42, input)
+	expected := `<p><img src="/media/42/aHR0cHM6Ly93ZWJzaXRlL2ZvbGRlci9pbWFnZS5wbmc=" alt="Test"/></p>`
+
+	if expected != output {
+		t.Errorf(`Not expected output: got "%s" instead of "%s"`, output, expected)
+	}
+}
+
+func TestMediaCacheRewriterWithExternalProxy(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("PROXY_IMAGES", "all")
+	os.Setenv("PROXY_IMAGE_URL", "https://proxy-example/proxy")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	r := mux.NewRouter()
+	r.HandleFunc("/media/{entryID}/{encodedURL}", func(w http.ResponseWriter, r *http.Request) {}).Name("mediaProxy")
+
+	input := `<p><img src="https://website/folder/image.png" alt="Test"/></p>`
+	output := AbsoluteMediaCacheRewriter(r, "localhost", 42, input)
+	expected := `<p><img src="http://localhost/media/42/aHR0cHM6Ly93ZWJzaXRlL2ZvbGRlci9pbWFnZS5wbmc=" alt="Test"/></p>`
+
+	if expected != output {
+		t.Errorf(`Not expected output: got "%s" instead of "%s"`, output, expected)
+	}
+}
+
+func TestMediaDigestIsScopedToEntry(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	link := "mid:issue-42@example.org/1/0/report.pdf"
+	if !bytes.Equal(MediaDigest(1, link), MediaDigest(1, link)) {
+		t.Fatal(`The digest should be stable`)
+	}
+
+	if bytes.Equal(MediaDigest(1, link), MediaDigest(2, link)) {
+		t.Fatal(`The digest should be different for each entry`)
+	}
+
+	mac := hmac.New(sha256.New, config.Opts.ProxyPrivateKey())
+	mac.Write([]byte("1/" + link))
+	if bytes.Equal(MediaDigest(1, link), mac.Sum(nil)) {
+		t.Fatal(`The digest should not be a valid proxy digest`)
+	}
+}
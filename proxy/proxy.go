@@ -10,6 +10,7 @@ import (
 	"encoding/base64"
 	"net/url"
 	"path"
+	"strconv"
 
 	"miniflux.app/http/route"
 
@@ -24,10 +25,7 @@ func ProxifyURL(router *mux.Router, link string) string {
 		proxyImageUrl := config.Opts.ProxyImageUrl()
 
 		if proxyImageUrl == "" {
-			mac := hmac.New(sha256.New, config.Opts.ProxyPrivateKey())
-			mac.Write([]byte(link))
-			digest := mac.Sum(nil)
-			return route.Path(router, "proxy", "encodedDigest", base64.URLEncoding.EncodeToString(digest), "encodedURL", base64.URLEncoding.EncodeToString([]byte(link)))
+			return internalProxyPath(router, link)
 		}
 
 		proxyUrl, err := url.Parse(proxyImageUrl)
@@ -47,15 +45,7 @@ func AbsoluteProxifyURL(router *mux.Router, host, link string) string {
 		proxyImageUrl := config.Opts.ProxyImageUrl()
 
 		if proxyImageUrl == "" {
-			mac := hmac.New(sha256.New, config.Opts.ProxyPrivateKey())
-			mac.Write([]byte(link))
-			digest := mac.Sum(nil)
-			path := route.Path(router, "proxy", "encodedDigest", base64.URLEncoding.EncodeToString(digest), "encodedURL", base64.URLEncoding.EncodeToString([]byte(link)))
-			if config.Opts.HTTPS {
-				return "https://" + host + path
-			} else {
-				return "http://" + host + path
-			}
+			return absoluteInternalProxyURL(host, internalProxyPath(router, link))
 		}
 
 		proxyUrl, err := url.Parse(proxyImageUrl)
@@ -68,3 +58,57 @@ func AbsoluteProxifyURL(router *mux.Router, host, link string) string {
 	}
 	return ""
 }
+
+// ProxifyCachedURL generates a relative URL for a resource of the media cache, it is always served by the internal proxy.
+//
+// The URL is only valid for the given entry, this way the files cached for an entry are not served for the entries of the other users.
+func ProxifyCachedURL(router *mux.Router, entryID int64, link string) string {
+	if link != "" {
+		return internalMediaPath(router, entryID, link)
+	}
+	return ""
+}
+
+// AbsoluteProxifyCachedURL generates an absolute URL for a resource of the media cache.
+func AbsoluteProxifyCachedURL(router *mux.Router, host string, entryID int64, link string) string {
+	if link != "" {
+		return absoluteInternalProxyURL(host, internalMediaPath(router, entryID, link))
+	}
+	return ""
+}
+
+// MediaDigest returns the signature of a resource of the media cache.
+//
+// The key is derived from the proxy key, the signatures of the proxy URLs cannot be used for the media cache.
+func MediaDigest(entryID int64, link string) []byte {
+	key := hmac.New(sha256.New, config.Opts.ProxyPrivateKey())
+	key.Write([]byte("media"))
+
+	mac := hmac.New(sha256.New, key.Sum(nil))
+	mac.Write([]byte(strconv.FormatInt(entryID, 10) + "/" + link))
+	return mac.Sum(nil)
+}
+
+func internalMediaPath(router *mux.Router, entryID int64, link string) string {
+	return route.Path(
+		router,
+		"mediaProxy",
+		"entryID", entryID,
+		"encodedDigest", base64.URLEncoding.EncodeToString(MediaDigest(entryID, link)),
+		"encodedURL", base64.URLEncoding.EncodeToString([]byte(link)),
+	)
+}
+
+func internalProxyPath(router *mux.Router, link string) string {
+	mac := hmac.New(sha256.New, config.Opts.ProxyPrivateKey())
+	mac.Write([]byte(link))
+	digest := mac.Sum(nil)
+	return route.Path(router, "proxy", "encodedDigest", base64.URLEncoding.EncodeToString(digest), "encodedURL", base64.URLEncoding.EncodeToString([]byte(link)))
+}
+
+func absoluteInternalProxyURL(host, path string) string {
+	if config.Opts.HTTPS {
+		return "https://" + host + path
+	}
+	return "http://" + host + path
+}
@@ -43,6 +43,17 @@ func (a *atom10Feed) Transform(baseURL string) *model.Feed {
 		feed.FeedURL = feedURL
 	}
 
+	if hubURL := a.Links.firstLinkWithRelation("hub"); hubURL != "" {
+		feed.HubURL, err = url.AbsoluteURL(baseURL, hubURL)
+		if err != nil {
+			feed.HubURL = hubURL
+		}
+
+		if feedURL != "" {
+			feed.TopicURL = feed.FeedURL
+		}
+	}
+
 	siteURL := a.Links.originalLink()
 	feed.SiteURL, err = url.AbsoluteURL(baseURL, siteURL)
 	if err != nil {
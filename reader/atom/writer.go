@@ -0,0 +1,120 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package atom // import "miniflux.app/reader/atom"
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	"miniflux.app/model"
+)
+
+type atomWriterFeed struct {
+	XMLName xml.Name           `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string             `xml:"id"`
+	Title   string             `xml:"title"`
+	Updated string             `xml:"updated"`
+	Links   []atomWriterLink   `xml:"link"`
+	Entries []*atomWriterEntry `xml:"entry"`
+}
+
+type atomWriterLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length int64  `xml:"length,attr,omitempty"`
+}
+
+type atomWriterEntry struct {
+	ID         string               `xml:"id"`
+	Title      string               `xml:"title"`
+	Published  string               `xml:"published"`
+	Updated    string               `xml:"updated"`
+	Links      []atomWriterLink     `xml:"link"`
+	Author     *atomWriterAuthor    `xml:"author,omitempty"`
+	Categories []atomWriterCategory `xml:"category"`
+	Content    atomWriterContent    `xml:"content"`
+	Source     *atomWriterSource    `xml:"source,omitempty"`
+}
+
+type atomWriterAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomWriterCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomWriterContent struct {
+	Type string `xml:"type,attr"`
+	Data string `xml:",chardata"`
+}
+
+type atomWriterSource struct {
+	Title string           `xml:"title"`
+	Links []atomWriterLink `xml:"link"`
+}
+
+// Write serializes the feed and its entries as an Atom 1.0 document.
+func Write(w io.Writer, feed *model.Feed) error {
+	output := &atomWriterFeed{
+		ID:    feed.FeedURL,
+		Title: feed.Title,
+		Links: []atomWriterLink{
+			{Href: feed.FeedURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: feed.SiteURL, Rel: "alternate", Type: "text/html"},
+		},
+	}
+
+	updated := time.Now()
+	if len(feed.Entries) > 0 {
+		updated = feed.Entries[0].Date
+	}
+	output.Updated = updated.Format(time.RFC3339)
+
+	for _, entry := range feed.Entries {
+		item := &atomWriterEntry{
+			ID:        entry.URL,
+			Title:     entry.Title,
+			Published: entry.Date.Format(time.RFC3339),
+			Updated:   entry.Date.Format(time.RFC3339),
+			Links:     []atomWriterLink{{Href: entry.URL, Rel: "alternate", Type: "text/html"}},
+			Content:   atomWriterContent{Type: "html", Data: entry.Content},
+		}
+
+		if entry.Author != "" {
+			item.Author = &atomWriterAuthor{Name: entry.Author}
+		}
+
+		for _, tag := range entry.Tags {
+			item.Categories = append(item.Categories, atomWriterCategory{Term: tag})
+		}
+
+		for _, enclosure := range entry.Enclosures {
+			item.Links = append(item.Links, atomWriterLink{
+				Href:   enclosure.URL,
+				Rel:    "enclosure",
+				Type:   enclosure.MimeType,
+				Length: enclosure.Size,
+			})
+		}
+
+		if entry.Feed != nil {
+			item.Source = &atomWriterSource{
+				Title: entry.Feed.Title,
+				Links: []atomWriterLink{{Href: entry.Feed.SiteURL, Rel: "alternate", Type: "text/html"}},
+			}
+		}
+
+		output.Entries = append(output.Entries, item)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	return xml.NewEncoder(w).Encode(output)
+}
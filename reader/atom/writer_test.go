@@ -0,0 +1,89 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package atom // import "miniflux.app/reader/atom"
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/model"
+)
+
+func TestWriteAtomFeed(t *testing.T) {
+	feed := &model.Feed{
+		Title:   "Starred <entries>",
+		FeedURL: "https://miniflux.example.org/feeds/starred.atom",
+		SiteURL: "https://miniflux.example.org/starred",
+		Entries: model.Entries{
+			{
+				Title:      "First & last",
+				URL:        "https://example.org/first",
+				Author:     "Jane",
+				Content:    `<p>Hello <a href="https://example.org/">world</a></p>`,
+				Date:       time.Date(2023, time.March, 1, 10, 0, 0, 0, time.UTC),
+				Tags:       []string{"go"},
+				Feed:       &model.Feed{Title: "Example", SiteURL: "https://example.org/"},
+				Enclosures: model.EnclosureList{{URL: "https://example.org/episode.mp3", MimeType: "audio/mpeg", Size: 42}},
+			},
+		},
+	}
+
+	var buffer bytes.Buffer
+	if err := Write(&buffer, feed); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(buffer.String(), `<?xml version="1.0" encoding="UTF-8"?>`) {
+		t.Errorf(`The XML declaration is missing: %s`, buffer.String())
+	}
+
+	parsedFeed, err := Parse(feed.FeedURL, &buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parsedFeed.Title != feed.Title || parsedFeed.FeedURL != feed.FeedURL || parsedFeed.SiteURL != feed.SiteURL {
+		t.Errorf(`Unexpected feed: %q, %q, %q`, parsedFeed.Title, parsedFeed.FeedURL, parsedFeed.SiteURL)
+	}
+
+	if len(parsedFeed.Entries) != 1 {
+		t.Fatalf(`Unexpected number of entries: %d`, len(parsedFeed.Entries))
+	}
+
+	entry := parsedFeed.Entries[0]
+	if entry.Title != "First & last" || entry.URL != "https://example.org/first" || entry.Author != "Jane" {
+		t.Errorf(`Unexpected entry: %q, %q, %q`, entry.Title, entry.URL, entry.Author)
+	}
+
+	if entry.Content != feed.Entries[0].Content {
+		t.Errorf(`Unexpected content: %q`, entry.Content)
+	}
+
+	if !entry.Date.Equal(feed.Entries[0].Date) {
+		t.Errorf(`Unexpected date: %v`, entry.Date)
+	}
+
+	if len(entry.Enclosures) != 1 || entry.Enclosures[0].URL != "https://example.org/episode.mp3" || entry.Enclosures[0].Size != 42 {
+		t.Errorf(`Unexpected enclosures: %+v`, entry.Enclosures)
+	}
+}
+
+func TestWriteEmptyAtomFeed(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := Write(&buffer, &model.Feed{Title: "Empty", FeedURL: "https://example.org/feed.atom"}); err != nil {
+		t.Fatal(err)
+	}
+
+	parsedFeed, err := Parse("https://example.org/feed.atom", &buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(parsedFeed.Entries) != 0 {
+		t.Errorf(`The feed should not have entries`)
+	}
+}
@@ -15,10 +15,12 @@ var (
 	errEncoding         = "Unable to normalize encoding: %q"
 	errEmptyFeed        = "This feed is empty"
 	errResourceNotFound = "Resource not found (404), this feed doesn't exist anymore, check the feed URL"
+	errResourceGone     = "This feed has been removed permanently by its publisher (410)"
 	errNotAuthorized    = "You are not authorized to access this resource (invalid username/password)"
 )
 
 // Exec executes a HTTP request and handles errors.
+// The response is also returned when the server replies with an error status code.
 func Exec(request *client.Client) (*client.Response, *errors.LocalizedError) {
 	response, err := request.Get()
 	if err != nil {
@@ -28,16 +30,20 @@ func Exec(request *client.Client) (*client.Response, *errors.LocalizedError) {
 		return nil, errors.NewLocalizedError(errRequestFailed, err)
 	}
 
+	if response.IsGone() {
+		return response, errors.NewLocalizedError(errResourceGone)
+	}
+
 	if response.IsNotFound() {
-		return nil, errors.NewLocalizedError(errResourceNotFound)
+		return response, errors.NewLocalizedError(errResourceNotFound)
 	}
 
 	if response.IsNotAuthorized() {
-		return nil, errors.NewLocalizedError(errNotAuthorized)
+		return response, errors.NewLocalizedError(errNotAuthorized)
 	}
 
 	if response.HasServerFailure() {
-		return nil, errors.NewLocalizedError(errServerFailure, response.StatusCode)
+		return response, errors.NewLocalizedError(errServerFailure, response.StatusCode)
 	}
 
 	if response.StatusCode != 304 {
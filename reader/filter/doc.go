@@ -0,0 +1,18 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+/*
+Package filter implements the user-level filter rules applied to all feeds.
+
+Each rule is written on its own line with the form "Action|Field=Pattern":
+
+	block|EntryTitle=(?i)sponsored
+	keep|EntryCategory=^Tech$
+	read|EntryAuthor=^Bot$
+	star|EntryURL=example\.org
+	tag:Go|EntryContent=(?i)golang
+
+Empty lines and lines starting with "#" are ignored.
+*/
+package filter // import "miniflux.app/reader/filter"
@@ -0,0 +1,191 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package filter // import "miniflux.app/reader/filter"
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"miniflux.app/model"
+)
+
+// Rule actions.
+const (
+	ActionBlock = "block"
+	ActionKeep  = "keep"
+	ActionRead  = "read"
+	ActionStar  = "star"
+	ActionTag   = "tag"
+)
+
+// Rule fields.
+const (
+	FieldEntryTitle    = "EntryTitle"
+	FieldEntryURL      = "EntryURL"
+	FieldEntryAuthor   = "EntryAuthor"
+	FieldEntryContent  = "EntryContent"
+	FieldEntryCategory = "EntryCategory"
+)
+
+// Rule represents a single filter rule.
+type Rule struct {
+	Action  string
+	TagName string
+	Field   string
+	Pattern *regexp.Regexp
+}
+
+// Rules represents a list of filter rules.
+type Rules []*Rule
+
+// Parse parses the rules text, one rule per line.
+func Parse(text string) (Rules, error) {
+	var rules Rules
+
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule, err := parseRule(line)
+		if err != nil {
+			return nil, fmt.Errorf(`filter: invalid rule on line %d: %v`, i+1, err)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func parseRule(line string) (*Rule, error) {
+	action, condition, found := strings.Cut(line, "|")
+	if !found {
+		return nil, fmt.Errorf(`missing action separator in %q`, line)
+	}
+
+	field, pattern, found := strings.Cut(condition, "=")
+	if !found {
+		return nil, fmt.Errorf(`missing field separator in %q`, line)
+	}
+
+	rule := &Rule{Field: strings.TrimSpace(field)}
+
+	action = strings.TrimSpace(action)
+	switch {
+	case action == ActionBlock, action == ActionKeep, action == ActionRead, action == ActionStar:
+		rule.Action = action
+	case strings.HasPrefix(action, ActionTag+":"):
+		rule.Action = ActionTag
+		rule.TagName = strings.TrimSpace(strings.TrimPrefix(action, ActionTag+":"))
+		if rule.TagName == "" || strings.Contains(rule.TagName, ",") {
+			return nil, fmt.Errorf(`invalid tag name in %q`, line)
+		}
+	default:
+		return nil, fmt.Errorf(`unknown action %q`, action)
+	}
+
+	switch rule.Field {
+	case FieldEntryTitle, FieldEntryURL, FieldEntryAuthor, FieldEntryContent, FieldEntryCategory:
+	default:
+		return nil, fmt.Errorf(`unknown field %q`, rule.Field)
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	rule.Pattern = re
+
+	return rule, nil
+}
+
+// Match returns true if the rule matches the given entry.
+func (r *Rule) Match(feed *model.Feed, entry *model.Entry) bool {
+	var value string
+
+	switch r.Field {
+	case FieldEntryTitle:
+		value = entry.Title
+	case FieldEntryURL:
+		value = entry.URL
+	case FieldEntryAuthor:
+		value = entry.Author
+	case FieldEntryContent:
+		value = entry.Content
+	case FieldEntryCategory:
+		if feed.Category != nil {
+			value = feed.Category.Title
+		}
+	}
+
+	return r.Pattern.MatchString(value)
+}
+
+// IsBlocked returns true if a "block" rule matches the entry.
+func (rules Rules) IsBlocked(feed *model.Feed, entry *model.Entry) bool {
+	for _, rule := range rules {
+		if rule.Action == ActionBlock && rule.Match(feed, entry) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsAllowed returns false if there are "keep" rules and none of them matches the entry.
+func (rules Rules) IsAllowed(feed *model.Feed, entry *model.Entry) bool {
+	hasKeepRules := false
+	for _, rule := range rules {
+		if rule.Action != ActionKeep {
+			continue
+		}
+
+		hasKeepRules = true
+		if rule.Match(feed, entry) {
+			return true
+		}
+	}
+
+	return !hasKeepRules
+}
+
+// Apply changes the entry according to the matching "read", "star" and "tag" rules.
+func (rules Rules) Apply(feed *model.Feed, entry *model.Entry) {
+	for _, rule := range rules {
+		switch rule.Action {
+		case ActionRead, ActionStar, ActionTag:
+		default:
+			continue
+		}
+
+		if !rule.Match(feed, entry) {
+			continue
+		}
+
+		switch rule.Action {
+		case ActionRead:
+			entry.Status = model.EntryStatusRead
+		case ActionStar:
+			entry.Starred = true
+		case ActionTag:
+			if !containsTag(entry.Tags, rule.TagName) {
+				entry.Tags = append(entry.Tags, rule.TagName)
+			}
+		}
+	}
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
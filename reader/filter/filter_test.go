@@ -0,0 +1,99 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package filter // import "miniflux.app/reader/filter"
+
+import (
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := Parse("block|EntryTitle=(?i)sponsored\n\n# comment\ntag:Go Lang|EntryContent=golang\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rules) != 2 {
+		t.Fatalf(`Unexpected number of rules: %d`, len(rules))
+	}
+
+	if rules[0].Action != ActionBlock || rules[0].Field != FieldEntryTitle {
+		t.Errorf(`Unexpected rule: %+v`, rules[0])
+	}
+
+	if rules[1].Action != ActionTag || rules[1].TagName != "Go Lang" || rules[1].Field != FieldEntryContent {
+		t.Errorf(`Unexpected rule: %+v`, rules[1])
+	}
+}
+
+func TestParseInvalidRules(t *testing.T) {
+	scenarios := []string{
+		"EntryTitle=test",
+		"block|EntryTitle",
+		"drop|EntryTitle=test",
+		"block|FeedTitle=test",
+		"block|EntryTitle=(",
+		"tag:|EntryTitle=test",
+		"tag:a,b|EntryTitle=test",
+	}
+
+	for _, scenario := range scenarios {
+		if _, err := Parse(scenario); err == nil {
+			t.Errorf(`Rule %q should be invalid`, scenario)
+		}
+	}
+}
+
+func TestBlockAndKeepRules(t *testing.T) {
+	rules, err := Parse("block|EntryURL=ads\nkeep|EntryCategory=^Tech$")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feed := &model.Feed{Category: &model.Category{Title: "Tech"}}
+
+	if !rules.IsBlocked(feed, &model.Entry{URL: "https://example.org/ads/1"}) {
+		t.Error(`The entry should be blocked`)
+	}
+
+	if rules.IsBlocked(feed, &model.Entry{URL: "https://example.org/posts/1"}) {
+		t.Error(`The entry should not be blocked`)
+	}
+
+	if !rules.IsAllowed(feed, &model.Entry{}) {
+		t.Error(`The entry should be allowed`)
+	}
+
+	if rules.IsAllowed(&model.Feed{Category: &model.Category{Title: "News"}}, &model.Entry{}) {
+		t.Error(`The entry should not be allowed`)
+	}
+
+	if !Rules(nil).IsAllowed(feed, &model.Entry{}) {
+		t.Error(`Entries should be allowed when there is no rule`)
+	}
+}
+
+func TestApplyRules(t *testing.T) {
+	rules, err := Parse("read|EntryAuthor=^Bot$\nstar|EntryTitle=(?i)important\ntag:Go|EntryContent=golang\ntag:Go|EntryTitle=Go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := &model.Entry{Title: "Important Go news", Author: "Bot", Content: "About golang", Status: model.EntryStatusUnread}
+	rules.Apply(&model.Feed{}, entry)
+
+	if entry.Status != model.EntryStatusRead {
+		t.Errorf(`Unexpected status: %q`, entry.Status)
+	}
+
+	if !entry.Starred {
+		t.Error(`The entry should be starred`)
+	}
+
+	if len(entry.Tags) != 1 || entry.Tags[0] != "Go" {
+		t.Errorf(`Unexpected tags: %v`, entry.Tags)
+	}
+}
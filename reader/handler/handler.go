@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"miniflux.app/config"
+	"miniflux.app/crypto"
 	"miniflux.app/errors"
 	"miniflux.app/http/client"
 	"miniflux.app/locale"
@@ -16,10 +17,13 @@ import (
 	"miniflux.app/model"
 	"miniflux.app/reader/browser"
 	"miniflux.app/reader/icon"
+	"miniflux.app/reader/media"
 	"miniflux.app/reader/parser"
 	"miniflux.app/reader/processor"
+	"miniflux.app/reader/scraper"
 	"miniflux.app/storage"
 	"miniflux.app/timer"
+	"miniflux.app/websub"
 )
 
 var (
@@ -60,7 +64,15 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 		return nil, errors.NewLocalizedError(errDuplicate, response.EffectiveURL)
 	}
 
-	subscription, parseErr := parser.ParseFeed(response.EffectiveURL, response.BodyAsString())
+	selectors := &scraper.FeedSelectors{
+		Item:    feedCreationRequest.ItemSelector,
+		Title:   feedCreationRequest.TitleSelector,
+		Link:    feedCreationRequest.LinkSelector,
+		Date:    feedCreationRequest.DateSelector,
+		Content: feedCreationRequest.ContentSelector,
+	}
+
+	subscription, parseErr := parseFeed(response.EffectiveURL, response.BodyAsString(), selectors)
 	if parseErr != nil {
 		return nil, parseErr
 	}
@@ -80,6 +92,13 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 	subscription.BlocklistRules = feedCreationRequest.BlocklistRules
 	subscription.KeeplistRules = feedCreationRequest.KeeplistRules
 	subscription.UrlRewriteRules = feedCreationRequest.UrlRewriteRules
+	subscription.CacheMedia = feedCreationRequest.CacheMedia
+	subscription.Notify = feedCreationRequest.Notify
+	subscription.ItemSelector = selectors.Item
+	subscription.TitleSelector = selectors.Title
+	subscription.LinkSelector = selectors.Link
+	subscription.DateSelector = selectors.Date
+	subscription.ContentSelector = selectors.Content
 	subscription.WithCategoryID(feedCreationRequest.CategoryID)
 	subscription.WithClientResponse(response)
 	subscription.CheckedNow()
@@ -92,6 +111,14 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 
 	logger.Debug("[CreateFeed] Feed saved with ID: %d", subscription.ID)
 
+	processor.PushEntries(store, subscription, subscription.Entries)
+
+	if config.Opts.HasWebSub() {
+		go subscribeToHub(store, subscription.ID, subscription.HubURL, subscription.TopicURL, subscription.FeedURL)
+	}
+
+	go media.CacheEntries(store, subscription, subscription.Entries)
+
 	checkFeedIcon(
 		store,
 		subscription.ID,
@@ -103,8 +130,33 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 	return subscription, nil
 }
 
+// CreateNewsletter creates a feed that receives its entries by email instead of being polled.
+func CreateNewsletter(store *storage.Storage, userID, categoryID int64, title string) (*model.Feed, error) {
+	if !store.CategoryIDExists(userID, categoryID) {
+		return nil, errors.NewLocalizedError(errCategoryNotFound)
+	}
+
+	newsletterURL := model.NewsletterURLPrefix + crypto.GenerateRandomStringHex(16)
+	subscription := &model.Feed{
+		UserID:          userID,
+		Title:           title,
+		FeedURL:         newsletterURL,
+		SiteURL:         newsletterURL,
+		NewsletterToken: crypto.GenerateRandomStringHex(16),
+	}
+	subscription.WithCategoryID(categoryID)
+	subscription.CheckedNow()
+
+	if storeErr := store.CreateFeed(subscription); storeErr != nil {
+		return nil, storeErr
+	}
+
+	logger.Debug("[CreateNewsletter] Feed saved with ID: %d", subscription.ID)
+	return subscription, nil
+}
+
 // RefreshFeed refreshes a feed.
-func RefreshFeed(store *storage.Storage, userID, feedID int64) error {
+func RefreshFeed(store *storage.Storage, userID, feedID int64) (refreshErr error) {
 	defer timer.ExecutionTime(time.Now(), fmt.Sprintf("[RefreshFeed] feedID=%d", feedID))
 	user, storeErr := store.UserByID(userID)
 	if storeErr != nil {
@@ -122,8 +174,13 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64) error {
 		return errors.NewLocalizedError(errNotFound, feedID)
 	}
 
+	// Newsletters receive their entries by email, there is nothing to fetch.
+	if originalFeed.IsNewsletter() {
+		return nil
+	}
+
 	weeklyEntryCount := 0
-	if config.Opts.PollingScheduler() == model.SchedulerEntryFrequency {
+	if scheduler := config.Opts.PollingScheduler(); scheduler == model.SchedulerEntryFrequency || scheduler == model.SchedulerAdaptive {
 		var weeklyCountErr error
 		weeklyEntryCount, weeklyCountErr = store.WeeklyFeedEntryCount(userID, feedID)
 		if weeklyCountErr != nil {
@@ -132,7 +189,20 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64) error {
 	}
 
 	originalFeed.CheckedNow()
-	originalFeed.ScheduleNextCheck(weeklyEntryCount)
+	scheduleNextCheck(store, originalFeed, weeklyEntryCount)
+
+	// Every refresh is recorded in the feed history, including the failed ones.
+	refresh := model.NewFeedRefresh(feedID)
+	defer func() {
+		if refreshErr != nil && refresh.ErrorMsg == "" {
+			refresh.ErrorMsg = originalFeed.ParsingErrorMsg
+		}
+
+		refresh.Finished()
+		if err := store.CreateFeedRefresh(refresh); err != nil {
+			logger.Error("[RefreshFeed] %v", err)
+		}
+	}()
 
 	request := client.NewClientWithConfig(originalFeed.FeedURL, config.Opts)
 	request.WithCredentials(originalFeed.Username, originalFeed.Password)
@@ -149,23 +219,35 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64) error {
 	}
 
 	response, requestErr := browser.Exec(request)
+	if response != nil {
+		refresh.WithClientResponse(response)
+
+		// The feed URL is not updated automatically, the user decides to follow permanent redirects from the feed health page.
+		originalFeed.WithHealthStatus(response)
+	}
+
 	if requestErr != nil {
+		// The feed is not broken when its server asks to slow down or is busy with other requests,
+		// it is checked again after the backoff period or during the next polling cycle.
+		if backoffUntil := client.HostBackoffUntil(originalFeed.FeedURL); !backoffUntil.IsZero() || client.IsHostLimitError(requestErr) {
+			originalFeed.DelayNextCheckUntil(backoffUntil)
+			refresh.ErrorMsg = requestErr.Localize(printer)
+			store.UpdateFeedError(originalFeed)
+			return requestErr
+		}
+
 		originalFeed.WithError(requestErr.Localize(printer))
 		store.UpdateFeedError(originalFeed)
 		return requestErr
 	}
 
-	if store.AnotherFeedURLExists(userID, originalFeed.ID, response.EffectiveURL) {
-		storeErr := errors.NewLocalizedError(errDuplicate, response.EffectiveURL)
-		originalFeed.WithError(storeErr.Error())
-		store.UpdateFeedError(originalFeed)
-		return storeErr
-	}
-
 	if originalFeed.IgnoreHTTPCache || response.IsModified(originalFeed.EtagHeader, originalFeed.LastModifiedHeader) {
 		logger.Debug("[RefreshFeed] Feed #%d has been modified", feedID)
 
-		updatedFeed, parseErr := parser.ParseFeed(response.EffectiveURL, response.BodyAsString())
+		body := response.BodyAsString()
+		refresh.ResponseSize = int64(len(body))
+
+		updatedFeed, parseErr := parseFeed(response.EffectiveURL, body, scraper.NewFeedSelectors(originalFeed))
 		if parseErr != nil {
 			originalFeed.WithError(parseErr.Localize(printer))
 			store.UpdateFeedError(originalFeed)
@@ -173,18 +255,32 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64) error {
 		}
 
 		originalFeed.Entries = updatedFeed.Entries
+		originalFeed.PollingHints = updatedFeed.PollingHints
 		processor.ProcessFeedEntries(store, originalFeed, user)
 
+		if config.Opts.HasWebSub() {
+			go subscribeToHub(store, originalFeed.ID, updatedFeed.HubURL, updatedFeed.TopicURL, response.EffectiveURL)
+		}
+
 		// We don't update existing entries when the crawler is enabled (we crawl only inexisting entries).
-		if storeErr := store.RefreshFeedEntries(originalFeed.UserID, originalFeed.ID, originalFeed.Entries, !originalFeed.Crawler); storeErr != nil {
+		newEntries, updatedEntries, storeErr := store.RefreshFeedEntries(originalFeed.UserID, originalFeed.ID, originalFeed.Entries, !originalFeed.Crawler)
+		if storeErr != nil {
 			originalFeed.WithError(storeErr.Error())
 			store.UpdateFeedError(originalFeed)
 			return storeErr
 		}
 
+		refresh.NewEntries = len(newEntries)
+		refresh.UpdatedEntries = updatedEntries
+
+		processor.PushEntries(store, originalFeed, newEntries)
+
+		// Downloading the media files would block the worker until the end of the refresh.
+		go media.CacheEntries(store, originalFeed, originalFeed.Entries)
+
 		// We update caching headers only if the feed has been modified,
 		// because some websites don't return the same headers when replying with a 304.
-		originalFeed.WithClientResponse(response)
+		originalFeed.WithCacheHeaders(response)
 		checkFeedIcon(
 			store,
 			originalFeed.ID,
@@ -195,10 +291,17 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64) error {
 		)
 	} else {
 		logger.Debug("[RefreshFeed] Feed #%d not modified", feedID)
+		refresh.NotModified = true
 	}
 
 	originalFeed.ResetErrorCounter()
 
+	// The adaptive scheduler depends on the hints received with the response.
+	if config.Opts.PollingScheduler() == model.SchedulerAdaptive {
+		originalFeed.PollingHints.WithCacheLifetime(response.CacheLifetime())
+		scheduleNextCheck(store, originalFeed, weeklyEntryCount)
+	}
+
 	if storeErr := store.UpdateFeed(originalFeed); storeErr != nil {
 		originalFeed.WithError(storeErr.Error())
 		store.UpdateFeedError(originalFeed)
@@ -208,6 +311,35 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64) error {
 	return nil
 }
 
+// scheduleNextCheck sets the next refresh of the feed according to the polling scheduler.
+func scheduleNextCheck(store *storage.Storage, feed *model.Feed, weeklyEntryCount int) {
+	feed.ScheduleNextCheck(weeklyEntryCount)
+
+	// Feeds with a live WebSub subscription receive new entries from the hub, polling is only a fallback.
+	if config.Opts.HasWebSub() && store.HasActiveWebSubSubscription(feed.ID) {
+		feed.DelayNextCheck(config.Opts.WebSubPollingInterval())
+	}
+}
+
+// parseFeed parses a feed document, or builds the feed from the web page when an item selector is defined.
+func parseFeed(baseURL, data string, selectors *scraper.FeedSelectors) (*model.Feed, *errors.LocalizedError) {
+	if selectors.Item != "" {
+		return scraper.ParseFeed(baseURL, data, selectors)
+	}
+	return parser.ParseFeed(baseURL, data)
+}
+
+// subscribeToHub keeps the WebSub subscription of the feed in sync with the hub advertised in the document.
+func subscribeToHub(store *storage.Storage, feedID int64, hubURL, topicURL, feedURL string) {
+	if topicURL == "" {
+		topicURL = feedURL
+	}
+
+	if err := websub.Subscribe(store, feedID, hubURL, topicURL); err != nil {
+		logger.Error("[SubscribeToHub] %v (feedID=%d hubURL=%s)", err, feedID, hubURL)
+	}
+}
+
 func checkFeedIcon(store *storage.Storage, feedID int64, websiteURL, userAgent string, fetchViaProxy, allowSelfSignedCertificates bool) {
 	if !store.HasIcon(feedID) {
 		icon, err := icon.FindIcon(websiteURL, userAgent, fetchViaProxy, allowSelfSignedCertificates)
@@ -0,0 +1,80 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package json // import "miniflux.app/reader/json"
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"miniflux.app/model"
+)
+
+type jsonWriterFeed struct {
+	Version string            `json:"version"`
+	Title   string            `json:"title"`
+	SiteURL string            `json:"home_page_url,omitempty"`
+	FeedURL string            `json:"feed_url,omitempty"`
+	Items   []*jsonWriterItem `json:"items"`
+}
+
+type jsonWriterItem struct {
+	ID            string                 `json:"id"`
+	URL           string                 `json:"url"`
+	Title         string                 `json:"title"`
+	HTML          string                 `json:"content_html"`
+	DatePublished string                 `json:"date_published"`
+	Authors       []jsonWriterAuthor     `json:"authors,omitempty"`
+	Tags          []string               `json:"tags,omitempty"`
+	Attachments   []jsonWriterAttachment `json:"attachments,omitempty"`
+}
+
+type jsonWriterAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonWriterAttachment struct {
+	URL      string `json:"url"`
+	MimeType string `json:"mime_type"`
+	Size     int64  `json:"size_in_bytes,omitempty"`
+}
+
+// Write serializes the feed and its entries as a JSON Feed 1.1 document.
+func Write(w io.Writer, feed *model.Feed) error {
+	output := &jsonWriterFeed{
+		Version: "https://jsonfeed.org/version/1.1",
+		Title:   feed.Title,
+		SiteURL: feed.SiteURL,
+		FeedURL: feed.FeedURL,
+		Items:   make([]*jsonWriterItem, 0, len(feed.Entries)),
+	}
+
+	for _, entry := range feed.Entries {
+		item := &jsonWriterItem{
+			ID:            entry.URL,
+			URL:           entry.URL,
+			Title:         entry.Title,
+			HTML:          entry.Content,
+			DatePublished: entry.Date.Format(time.RFC3339),
+			Tags:          entry.Tags,
+		}
+
+		if entry.Author != "" {
+			item.Authors = []jsonWriterAuthor{{Name: entry.Author}}
+		}
+
+		for _, enclosure := range entry.Enclosures {
+			item.Attachments = append(item.Attachments, jsonWriterAttachment{
+				URL:      enclosure.URL,
+				MimeType: enclosure.MimeType,
+				Size:     enclosure.Size,
+			})
+		}
+
+		output.Items = append(output.Items, item)
+	}
+
+	return json.NewEncoder(w).Encode(output)
+}
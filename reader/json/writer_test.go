@@ -0,0 +1,92 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package json // import "miniflux.app/reader/json"
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/model"
+)
+
+func TestWriteJSONFeed(t *testing.T) {
+	feed := &model.Feed{
+		Title:   "Saved search",
+		FeedURL: "https://miniflux.example.org/feeds/search/1.json",
+		SiteURL: "https://miniflux.example.org/search/1/entries",
+		Entries: model.Entries{
+			{
+				Title:      "First & last",
+				URL:        "https://example.org/first",
+				Author:     "Jane",
+				Content:    `<p>Hello <a href="https://example.org/">world</a></p>`,
+				Date:       time.Date(2023, time.March, 1, 10, 0, 0, 0, time.UTC),
+				Tags:       []string{"go"},
+				Enclosures: model.EnclosureList{{URL: "https://example.org/episode.mp3", MimeType: "audio/mpeg", Size: 42}},
+			},
+			{
+				Title: "Second",
+				URL:   "https://example.org/second",
+				Date:  time.Date(2023, time.February, 1, 10, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	var buffer bytes.Buffer
+	if err := Write(&buffer, feed); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buffer.String(), `"version":"https://jsonfeed.org/version/1.1"`) {
+		t.Errorf(`The JSON Feed version is missing: %s`, buffer.String())
+	}
+
+	parsedFeed, err := Parse(feed.FeedURL, &buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parsedFeed.Title != feed.Title || parsedFeed.FeedURL != feed.FeedURL || parsedFeed.SiteURL != feed.SiteURL {
+		t.Errorf(`Unexpected feed: %q, %q, %q`, parsedFeed.Title, parsedFeed.FeedURL, parsedFeed.SiteURL)
+	}
+
+	if len(parsedFeed.Entries) != 2 {
+		t.Fatalf(`Unexpected number of entries: %d`, len(parsedFeed.Entries))
+	}
+
+	entry := parsedFeed.Entries[0]
+	if entry.Title != "First & last" || entry.URL != "https://example.org/first" || entry.Author != "Jane" {
+		t.Errorf(`Unexpected entry: %q, %q, %q`, entry.Title, entry.URL, entry.Author)
+	}
+
+	if entry.Content != feed.Entries[0].Content {
+		t.Errorf(`Unexpected content: %q`, entry.Content)
+	}
+
+	if !entry.Date.Equal(feed.Entries[0].Date) {
+		t.Errorf(`Unexpected date: %v`, entry.Date)
+	}
+
+	if len(entry.Enclosures) != 1 || entry.Enclosures[0].MimeType != "audio/mpeg" || entry.Enclosures[0].Size != 42 {
+		t.Errorf(`Unexpected enclosures: %+v`, entry.Enclosures)
+	}
+
+	if parsedFeed.Entries[1].Author != "" || len(parsedFeed.Entries[1].Enclosures) != 0 {
+		t.Errorf(`Unexpected second entry: %+v`, parsedFeed.Entries[1])
+	}
+}
+
+func TestWriteEmptyJSONFeed(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := Write(&buffer, &model.Feed{Title: "Empty"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buffer.String(), `"items":[]`) {
+		t.Errorf(`The items should be an empty list: %s`, buffer.String())
+	}
+}
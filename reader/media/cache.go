@@ -0,0 +1,169 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package media // import "miniflux.app/reader/media"
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"miniflux.app/config"
+	"miniflux.app/crypto"
+	"miniflux.app/http/client"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// CacheEntries downloads the images of the entries, and the enclosures when enabled, into the media cache.
+// Files are not downloaded anymore once the quota of the user is reached.
+func CacheEntries(store *storage.Storage, feed *model.Feed, entries model.Entries) {
+	if !feed.CacheMedia {
+		return
+	}
+
+	var entryIDs []int64
+	for _, entry := range entries {
+		if entry.ID > 0 {
+			entryIDs = append(entryIDs, entry.ID)
+		}
+	}
+
+	if len(entryIDs) == 0 {
+		return
+	}
+
+	// Archived entries are still listed in the feed document, their files have been evicted on purpose.
+	builder := store.NewEntryQueryBuilder(feed.UserID)
+	builder.WithEntryIDs(entryIDs)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	visibleEntryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		logger.Error("[Media] %v", err)
+		return
+	}
+
+	cacheSize, err := store.MediaCacheSize(feed.UserID)
+	if err != nil {
+		logger.Error("[Media] %v", err)
+		return
+	}
+
+	quota := config.Opts.MediaCacheUserQuota()
+	for _, entry := range entries {
+		if !containsID(visibleEntryIDs, entry.ID) {
+			continue
+		}
+
+		for _, mediaURL := range EntryMediaURLs(entry, config.Opts.HasMediaCacheEnclosures()) {
+			if store.HasEntryMedia(entry.ID, mediaURL) {
+				continue
+			}
+
+			media, err := downloadMedia(feed, mediaURL)
+			if err != nil {
+				logger.Debug("[Media] %v (feedID=%d entryID=%d)", err, feed.ID, entry.ID)
+				continue
+			}
+
+			if cacheSize+media.Size > quota {
+				logger.Info("[Media] The media cache of user #%d is full, no more files are stored until the next cleanup", feed.UserID)
+				return
+			}
+
+			if err := store.CreateEntryMedia(entry.ID, mediaURL, media); err != nil {
+				logger.Error("[Media] %v", err)
+				continue
+			}
+
+			cacheSize += media.Size
+		}
+	}
+}
+
+// EntryMediaURLs returns the URLs of the images referenced in the entry content, and the URLs of the enclosures.
+// Only the "src" attribute of the images is used, the other candidates of a "srcset" are always proxied live.
+func EntryMediaURLs(entry *model.Entry, withEnclosures bool) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	add := func(mediaURL string) {
+		mediaURL = strings.TrimSpace(mediaURL)
+		if !seen[mediaURL] && (strings.HasPrefix(mediaURL, "http://") || strings.HasPrefix(mediaURL, "https://")) {
+			seen[mediaURL] = true
+			urls = append(urls, mediaURL)
+		}
+	}
+
+	if doc, err := goquery.NewDocumentFromReader(strings.NewReader(entry.Content)); err == nil {
+		doc.Find("img[src]").Each(func(i int, img *goquery.Selection) {
+			src, _ := img.Attr("src")
+			add(src)
+		})
+	}
+
+	if withEnclosures {
+		for _, enclosure := range entry.Enclosures {
+			add(enclosure.URL)
+		}
+	}
+
+	return urls
+}
+
+func downloadMedia(feed *model.Feed, mediaURL string) (*model.Media, error) {
+	clt := client.NewClientWithConfig(mediaURL, config.Opts)
+	clt.WithUserAgent(feed.UserAgent)
+	clt.AllowSelfSignedCertificates = feed.AllowSelfSignedCertificates
+	clt.ClientMaxBodySize = config.Opts.MediaCacheMaxFileSize()
+	if feed.FetchViaProxy {
+		clt.WithProxy()
+	}
+
+	response, err := clt.Get()
+	if err != nil {
+		return nil, fmt.Errorf("media: unable to download %q: %v", mediaURL, err)
+	}
+
+	if response.HasServerFailure() {
+		return nil, fmt.Errorf("media: unable to download %q: status=%d", mediaURL, response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("media: unable to read %q: %v", mediaURL, err)
+	}
+
+	if len(body) == 0 {
+		return nil, fmt.Errorf("media: the file %q is empty", mediaURL)
+	}
+
+	if int64(len(body)) > config.Opts.MediaCacheMaxFileSize() {
+		return nil, fmt.Errorf("media: the file %q is too large (%d bytes)", mediaURL, len(body))
+	}
+
+	mimeType, _, err := mime.ParseMediaType(response.ContentType)
+	if err != nil {
+		mimeType = "application/octet-stream"
+	}
+
+	return &model.Media{
+		Hash:     crypto.HashFromBytes(body),
+		MimeType: mimeType,
+		Size:     int64(len(body)),
+		Content:  body,
+	}, nil
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, value := range ids {
+		if value == id {
+			return true
+		}
+	}
+	return false
+}
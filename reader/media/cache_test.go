@@ -0,0 +1,38 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package media // import "miniflux.app/reader/media"
+
+import (
+	"reflect"
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestEntryMediaURLs(t *testing.T) {
+	entry := &model.Entry{
+		Content: `<p><img src="https://example.org/a.png" srcset="https://example.org/a-2x.png 2x"><img src="data:image/gif;base64,test"></p>` +
+			`<img src=" https://example.org/a.png "><img src="http://example.org/b.jpg"><img alt="no source">`,
+		Enclosures: model.EnclosureList{
+			{URL: "https://example.org/podcast.mp3", MimeType: "audio/mpeg"},
+		},
+	}
+
+	expected := []string{"https://example.org/a.png", "http://example.org/b.jpg"}
+	if urls := EntryMediaURLs(entry, false); !reflect.DeepEqual(urls, expected) {
+		t.Errorf(`Unexpected URLs, got %v instead of %v`, urls, expected)
+	}
+
+	expected = append(expected, "https://example.org/podcast.mp3")
+	if urls := EntryMediaURLs(entry, true); !reflect.DeepEqual(urls, expected) {
+		t.Errorf(`Unexpected URLs with enclosures, got %v instead of %v`, urls, expected)
+	}
+}
+
+func TestEntryMediaURLsWithoutImages(t *testing.T) {
+	if urls := EntryMediaURLs(&model.Entry{Content: `<p>Text only</p>`}, true); len(urls) != 0 {
+		t.Errorf(`No URL should be returned, got %v`, urls)
+	}
+}
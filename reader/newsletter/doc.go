@@ -0,0 +1,8 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+/*
+Package newsletter converts the emails received by the newsletter feeds into entries.
+*/
+package newsletter // import "miniflux.app/reader/newsletter"
@@ -0,0 +1,258 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package newsletter // import "miniflux.app/reader/newsletter"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/crypto"
+	"miniflux.app/model"
+	"miniflux.app/reader/encoding"
+	"miniflux.app/reader/sanitizer"
+)
+
+// maxPartDepth limits the nesting of multipart bodies.
+const maxPartDepth = 10
+
+// Newsletter represents an email converted into an entry.
+type Newsletter struct {
+	Entry       *model.Entry
+	Attachments []*Attachment
+}
+
+// Attachment represents a file attached to an email, it is stored in the media cache and referenced by an enclosure.
+type Attachment struct {
+	URL      string
+	Filename string
+	MimeType string
+	Content  []byte
+}
+
+// Media returns the attachment as a file of the media cache.
+func (a *Attachment) Media() *model.Media {
+	return &model.Media{
+		Hash:     crypto.HashFromBytes(a.Content),
+		MimeType: a.MimeType,
+		Size:     int64(len(a.Content)),
+		Content:  a.Content,
+	}
+}
+
+type messageParts struct {
+	html        string
+	text        string
+	attachments []*Attachment
+}
+
+var wordDecoder = &mime.WordDecoder{CharsetReader: encoding.CharsetReader}
+
+// Parse converts a MIME message into an entry.
+//
+// The HTML part is preferred over the plain text part, and the other parts are returned as attachments.
+// The entry URL uses the "mid" scheme (RFC 2392) since the emails are not available on the web.
+// The message IDs are chosen by the senders, the attachment URLs contain the ID of the feed to be unique per feed.
+func Parse(feedID int64, data []byte) (*Newsletter, error) {
+	message, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("newsletter: unable to parse the message: %v", err)
+	}
+
+	entryURL := messageURL(message.Header.Get("Message-Id"), data)
+
+	parts := &messageParts{}
+	if err := parts.walk(textproto.MIMEHeader(message.Header), message.Body, 0); err != nil {
+		return nil, err
+	}
+
+	entry := new(model.Entry)
+	entry.URL = entryURL
+	entry.Hash = crypto.Hash(entryURL)
+	entry.Title = decodeHeader(message.Header.Get("Subject"))
+	entry.Author = messageAuthor(message.Header.Get("From"))
+
+	entry.Date = time.Now()
+	if date, err := message.Header.Date(); err == nil {
+		entry.Date = date
+	}
+
+	if entry.Title == "" {
+		entry.Title = entry.Author
+	}
+
+	if parts.html != "" {
+		entry.Content = sanitizer.Sanitize(entryURL, parts.html)
+	} else {
+		entry.Content = textToHTML(parts.text)
+	}
+
+	for index, attachment := range parts.attachments {
+		attachment.URL = fmt.Sprintf("%s/%d/%d/%s", entryURL, feedID, index, url.PathEscape(attachment.Filename))
+		entry.Enclosures = append(entry.Enclosures, &model.Enclosure{
+			URL:      attachment.URL,
+			MimeType: attachment.MimeType,
+			Size:     int64(len(attachment.Content)),
+		})
+	}
+
+	return &Newsletter{Entry: entry, Attachments: parts.attachments}, nil
+}
+
+func (p *messageParts) walk(header textproto.MIMEHeader, body io.Reader, depth int) error {
+	if depth > maxPartDepth {
+		return fmt.Errorf("newsletter: too many nested parts")
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("newsletter: unable to read the message part: %v", err)
+			}
+
+			if err := p.walk(part.Header, part, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := io.ReadAll(decodeTransferEncoding(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return fmt.Errorf("newsletter: unable to decode the message part: %v", err)
+	}
+
+	disposition, dispositionParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	filename := decodeHeader(dispositionParams["filename"])
+	if filename == "" {
+		filename = decodeHeader(params["name"])
+	}
+
+	isBody := disposition != "attachment" && filename == ""
+	switch {
+	case isBody && mediaType == "text/html" && p.html == "":
+		p.html = decodeCharset(params["charset"], content)
+	case isBody && mediaType == "text/plain" && p.text == "":
+		p.text = decodeCharset(params["charset"], content)
+	case len(content) > 0:
+		if filename == "" {
+			filename = "attachment-" + strconv.Itoa(len(p.attachments)+1)
+		}
+		p.attachments = append(p.attachments, &Attachment{Filename: filename, MimeType: attachmentMimeType(mediaType), Content: content})
+	}
+
+	return nil
+}
+
+// attachmentMimeType returns the type used to serve the attachment from the media cache.
+// Documents that a browser could execute, like HTML or SVG, are downloaded instead.
+func attachmentMimeType(mediaType string) string {
+	switch {
+	case mediaType == "image/svg+xml":
+		return "application/octet-stream"
+	case strings.HasPrefix(mediaType, "image/"), strings.HasPrefix(mediaType, "audio/"), strings.HasPrefix(mediaType, "video/"):
+		return mediaType
+	case mediaType == "application/pdf", mediaType == "text/plain", mediaType == "text/calendar":
+		return mediaType
+	default:
+		return "application/octet-stream"
+	}
+}
+
+func decodeTransferEncoding(transferEncoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(transferEncoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
+
+func decodeCharset(label string, content []byte) string {
+	if label == "" {
+		return string(content)
+	}
+
+	reader, err := encoding.CharsetReader(label, bytes.NewReader(content))
+	if err != nil {
+		return string(content)
+	}
+
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return string(content)
+	}
+
+	return string(decoded)
+}
+
+func decodeHeader(value string) string {
+	decoded, err := wordDecoder.DecodeHeader(value)
+	if err != nil {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(decoded)
+}
+
+func messageAuthor(from string) string {
+	parser := &mail.AddressParser{WordDecoder: wordDecoder}
+	address, err := parser.Parse(from)
+	if err != nil {
+		return decodeHeader(from)
+	}
+
+	if address.Name != "" {
+		return address.Name
+	}
+	return address.Address
+}
+
+func messageURL(messageID string, data []byte) string {
+	messageID = strings.Trim(strings.TrimSpace(messageID), "<>")
+	if messageID == "" {
+		messageID = crypto.HashFromBytes(data)
+	}
+	return "mid:" + url.PathEscape(messageID)
+}
+
+// textToHTML converts a plain text body into paragraphs.
+func textToHTML(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var builder strings.Builder
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+
+		lines := strings.Split(html.EscapeString(paragraph), "\n")
+		builder.WriteString("<p>" + strings.Join(lines, "<br>") + "</p>")
+	}
+
+	return builder.String()
+}
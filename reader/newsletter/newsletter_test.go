@@ -0,0 +1,152 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package newsletter // import "miniflux.app/reader/newsletter"
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMultipartMessage(t *testing.T) {
+	data := strings.ReplaceAll(`From: =?utf-8?q?Caf=C3=A9_Weekly?= <news@example.org>
+To: abc123@newsletters.example.org
+Subject: =?utf-8?q?Issue_42:_caf=C3=A9?=
+Date: Wed, 01 Mar 2023 10:00:00 +0000
+Message-ID: <issue-42@example.org>
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="outer"
+
+--outer
+Content-Type: multipart/alternative; boundary="inner"
+
+--inner
+Content-Type: text/plain; charset=utf-8
+
+Plain version
+--inner
+Content-Type: text/html; charset=utf-8
+Content-Transfer-Encoding: quoted-printable
+
+<p>Hello <strong>caf=C3=A9</strong></p><script>alert(1)</script>
+--inner--
+--outer
+Content-Type: application/pdf; name="report.pdf"
+Content-Disposition: attachment; filename="report 2023.pdf"
+Content-Transfer-Encoding: base64
+
+JVBERi0xLjQK
+--outer--
+`, "\n", "\r\n")
+
+	newsletter, err := Parse(1, []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := newsletter.Entry
+	if entry.Title != "Issue 42: café" {
+		t.Errorf(`Unexpected title: %q`, entry.Title)
+	}
+
+	if entry.Author != "Café Weekly" {
+		t.Errorf(`Unexpected author: %q`, entry.Author)
+	}
+
+	if entry.URL != "mid:issue-42@example.org" {
+		t.Errorf(`Unexpected URL: %q`, entry.URL)
+	}
+
+	if !entry.Date.Equal(time.Date(2023, time.March, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf(`Unexpected date: %v`, entry.Date)
+	}
+
+	if entry.Content != "<p>Hello <strong>café</strong></p>" {
+		t.Errorf(`The HTML part should be sanitized: %q`, entry.Content)
+	}
+
+	if len(newsletter.Attachments) != 1 || len(entry.Enclosures) != 1 {
+		t.Fatalf(`Unexpected attachments: %+v`, newsletter.Attachments)
+	}
+
+	attachment := newsletter.Attachments[0]
+	if attachment.Filename != "report 2023.pdf" || attachment.MimeType != "application/pdf" || string(attachment.Content) != "%PDF-1.4\n" {
+		t.Errorf(`Unexpected attachment: %+v`, attachment)
+	}
+
+	enclosure := entry.Enclosures[0]
+	if enclosure.URL != "mid:issue-42@example.org/1/0/report%202023.pdf" || enclosure.URL != attachment.URL {
+		t.Errorf(`Unexpected enclosure URL: %q`, enclosure.URL)
+	}
+
+	if enclosure.Size != 9 || attachment.Media().Size != 9 {
+		t.Errorf(`Unexpected enclosure size: %d`, enclosure.Size)
+	}
+}
+
+func TestParsePlainTextMessage(t *testing.T) {
+	data := "From: news@example.org\r\n" +
+		"Subject: Hello\r\n" +
+		"Content-Type: text/plain; charset=iso-8859-1\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n" +
+		"\r\n" +
+		"Caf=E9 <b>\r\nsecond line\r\n\r\nNew paragraph\r\n"
+
+	newsletter, err := Parse(1, []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := newsletter.Entry
+	if entry.Content != "<p>Café &lt;b&gt;<br>second line</p><p>New paragraph</p>" {
+		t.Errorf(`Unexpected content: %q`, entry.Content)
+	}
+
+	if entry.Author != "news@example.org" {
+		t.Errorf(`Unexpected author: %q`, entry.Author)
+	}
+
+	if !strings.HasPrefix(entry.URL, "mid:") || len(entry.URL) < 10 {
+		t.Errorf(`Messages without identifier should have a URL computed from their content: %q`, entry.URL)
+	}
+
+	if len(newsletter.Attachments) != 0 {
+		t.Errorf(`Unexpected attachments: %+v`, newsletter.Attachments)
+	}
+}
+
+func TestParseMessageWithoutSubject(t *testing.T) {
+	newsletter, err := Parse(1, []byte("From: Weekly <news@example.org>\r\n\r\nBody\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if newsletter.Entry.Title != "Weekly" {
+		t.Errorf(`The sender should be used as title, got %q`, newsletter.Entry.Title)
+	}
+}
+
+func TestAttachmentMimeType(t *testing.T) {
+	scenarios := map[string]string{
+		"image/png":       "image/png",
+		"audio/mpeg":      "audio/mpeg",
+		"application/pdf": "application/pdf",
+		"image/svg+xml":   "application/octet-stream",
+		"text/html":       "application/octet-stream",
+		"application/xml": "application/octet-stream",
+	}
+
+	for input, expected := range scenarios {
+		if result := attachmentMimeType(input); result != expected {
+			t.Errorf(`Unexpected type for %q, got %q instead of %q`, input, result, expected)
+		}
+	}
+}
+
+func TestParseInvalidMessage(t *testing.T) {
+	if _, err := Parse(1, []byte("not an email")); err == nil {
+		t.Fatal(`Invalid messages should be rejected`)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"miniflux.app/metric"
 	"miniflux.app/model"
 	"miniflux.app/reader/browser"
+	"miniflux.app/reader/filter"
 	"miniflux.app/reader/rewrite"
 	"miniflux.app/reader/sanitizer"
 	"miniflux.app/reader/scraper"
@@ -41,8 +42,10 @@ var (
 func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.User) {
 	var filteredEntries model.Entries
 
-	// array used for bulk push
-	entriesToPush := model.Entries{}
+	userRules, err := filter.Parse(user.FilterRules)
+	if err != nil {
+		logger.Error("[Processor] Ignoring filter rules of user #%d: %v", user.ID, err)
+	}
 
 	for _, entry := range feed.Entries {
 		logger.Debug("[Processor] Processing entry %q from feed %q", entry.URL, feed.FeedURL)
@@ -51,6 +54,13 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.Us
 			continue
 		}
 
+		if userRules.IsBlocked(feed, entry) || !userRules.IsAllowed(feed, entry) {
+			logger.Debug("[Processor] Entry %q from feed %q filtered out by user rules", entry.Title, feed.FeedURL)
+			continue
+		}
+
+		userRules.Apply(feed, entry)
+
 		url := getUrlFromEntry(feed, entry)
 		entryIsNew := !store.EntryURLExists(feed.ID, entry.URL)
 		if feed.Crawler && entryIsNew {
@@ -87,33 +97,39 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.Us
 		// The sanitizer should always run at the end of the process to make sure unsafe HTML is filtered.
 		entry.Content = sanitizer.Sanitize(url, entry.Content)
 
-		if entryIsNew {
-			intg, err := store.Integration(feed.UserID)
-			if err != nil {
-				logger.Error("[Processor] Get integrations for user %d failed: %v; the refresh process will go on, but no integrations will run this time.", feed.UserID, err)
-			} else if intg != nil {
-				localEntry := entry
-				go func() {
-					integration.PushEntry(localEntry, intg)
-				}()
-				entriesToPush = append(entriesToPush, localEntry)
-			}
-		}
-
 		updateEntryReadingTime(store, feed, entry, entryIsNew, user)
 		filteredEntries = append(filteredEntries, entry)
 	}
 
+	feed.Entries = filteredEntries
+}
+
+// PushEntries sends the new entries of a feed to the integrations of the user in the background.
+//
+// The entries must be saved beforehand to have their ID, and must not be modified afterwards.
+func PushEntries(store *storage.Storage, feed *model.Feed, entries model.Entries) {
+	if len(entries) == 0 {
+		return
+	}
+
 	intg, err := store.Integration(feed.UserID)
 	if err != nil {
 		logger.Error("[Processor] Get integrations for user %d failed: %v; the refresh process will go on, but no integrations will run this time.", feed.UserID, err)
-	} else if intg != nil && len(entriesToPush) > 0 {
-		go func() {
-			integration.PushEntries(entriesToPush, intg)
-		}()
+		return
 	}
 
-	feed.Entries = filteredEntries
+	if intg == nil {
+		return
+	}
+
+	// The refresh goes on with the feed, the integrations receive a copy.
+	feedCopy := *feed
+	feedCopy.Entries = nil
+
+	go func() {
+		integration.PushEntries(&feedCopy, entries, intg)
+		integration.SendNotifications(&feedCopy, entries, intg)
+	}()
 }
 
 func isBlockedEntry(feed *model.Feed, entry *model.Entry) bool {
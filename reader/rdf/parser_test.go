@@ -577,3 +577,31 @@ func TestParseRDFWithEncodedHTMLDescription(t *testing.T) {
 		t.Errorf(`Unexpected entry URL, got %v instead of %v`, result, expected)
 	}
 }
+
+func TestParseSyndicationModule(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<rdf:RDF
+		xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+		xmlns:sy="http://purl.org/rss/1.0/modules/syndication/"
+		xmlns="http://purl.org/rss/1.0/">
+		<channel>
+			<title>Example</title>
+			<link>http://example.org/</link>
+			<sy:updatePeriod>hourly</sy:updatePeriod>
+			<sy:updateFrequency>2</sy:updateFrequency>
+		</channel>
+		<item>
+			<title>Title</title>
+			<link>http://example.org/item</link>
+		</item>
+	</rdf:RDF>`
+
+	feed, err := Parse("http://example.org/feed.rdf", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.PollingHints.UpdateInterval != 30 {
+		t.Errorf(`Unexpected update interval, got %d instead of 30`, feed.PollingHints.UpdateInterval)
+	}
+}
@@ -24,6 +24,7 @@ type rdfFeed struct {
 	Link    string    `xml:"channel>link"`
 	Items   []rdfItem `xml:"item"`
 	DublinCoreFeedElement
+	SyndicationFeedElement
 }
 
 func (r *rdfFeed) Transform(baseURL string) *model.Feed {
@@ -36,6 +37,8 @@ func (r *rdfFeed) Transform(baseURL string) *model.Feed {
 		feed.SiteURL = r.Link
 	}
 
+	feed.PollingHints.WithSyndication(r.SyndicationUpdatePeriod, r.SyndicationUpdateFrequency)
+
 	for _, item := range r.Items {
 		entry := item.Transform()
 		if entry.Author == "" && r.DublinCoreCreator != "" {
@@ -331,6 +331,59 @@ func TestParseFeedURLWithAtomLink(t *testing.T) {
 	}
 }
 
+func TestParseFeedWithWebSubHub(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss xmlns:atom="http://www.w3.org/2005/Atom" version="2.0">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<atom:link href="https://hub.example.org/" rel="hub"></atom:link>
+			<atom:link href="/rss" type="application/rss+xml" rel="self"></atom:link>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.FeedURL != "https://example.org/rss" {
+		t.Errorf("Incorrect feed URL, got: %s", feed.FeedURL)
+	}
+
+	if feed.HubURL != "https://hub.example.org/" {
+		t.Errorf("Incorrect hub URL, got: %s", feed.HubURL)
+	}
+
+	if feed.TopicURL != "https://example.org/rss" {
+		t.Errorf("Incorrect topic URL, got: %s", feed.TopicURL)
+	}
+}
+
+func TestParseFeedWithoutWebSubHub(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss xmlns:atom="http://www.w3.org/2005/Atom" version="2.0">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<atom:link href="https://example.org/rss" type="application/rss+xml" rel="self"></atom:link>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.HubURL != "" {
+		t.Errorf("Incorrect hub URL, got: %s", feed.HubURL)
+	}
+
+	if feed.TopicURL != "" {
+		t.Errorf("Incorrect topic URL, got: %s", feed.TopicURL)
+	}
+}
+
 func TestParseFeedWithWebmaster(t *testing.T) {
 	data := `<?xml version="1.0" encoding="utf-8"?>
 		<rss version="2.0">
@@ -1426,3 +1479,169 @@ func TestEntryDescriptionFromGooglePlayDescription(t *testing.T) {
 		t.Errorf(`Unexpected podcast content, got %q instead of %q`, result, expected)
 	}
 }
+
+func TestParsePodcastEpisodeMetadata(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<rss version="2.0"
+		xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd"
+		xmlns:psc="http://podlove.org/simple-chapters"
+		xmlns:podcast="https://podcastindex.org/namespace/1.0">
+		<channel>
+			<title>Podcast Example</title>
+			<link>http://www.example.com/index.html</link>
+			<itunes:image href="https://example.org/podcast.jpg"/>
+			<item>
+				<title>Episode 1</title>
+				<guid>http://example.com/episode1.mp3</guid>
+				<enclosure url="http://example.com/episode1.mp3" length="1234" type="audio/mpeg"/>
+				<itunes:duration>1:02:03</itunes:duration>
+				<itunes:image href="https://example.org/episode1.jpg"/>
+				<psc:chapters version="1.2">
+					<psc:chapter start="00:00:00.000" title="Introduction"/>
+					<psc:chapter start="00:05:30.500" title="Interview"/>
+					<psc:chapter start="01:00:00" title=""/>
+				</psc:chapters>
+				<podcast:chapters url="https://example.org/episode1.json" type="application/json+chapters"/>
+			</item>
+			<item>
+				<title>Episode 2</title>
+				<guid>http://example.com/episode2.mp3</guid>
+				<enclosure url="http://example.com/episode2.mp3" length="1234" type="audio/mpeg"/>
+				<enclosure url="http://example.com/episode2.jpg" length="10" type="image/jpeg"/>
+				<itunes:duration>3600</itunes:duration>
+			</item>
+		</channel>
+	</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries) != 2 {
+		t.Fatalf("Incorrect number of entries, got: %d", len(feed.Entries))
+	}
+
+	enclosure := feed.Entries[0].Enclosures[0]
+	if enclosure.Duration != 3723 {
+		t.Errorf(`Unexpected duration, got %d instead of 3723`, enclosure.Duration)
+	}
+
+	if enclosure.ArtworkURL != "https://example.org/episode1.jpg" {
+		t.Errorf(`Unexpected artwork, got %q`, enclosure.ArtworkURL)
+	}
+
+	if enclosure.ChaptersURL != "https://example.org/episode1.json" {
+		t.Errorf(`Unexpected chapters URL, got %q`, enclosure.ChaptersURL)
+	}
+
+	if len(enclosure.Chapters) != 2 {
+		t.Fatalf(`Unexpected number of chapters, got %d`, len(enclosure.Chapters))
+	}
+
+	if enclosure.Chapters[1].Start != 330 || enclosure.Chapters[1].Title != "Interview" {
+		t.Errorf(`Unexpected chapter, got %+v`, enclosure.Chapters[1])
+	}
+
+	enclosures := feed.Entries[1].Enclosures
+	if len(enclosures) != 2 {
+		t.Fatalf(`Unexpected number of enclosures, got %d`, len(enclosures))
+	}
+
+	if enclosures[0].Duration != 3600 {
+		t.Errorf(`Unexpected duration, got %d instead of 3600`, enclosures[0].Duration)
+	}
+
+	if enclosures[0].ArtworkURL != "https://example.org/podcast.jpg" {
+		t.Errorf(`The podcast artwork should be used by default, got %q`, enclosures[0].ArtworkURL)
+	}
+
+	if enclosures[1].Duration != 0 || enclosures[1].ArtworkURL != "" {
+		t.Errorf(`Images should not have podcast metadata, got %+v`, enclosures[1])
+	}
+}
+
+func TestParsePodcastDuration(t *testing.T) {
+	scenarios := map[string]int{
+		"":            0,
+		"3600":        3600,
+		" 45 ":        45,
+		"59:59":       3599,
+		"1:02:03":     3723,
+		"01:02:03.75": 3723,
+		"1:2:3:4":     0,
+		"abc":         0,
+		"-10":         0,
+	}
+
+	for input, expected := range scenarios {
+		if result := parseNormalPlayTime(input); result != expected {
+			t.Errorf(`Unexpected duration for %q, got %d instead of %d`, input, result, expected)
+		}
+	}
+}
+
+func TestParsePollingHints(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<rss version="2.0" xmlns:sy="http://purl.org/rss/1.0/modules/syndication/">
+		<channel>
+			<title>Example</title>
+			<link>http://www.example.com/index.html</link>
+			<ttl>60</ttl>
+			<sy:updatePeriod>daily</sy:updatePeriod>
+			<sy:updateFrequency>4</sy:updateFrequency>
+			<skipHours>
+				<hour>0</hour>
+				<hour>24</hour>
+				<hour>23</hour>
+				<hour>invalid</hour>
+			</skipHours>
+			<skipDays>
+				<day>Saturday</day>
+				<day>sunday</day>
+			</skipDays>
+			<item>
+				<title>Item</title>
+				<link>http://www.example.com/item</link>
+			</item>
+		</channel>
+	</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hints := feed.PollingHints
+	if hints.UpdateInterval != 360 {
+		t.Errorf(`Unexpected update interval, got %d instead of 360`, hints.UpdateInterval)
+	}
+
+	if len(hints.SkipHours) != 3 || hints.SkipHours[0] != 0 || hints.SkipHours[1] != 0 || hints.SkipHours[2] != 23 {
+		t.Errorf(`Unexpected skip hours: %v`, hints.SkipHours)
+	}
+
+	if len(hints.SkipDays) != 2 || hints.SkipDays[0] != time.Saturday || hints.SkipDays[1] != time.Sunday {
+		t.Errorf(`Unexpected skip days: %v`, hints.SkipDays)
+	}
+}
+
+func TestParseTTLWithoutSyndication(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<rss version="2.0">
+		<channel>
+			<title>Example</title>
+			<link>http://www.example.com/index.html</link>
+			<ttl> 90 </ttl>
+		</channel>
+	</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.PollingHints.UpdateInterval != 90 {
+		t.Errorf(`Unexpected update interval, got %d instead of 90`, feed.PollingHints.UpdateInterval)
+	}
+}
@@ -4,7 +4,12 @@
 
 package rss // import "miniflux.app/reader/rss"
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+
+	"miniflux.app/model"
+)
 
 // PodcastFeedElement represents iTunes and GooglePlay feed XML elements.
 // Specs:
@@ -16,13 +21,33 @@ type PodcastFeedElement struct {
 	Summary          string       `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd channel>summary"`
 	PodcastOwner     PodcastOwner `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd channel>owner"`
 	GooglePlayAuthor string       `xml:"http://www.google.com/schemas/play-podcasts/1.0 channel>author"`
+	ItunesImage      Image        `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd channel>image"`
 }
 
 // PodcastEntryElement represents iTunes and GooglePlay entry XML elements.
+// Chapters are read from the Podlove Simple Chapters and the Podcasting 2.0 namespaces:
+// - https://podlove.org/simple-chapters/
+// - https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#chapters
 type PodcastEntryElement struct {
-	Subtitle              string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd subtitle"`
-	Summary               string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary"`
-	GooglePlayDescription string `xml:"http://www.google.com/schemas/play-podcasts/1.0 description"`
+	Subtitle              string              `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd subtitle"`
+	Summary               string              `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary"`
+	GooglePlayDescription string              `xml:"http://www.google.com/schemas/play-podcasts/1.0 description"`
+	ItunesDuration        string              `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+	ItunesImage           Image               `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+	SimpleChapters        []PodcastChapter    `xml:"http://podlove.org/simple-chapters chapters>chapter"`
+	ChaptersLink          PodcastChaptersLink `xml:"https://podcastindex.org/namespace/1.0 chapters"`
+}
+
+// PodcastChapter represents a Podlove Simple Chapter.
+type PodcastChapter struct {
+	Start string `xml:"start,attr"`
+	Title string `xml:"title,attr"`
+}
+
+// PodcastChaptersLink represents a link to an external chapters file.
+type PodcastChaptersLink struct {
+	URL  string `xml:"url,attr"`
+	Type string `xml:"type,attr"`
 }
 
 // PodcastOwner represents contact information for the podcast owner.
@@ -68,3 +93,58 @@ func (e *PodcastEntryElement) PodcastDescription() string {
 	}
 	return strings.TrimSpace(description)
 }
+
+// PodcastDuration returns the duration of the episode in seconds.
+func (e *PodcastEntryElement) PodcastDuration() int {
+	return parseNormalPlayTime(e.ItunesDuration)
+}
+
+// PodcastChapters returns the chapters of the episode.
+func (e *PodcastEntryElement) PodcastChapters() model.EnclosureChapters {
+	var chapters model.EnclosureChapters
+	for _, chapter := range e.SimpleChapters {
+		title := strings.TrimSpace(chapter.Title)
+		if title == "" || strings.TrimSpace(chapter.Start) == "" {
+			continue
+		}
+
+		chapters = append(chapters, &model.EnclosureChapter{
+			Start: parseNormalPlayTime(chapter.Start),
+			Title: title,
+		})
+	}
+	return chapters
+}
+
+// parseNormalPlayTime converts durations such as "3600", "59:59", "1:02:03" or "01:02:03.500" to seconds.
+// Invalid values are ignored.
+func parseNormalPlayTime(value string) int {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	parts := strings.Split(value, ":")
+	if len(parts) > 3 {
+		return 0
+	}
+
+	seconds := 0
+	for i, part := range parts {
+		// Only the last part can contain fractions of a second.
+		if i == len(parts)-1 {
+			if index := strings.IndexByte(part, '.'); index >= 0 {
+				part = part[:index]
+			}
+		}
+
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return 0
+		}
+
+		seconds = seconds*60 + number
+	}
+
+	return seconds
+}
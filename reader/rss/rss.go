@@ -32,8 +32,12 @@ type rssFeed struct {
 	PubDate        string    `xml:"channel>pubDate"`
 	ManagingEditor string    `xml:"channel>managingEditor"`
 	Webmaster      string    `xml:"channel>webMaster"`
+	TTL            string    `xml:"channel>ttl"`
+	SkipHours      []string  `xml:"channel>skipHours>hour"`
+	SkipDays       []string  `xml:"channel>skipDays>day"`
 	Items          []rssItem `xml:"channel>item"`
 	PodcastFeedElement
+	SyndicationFeedElement
 }
 
 func (r *rssFeed) Transform(baseURL string) *model.Feed {
@@ -53,11 +57,27 @@ func (r *rssFeed) Transform(baseURL string) *model.Feed {
 		feed.FeedURL = feedURL
 	}
 
+	if hubURL := r.atomLinkWithRelation("hub"); hubURL != "" {
+		feed.HubURL, err = url.AbsoluteURL(baseURL, hubURL)
+		if err != nil {
+			feed.HubURL = hubURL
+		}
+
+		if feedURL != "" {
+			feed.TopicURL = feed.FeedURL
+		}
+	}
+
 	feed.Title = html.UnescapeString(strings.TrimSpace(r.Title))
 	if feed.Title == "" {
 		feed.Title = feed.SiteURL
 	}
 
+	feed.PollingHints.WithTTL(r.TTL)
+	feed.PollingHints.WithSyndication(r.SyndicationUpdatePeriod, r.SyndicationUpdateFrequency)
+	feed.PollingHints.WithSkipHours(r.SkipHours)
+	feed.PollingHints.WithSkipDays(r.SkipDays)
+
 	for _, item := range r.Items {
 		entry := item.Transform()
 		if entry.Author == "" {
@@ -81,6 +101,12 @@ func (r *rssFeed) Transform(baseURL string) *model.Feed {
 			entry.Title = entry.URL
 		}
 
+		for _, enclosure := range entry.Enclosures {
+			if enclosure.ArtworkURL == "" && isPodcastEnclosure(enclosure) {
+				enclosure.ArtworkURL = strings.TrimSpace(r.ItunesImage.URL)
+			}
+		}
+
 		feed.Entries = append(feed.Entries, entry)
 	}
 
@@ -98,8 +124,16 @@ func (r *rssFeed) siteURL() string {
 }
 
 func (r *rssFeed) feedURL() string {
+	if feedURL := r.atomLinkWithRelation("self"); feedURL != "" {
+		return feedURL
+	}
+
+	return r.atomLinkWithRelation("")
+}
+
+func (r *rssFeed) atomLinkWithRelation(relation string) string {
 	for _, element := range r.Links {
-		if element.XMLName.Space == "http://www.w3.org/2005/Atom" {
+		if element.XMLName.Space == "http://www.w3.org/2005/Atom" && strings.ToLower(strings.TrimSpace(element.Rel)) == relation {
 			return strings.TrimSpace(element.Href)
 		}
 	}
@@ -339,11 +373,20 @@ func (r *rssItem) entryEnclosures() model.EnclosureList {
 		if _, found := duplicates[enclosureURL]; !found {
 			duplicates[enclosureURL] = true
 
-			enclosures = append(enclosures, &model.Enclosure{
+			entryEnclosure := &model.Enclosure{
 				URL:      enclosureURL,
 				MimeType: enclosure.Type,
 				Size:     enclosure.Size(),
-			})
+			}
+
+			if isPodcastEnclosure(entryEnclosure) {
+				entryEnclosure.Duration = r.PodcastDuration()
+				entryEnclosure.ArtworkURL = strings.TrimSpace(r.ItunesImage.URL)
+				entryEnclosure.Chapters = r.PodcastChapters()
+				entryEnclosure.ChaptersURL = strings.TrimSpace(r.ChaptersLink.URL)
+			}
+
+			enclosures = append(enclosures, entryEnclosure)
 		}
 	}
 
@@ -372,6 +415,11 @@ func (r *rssItem) entryEnclosures() model.EnclosureList {
 	return enclosures
 }
 
+// isPodcastEnclosure returns true when the attachment is an audio or video episode.
+func isPodcastEnclosure(enclosure *model.Enclosure) bool {
+	return strings.HasPrefix(enclosure.MimeType, "audio/") || strings.HasPrefix(enclosure.MimeType, "video/")
+}
+
 func (r *rssItem) entryCommentsURL() string {
 	for _, commentLink := range r.CommentLinks {
 		if commentLink.XMLName.Space == "" {
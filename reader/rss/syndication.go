@@ -0,0 +1,12 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package rss // import "miniflux.app/reader/rss"
+
+// SyndicationFeedElement represents the elements of the RSS syndication module.
+// Specs: https://web.resource.org/rss/1.0/modules/syndication/
+type SyndicationFeedElement struct {
+	SyndicationUpdatePeriod    string `xml:"http://purl.org/rss/1.0/modules/syndication/ channel>updatePeriod"`
+	SyndicationUpdateFrequency string `xml:"http://purl.org/rss/1.0/modules/syndication/ channel>updateFrequency"`
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package rss // import "miniflux.app/reader/rss"
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+
+	"miniflux.app/model"
+)
+
+type rssWriterFeed struct {
+	XMLName     xml.Name         `xml:"rss"`
+	Version     string           `xml:"version,attr"`
+	AtomNS      string           `xml:"xmlns:atom,attr"`
+	DublinCore  string           `xml:"xmlns:dc,attr"`
+	Title       string           `xml:"channel>title"`
+	Link        string           `xml:"channel>link"`
+	SelfLink    rssWriterLink    `xml:"channel>atom:link"`
+	Description string           `xml:"channel>description"`
+	Items       []*rssWriterItem `xml:"channel>item"`
+}
+
+type rssWriterLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssWriterItem struct {
+	Title       string              `xml:"title"`
+	Link        string              `xml:"link"`
+	GUID        rssWriterGUID       `xml:"guid"`
+	PubDate     string              `xml:"pubDate"`
+	Creator     string              `xml:"dc:creator,omitempty"`
+	Categories  []string            `xml:"category"`
+	Description string              `xml:"description"`
+	Enclosure   *rssWriterEnclosure `xml:"enclosure,omitempty"`
+	Source      *rssWriterSource    `xml:"source,omitempty"`
+}
+
+type rssWriterGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Data        string `xml:",chardata"`
+}
+
+type rssWriterEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+type rssWriterSource struct {
+	URL  string `xml:"url,attr"`
+	Data string `xml:",chardata"`
+}
+
+// Write serializes the feed and its entries as a RSS 2.0 document.
+//
+// RSS items have only one enclosure, the other attachments of the entries are ignored.
+func Write(w io.Writer, feed *model.Feed) error {
+	output := &rssWriterFeed{
+		Version:     "2.0",
+		AtomNS:      "http://www.w3.org/2005/Atom",
+		DublinCore:  "http://purl.org/dc/elements/1.1/",
+		Title:       feed.Title,
+		Link:        feed.SiteURL,
+		SelfLink:    rssWriterLink{Href: feed.FeedURL, Rel: "self", Type: "application/rss+xml"},
+		Description: feed.Title,
+	}
+
+	for _, entry := range feed.Entries {
+		item := &rssWriterItem{
+			Title:       entry.Title,
+			Link:        entry.URL,
+			GUID:        rssWriterGUID{IsPermaLink: "false", Data: entry.URL},
+			PubDate:     entry.Date.Format(time.RFC1123Z),
+			Creator:     entry.Author,
+			Categories:  entry.Tags,
+			Description: entry.Content,
+		}
+
+		if len(entry.Enclosures) > 0 {
+			enclosure := entry.Enclosures[0]
+			item.Enclosure = &rssWriterEnclosure{
+				URL:    enclosure.URL,
+				Type:   enclosure.MimeType,
+				Length: strconv.FormatInt(enclosure.Size, 10),
+			}
+		}
+
+		if entry.Feed != nil && entry.Feed.FeedURL != "" {
+			item.Source = &rssWriterSource{URL: entry.Feed.FeedURL, Data: entry.Feed.Title}
+		}
+
+		output.Items = append(output.Items, item)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	return xml.NewEncoder(w).Encode(output)
+}
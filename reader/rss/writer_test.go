@@ -0,0 +1,83 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package rss // import "miniflux.app/reader/rss"
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/model"
+)
+
+func TestWriteRSSFeed(t *testing.T) {
+	feed := &model.Feed{
+		Title:   "Category <Go>",
+		FeedURL: "https://miniflux.example.org/feeds/category/1.rss",
+		SiteURL: "https://miniflux.example.org/category/1/entries",
+		Entries: model.Entries{
+			{
+				Title:   "First & last",
+				URL:     "https://example.org/first",
+				Author:  "Jane",
+				Content: `<p>Hello <a href="https://example.org/">world</a></p>`,
+				Date:    time.Date(2023, time.March, 1, 10, 0, 0, 0, time.UTC),
+				Feed:    &model.Feed{Title: "Example", FeedURL: "https://example.org/feed.xml"},
+				Enclosures: model.EnclosureList{
+					{URL: "https://example.org/episode.mp3", MimeType: "audio/mpeg", Size: 42},
+					{URL: "https://example.org/cover.jpg", MimeType: "image/jpeg"},
+				},
+			},
+		},
+	}
+
+	var buffer bytes.Buffer
+	if err := Write(&buffer, feed); err != nil {
+		t.Fatal(err)
+	}
+
+	output := buffer.String()
+	for _, expected := range []string{
+		`<atom:link href="https://miniflux.example.org/feeds/category/1.rss" rel="self" type="application/rss+xml"></atom:link>`,
+		`<dc:creator>Jane</dc:creator>`,
+		`<pubDate>Wed, 01 Mar 2023 10:00:00 +0000</pubDate>`,
+		`<source url="https://example.org/feed.xml">Example</source>`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf(`The document should contain %q: %s`, expected, output)
+		}
+	}
+
+	parsedFeed, err := Parse(feed.FeedURL, &buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parsedFeed.Title != feed.Title || parsedFeed.FeedURL != feed.FeedURL || parsedFeed.SiteURL != feed.SiteURL {
+		t.Errorf(`Unexpected feed: %q, %q, %q`, parsedFeed.Title, parsedFeed.FeedURL, parsedFeed.SiteURL)
+	}
+
+	if len(parsedFeed.Entries) != 1 {
+		t.Fatalf(`Unexpected number of entries: %d`, len(parsedFeed.Entries))
+	}
+
+	entry := parsedFeed.Entries[0]
+	if entry.Title != "First & last" || entry.URL != "https://example.org/first" || entry.Author != "Jane" {
+		t.Errorf(`Unexpected entry: %q, %q, %q`, entry.Title, entry.URL, entry.Author)
+	}
+
+	if entry.Content != feed.Entries[0].Content {
+		t.Errorf(`Unexpected content: %q`, entry.Content)
+	}
+
+	if !entry.Date.Equal(feed.Entries[0].Date) {
+		t.Errorf(`Unexpected date: %v`, entry.Date)
+	}
+
+	if len(entry.Enclosures) != 1 || entry.Enclosures[0].URL != "https://example.org/episode.mp3" || entry.Enclosures[0].Size != 42 {
+		t.Errorf(`Only the first enclosure should be written: %+v`, entry.Enclosures)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package scraper // import "miniflux.app/reader/scraper"
+
+import (
+	stdlib_url "net/url"
+	"strings"
+	"time"
+
+	"miniflux.app/crypto"
+	"miniflux.app/errors"
+	"miniflux.app/model"
+	"miniflux.app/reader/date"
+	"miniflux.app/url"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// FeedSelectors contains the CSS selectors used to build a feed from a web page.
+//
+// Only the item selector is mandatory, the other selectors are relative to each item:
+// the first link of the item is used by default and its text becomes the entry title.
+type FeedSelectors struct {
+	Item    string
+	Title   string
+	Link    string
+	Date    string
+	Content string
+}
+
+// NewFeedSelectors returns the selectors of a scraped feed.
+func NewFeedSelectors(feed *model.Feed) *FeedSelectors {
+	return &FeedSelectors{
+		Item:    feed.ItemSelector,
+		Title:   feed.TitleSelector,
+		Link:    feed.LinkSelector,
+		Date:    feed.DateSelector,
+		Content: feed.ContentSelector,
+	}
+}
+
+// ParseFeed builds a feed from the items of a web page.
+func ParseFeed(websiteURL, data string, selectors *FeedSelectors) (*model.Feed, *errors.LocalizedError) {
+	document, err := goquery.NewDocumentFromReader(strings.NewReader(data))
+	if err != nil {
+		return nil, errors.NewLocalizedError("Unable to parse the web page: %v", err)
+	}
+
+	feed := &model.Feed{
+		FeedURL: websiteURL,
+		SiteURL: websiteURL,
+		Title:   collapseSpaces(document.Find("title").First().Text()),
+	}
+
+	if feed.Title == "" {
+		feed.Title = url.Domain(websiteURL)
+	}
+
+	seen := make(map[string]bool)
+	document.Find(selectors.Item).Each(func(i int, item *goquery.Selection) {
+		entry := selectors.entry(websiteURL, item)
+		if entry == nil || seen[entry.Hash] {
+			return
+		}
+
+		seen[entry.Hash] = true
+		feed.Entries = append(feed.Entries, entry)
+	})
+
+	if len(feed.Entries) == 0 {
+		return nil, errors.NewLocalizedError("error.scraped_feed_no_items")
+	}
+
+	return feed, nil
+}
+
+// entry returns the entry described by an item of the page, items without link are ignored.
+func (s *FeedSelectors) entry(websiteURL string, item *goquery.Selection) *model.Entry {
+	link := item
+	if s.Link != "" {
+		link = item.Find(s.Link).First()
+	}
+
+	if _, found := link.Attr("href"); !found {
+		link = link.Find("a[href]").First()
+	}
+
+	href, found := link.Attr("href")
+	href = strings.TrimSpace(href)
+	if !found || href == "" || strings.HasPrefix(href, "#") {
+		return nil
+	}
+
+	entryURL, err := url.AbsoluteURL(websiteURL, href)
+	if err != nil || !isWebURL(entryURL) {
+		return nil
+	}
+
+	entry := &model.Entry{
+		URL:  entryURL,
+		Hash: crypto.Hash(entryURL),
+		Date: time.Now(),
+	}
+
+	title := link
+	if s.Title != "" {
+		title = item.Find(s.Title).First()
+	}
+
+	entry.Title = collapseSpaces(title.Text())
+	if entry.Title == "" {
+		entry.Title = entryURL
+	}
+
+	if s.Date != "" {
+		selection := item.Find(s.Date).First()
+		value, found := selection.Attr("datetime")
+		if !found {
+			value = selection.Text()
+		}
+
+		if publishedAt, err := date.Parse(strings.TrimSpace(value)); err == nil {
+			entry.Date = publishedAt
+		}
+	}
+
+	if s.Content != "" {
+		item.Find(s.Content).Each(func(i int, selection *goquery.Selection) {
+			content, _ := goquery.OuterHtml(selection)
+			entry.Content += content
+		})
+	}
+
+	return entry
+}
+
+func collapseSpaces(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// isWebURL returns true for http and https links, the other schemes like javascript: or mailto: are not entries.
+func isWebURL(link string) bool {
+	u, err := stdlib_url.Parse(link)
+	if err != nil {
+		return false
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	return (scheme == "http" || scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,152 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package scraper // import "miniflux.app/reader/scraper"
+
+import (
+	"testing"
+	"time"
+)
+
+const scrapedPage = `<!DOCTYPE html>
+<html>
+<head><title>
+	Example Blog
+</title></head>
+<body>
+	<nav><a href="/about">About</a></nav>
+	<article class="post">
+		<h2><a href="/posts/second">Second   post</a></h2>
+		<time datetime="2023-03-02T10:00:00Z">March 2</time>
+		<div class="summary"><p>Second summary</p></div>
+	</article>
+	<article class="post">
+		<h2><a href="https://example.org/posts/first">First post</a></h2>
+		<span class="date">Wed, 01 Mar 2023 08:00:00 GMT</span>
+		<div class="summary"><p>First summary</p></div>
+	</article>
+	<article class="post">
+		<h2>Post without link</h2>
+	</article>
+	<article class="post">
+		<h2><a href="/posts/second">Duplicate</a></h2>
+	</article>
+</body>
+</html>`
+
+func TestParseFeedWithSelectors(t *testing.T) {
+	selectors := &FeedSelectors{
+		Item:    "article.post",
+		Title:   "h2",
+		Link:    "h2 a",
+		Date:    "time, .date",
+		Content: ".summary",
+	}
+
+	feed, err := ParseFeed("https://example.org/blog/", scrapedPage, selectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "Example Blog" {
+		t.Errorf(`Unexpected feed title: %q`, feed.Title)
+	}
+
+	if feed.FeedURL != "https://example.org/blog/" || feed.SiteURL != "https://example.org/blog/" {
+		t.Errorf(`Unexpected feed URLs: %q, %q`, feed.FeedURL, feed.SiteURL)
+	}
+
+	if len(feed.Entries) != 2 {
+		t.Fatalf(`Items without link and duplicates should be ignored, got %d entries`, len(feed.Entries))
+	}
+
+	entry := feed.Entries[0]
+	if entry.Title != "Second post" {
+		t.Errorf(`Unexpected title: %q`, entry.Title)
+	}
+
+	if entry.URL != "https://example.org/posts/second" {
+		t.Errorf(`Unexpected URL: %q`, entry.URL)
+	}
+
+	if entry.Hash == "" {
+		t.Error(`The entry hash should be defined`)
+	}
+
+	if !entry.Date.Equal(time.Date(2023, time.March, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf(`The datetime attribute should be used, got %v`, entry.Date)
+	}
+
+	if entry.Content != `<div class="summary"><p>Second summary</p></div>` {
+		t.Errorf(`Unexpected content: %q`, entry.Content)
+	}
+
+	if !feed.Entries[1].Date.Equal(time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf(`The element text should be used when there is no datetime attribute, got %v`, feed.Entries[1].Date)
+	}
+}
+
+func TestParseFeedWithItemSelectorOnly(t *testing.T) {
+	feed, err := ParseFeed("https://example.org/blog/", scrapedPage, &FeedSelectors{Item: "article.post"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries) != 2 {
+		t.Fatalf(`Unexpected number of entries: %d`, len(feed.Entries))
+	}
+
+	if feed.Entries[1].Title != "First post" || feed.Entries[1].URL != "https://example.org/posts/first" {
+		t.Errorf(`The item link should be used: %q, %q`, feed.Entries[1].Title, feed.Entries[1].URL)
+	}
+
+	if feed.Entries[1].Content != "" {
+		t.Errorf(`The content should be empty without content selector: %q`, feed.Entries[1].Content)
+	}
+}
+
+func TestParseFeedWithoutItems(t *testing.T) {
+	if _, err := ParseFeed("https://example.org/", scrapedPage, &FeedSelectors{Item: "li.missing"}); err == nil {
+		t.Error(`Pages without items should be rejected`)
+	}
+}
+
+func TestParseFeedIgnoresNonWebLinks(t *testing.T) {
+	page := `<html><body>
+		<div class="post"><a href="javascript:alert(1)">Script</a></div>
+		<div class="post"><a href=" JavaScript:alert(2)">Script with spaces</a></div>
+		<div class="post"><a href="mailto:author@example.org">Mail</a></div>
+		<div class="post"><a href="data:text/html,hello">Data</a></div>
+		<div class="post"><a href="#comments">Anchor</a></div>
+		<div class="post"><a href="ftp://example.org/file">File</a></div>
+		<div class="post"><a href="/posts/web">Web</a></div>
+	</body></html>`
+
+	feed, err := ParseFeed("https://example.org/", page, &FeedSelectors{Item: "div.post"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries) != 1 || feed.Entries[0].URL != "https://example.org/posts/web" {
+		t.Errorf(`Only the web links should be entries, got %d entries`, len(feed.Entries))
+	}
+}
+
+func TestIsWebURL(t *testing.T) {
+	scenarios := map[string]bool{
+		"https://example.org/":  true,
+		"HTTP://example.org/a":  true,
+		"javascript:alert(1)":   false,
+		"mailto:a@example.org":  false,
+		"ftp://example.org/":    false,
+		"http:///path/only":     false,
+		"data:text/plain,hello": false,
+	}
+
+	for input, expected := range scenarios {
+		if result := isWebURL(input); result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, input, result, expected)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"miniflux.app/storage"
 	"miniflux.app/ui"
 	"miniflux.app/version"
+	"miniflux.app/websub"
 	"miniflux.app/worker"
 
 	"github.com/gorilla/mux"
@@ -36,10 +37,11 @@ func Serve(store *storage.Storage, pool *worker.Pool) *http.Server {
 	keyFile := config.Opts.CertKeyFile()
 	certDomain := config.Opts.CertDomain()
 	listenAddr := config.Opts.ListenAddr()
+	serverTimeout := time.Duration(config.Opts.HTTPServerTimeout()) * time.Second
 	server := &http.Server{
-		ReadTimeout:  300 * time.Second,
-		WriteTimeout: 300 * time.Second,
-		IdleTimeout:  300 * time.Second,
+		ReadTimeout:  serverTimeout,
+		WriteTimeout: serverTimeout,
+		IdleTimeout:  serverTimeout,
 		Handler:      setupHandler(store, pool),
 	}
 
@@ -184,6 +186,11 @@ func setupHandler(store *storage.Storage, pool *worker.Pool) *mux.Router {
 
 	fever.Serve(router, store)
 	googlereader.Serve(router, store)
+
+	if config.Opts.HasWebSub() {
+		websub.Serve(router, store)
+	}
+
 	api.Serve(router, store, pool)
 	ui.Serve(router, store, pool)
 
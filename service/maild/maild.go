@@ -0,0 +1,348 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package maild // import "miniflux.app/service/maild"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/reader/newsletter"
+	"miniflux.app/reader/processor"
+	"miniflux.app/storage"
+)
+
+const (
+	commandTimeout = 5 * time.Minute
+	maxRecipients  = 100
+
+	// Only the owner and the group of the socket can deliver emails, the group must be the one of the mail server.
+	socketFileMode = 0660
+)
+
+// Server receives the newsletters sent to the feed addresses.
+type Server struct {
+	store    *storage.Storage
+	listener net.Listener
+	sessions sync.WaitGroup
+}
+
+// Serve starts a new SMTP/LMTP server.
+func Serve(store *storage.Storage) *Server {
+	listenAddr := config.Opts.NewsletterListenAddr()
+	server := &Server{store: store}
+
+	var err error
+	if strings.HasPrefix(listenAddr, "/") {
+		os.Remove(listenAddr)
+		server.listener, err = net.Listen("unix", listenAddr)
+		if err == nil {
+			err = os.Chmod(listenAddr, socketFileMode)
+		}
+	} else {
+		server.listener, err = net.Listen("tcp", listenAddr)
+	}
+
+	if err != nil {
+		logger.Fatal(`[Maild] Server failed to start: %v`, err)
+	}
+
+	logger.Info(`[Maild] Listening on %q for newsletters sent to *@%s`, listenAddr, config.Opts.NewsletterDomain())
+	go server.acceptConnections()
+
+	return server
+}
+
+// Shutdown stops accepting connections and waits for the sessions in progress.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.listener.Close()
+
+	done := make(chan struct{})
+	go func() {
+		s.sessions.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Server) acceptConnections() {
+	for {
+		conn, err := s.listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			logger.Error(`[Maild] Unable to accept connection: %v`, err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		s.sessions.Add(1)
+		go func() {
+			defer s.sessions.Done()
+			defer conn.Close()
+			newSession(s.store, conn).serve()
+		}()
+	}
+}
+
+type session struct {
+	store      *storage.Storage
+	conn       net.Conn
+	text       *textproto.Conn
+	lmtp       bool
+	hasSender  bool
+	recipients []*model.Feed
+}
+
+func newSession(store *storage.Storage, conn net.Conn) *session {
+	return &session{store: store, conn: conn, text: textproto.NewConn(conn)}
+}
+
+func (s *session) serve() {
+	domain := config.Opts.NewsletterDomain()
+	s.reply(220, domain+" Miniflux newsletter service ready")
+
+	for {
+		s.conn.SetDeadline(time.Now().Add(commandTimeout))
+
+		line, err := s.text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, args, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "HELO":
+			s.reset()
+			s.reply(250, domain)
+		case "EHLO", "LHLO":
+			s.reset()
+			s.lmtp = strings.EqualFold(verb, "LHLO")
+			s.reply(250, domain, "8BITMIME", "SIZE "+strconv.FormatInt(config.Opts.NewsletterMaxMessageSize(), 10))
+		case "MAIL":
+			s.handleMail(args)
+		case "RCPT":
+			s.handleRecipient(args, domain)
+		case "DATA":
+			s.handleData()
+		case "RSET":
+			s.reset()
+			s.reply(250, "OK")
+		case "NOOP":
+			s.reply(250, "OK")
+		case "VRFY":
+			s.reply(252, "Cannot verify the mailbox")
+		case "QUIT":
+			s.reply(221, "Bye")
+			return
+		default:
+			s.reply(502, "Command not implemented")
+		}
+	}
+}
+
+func (s *session) reset() {
+	s.hasSender = false
+	s.recipients = nil
+}
+
+func (s *session) handleMail(args string) {
+	if !strings.HasPrefix(strings.ToUpper(args), "FROM:") {
+		s.reply(501, "Syntax: MAIL FROM:<address>")
+		return
+	}
+
+	for _, param := range strings.Fields(args[5:]) {
+		if name, value, found := strings.Cut(param, "="); found && strings.EqualFold(name, "SIZE") {
+			if size, err := strconv.ParseInt(value, 10, 64); err == nil && size > config.Opts.NewsletterMaxMessageSize() {
+				s.reply(552, "Message size exceeds the limit")
+				return
+			}
+		}
+	}
+
+	s.reset()
+	s.hasSender = true
+	s.reply(250, "OK")
+}
+
+func (s *session) handleRecipient(args, domain string) {
+	if !s.hasSender {
+		s.reply(503, "Need MAIL command first")
+		return
+	}
+
+	if !strings.HasPrefix(strings.ToUpper(args), "TO:") {
+		s.reply(501, "Syntax: RCPT TO:<address>")
+		return
+	}
+
+	if len(s.recipients) >= maxRecipients {
+		s.reply(452, "Too many recipients")
+		return
+	}
+
+	address := strings.Fields(args[3:])
+	if len(address) == 0 {
+		s.reply(501, "Syntax: RCPT TO:<address>")
+		return
+	}
+
+	localPart, recipientDomain, found := strings.Cut(strings.Trim(address[0], "<>"), "@")
+	if !found || !strings.EqualFold(recipientDomain, domain) {
+		s.reply(550, "Mailbox unavailable")
+		return
+	}
+
+	// Sub-addresses like "token+anything" are delivered to the same feed.
+	token, _, _ := strings.Cut(strings.ToLower(localPart), "+")
+
+	feed, err := s.store.FeedByNewsletterToken(token)
+	if err != nil {
+		logger.Error("[Maild] %v", err)
+		s.reply(451, "Temporary failure")
+		return
+	}
+
+	if feed == nil {
+		s.reply(550, "Mailbox unavailable")
+		return
+	}
+
+	s.recipients = append(s.recipients, feed)
+	s.reply(250, "OK")
+}
+
+func (s *session) handleData() {
+	if len(s.recipients) == 0 {
+		s.reply(503, "Need RCPT command first")
+		return
+	}
+
+	s.reply(354, "End data with <CR><LF>.<CR><LF>")
+
+	maxSize := config.Opts.NewsletterMaxMessageSize()
+	reader := s.text.DotReader()
+	data, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return
+	}
+
+	recipients := s.recipients
+	s.reset()
+
+	if int64(len(data)) > maxSize {
+		io.Copy(io.Discard, reader)
+		s.replyForEachRecipient(recipients, 552, "Message size exceeds the limit")
+		return
+	}
+
+	var failed bool
+	for _, feed := range recipients {
+		if err := deliver(s.store, feed, data); err != nil {
+			logger.Error("[Maild] Feed #%d: %v", feed.ID, err)
+			failed = true
+			if s.lmtp {
+				s.reply(451, "Unable to deliver the message")
+			}
+			continue
+		}
+
+		if s.lmtp {
+			s.reply(250, "OK")
+		}
+	}
+
+	if !s.lmtp {
+		if failed {
+			s.reply(451, "Unable to deliver the message")
+		} else {
+			s.reply(250, "OK")
+		}
+	}
+}
+
+func (s *session) replyForEachRecipient(recipients []*model.Feed, code int, message string) {
+	if !s.lmtp {
+		s.reply(code, message)
+		return
+	}
+
+	for range recipients {
+		s.reply(code, message)
+	}
+}
+
+func (s *session) reply(code int, lines ...string) {
+	for i, line := range lines {
+		separator := " "
+		if i < len(lines)-1 {
+			separator = "-"
+		}
+		s.text.PrintfLine("%d%s%s", code, separator, line)
+	}
+}
+
+// deliver converts the message into an entry of the newsletter feed, attachments are stored in the media cache.
+func deliver(store *storage.Storage, feed *model.Feed, data []byte) error {
+	message, err := newsletter.Parse(feed.ID, data)
+	if err != nil {
+		return err
+	}
+
+	user, err := store.UserByID(feed.UserID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return fmt.Errorf("user #%d not found", feed.UserID)
+	}
+
+	feed.Entries = model.Entries{message.Entry}
+	processor.ProcessFeedEntries(store, feed, user)
+
+	newEntries, _, err := store.RefreshFeedEntries(feed.UserID, feed.ID, feed.Entries, false)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("[Maild] Feed #%d: received %q (%d new entries)", feed.ID, message.Entry.Title, len(newEntries))
+
+	// Entries without ID were already received or removed by the feed filters.
+	for _, entry := range feed.Entries {
+		if entry.ID == 0 {
+			continue
+		}
+
+		for _, attachment := range message.Attachments {
+			if err := store.CreateEntryMedia(entry.ID, attachment.URL, attachment.Media()); err != nil {
+				return err
+			}
+		}
+	}
+
+	processor.PushEntries(store, feed, newEntries)
+
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package scheduler // import "miniflux.app/service/scheduler"
+
+import (
+	"strings"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/integration/email"
+	"miniflux.app/locale"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+	"miniflux.app/template"
+	"miniflux.app/timezone"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// Digests are sent in the morning, in the timezone of each user.
+	digestHour = 7
+
+	digestEntriesPerCategory = 10
+)
+
+// digestCategory represents the unread entries of a category included in the digest.
+type digestCategory struct {
+	Category *model.Category
+	Entries  model.Entries
+}
+
+func digestScheduler(store *storage.Storage) {
+	tpl := template.NewEngine(mux.NewRouter())
+	if err := tpl.ParseTemplates(); err != nil {
+		logger.Error("[Scheduler:Digest] %v", err)
+		return
+	}
+
+	client := email.NewClient(
+		config.Opts.SMTPHost(),
+		config.Opts.SMTPPort(),
+		config.Opts.SMTPUsername(),
+		config.Opts.SMTPPassword(),
+		config.Opts.SMTPFromAddress(),
+	)
+
+	for range time.Tick(15 * time.Minute) {
+		recipients, err := store.DigestRecipients()
+		if err != nil {
+			logger.Error("[Scheduler:Digest] %v", err)
+			continue
+		}
+
+		for _, recipient := range recipients {
+			now := timezone.Now(recipient.Timezone)
+			scheduledAt := lastDigestSchedule(recipient.Frequency, now)
+			if scheduledAt.IsZero() || (recipient.SentAt != nil && !recipient.SentAt.Before(scheduledAt)) {
+				continue
+			}
+
+			if err := sendDigest(store, tpl, client, recipient, scheduledAt); err != nil {
+				logger.Error("[Scheduler:Digest] User #%d: %v", recipient.UserID, err)
+				continue
+			}
+
+			if err := store.SetDigestSentAt(recipient.UserID, now); err != nil {
+				logger.Error("[Scheduler:Digest] %v", err)
+			}
+		}
+	}
+}
+
+func sendDigest(store *storage.Storage, tpl *template.Engine, client *email.Client, recipient *model.DigestRecipient, scheduledAt time.Time) error {
+	categories, err := store.Categories(recipient.UserID)
+	if err != nil {
+		return err
+	}
+
+	since := previousDigestSchedule(recipient.Frequency, scheduledAt)
+
+	var digest []*digestCategory
+	var nbEntries int
+	for _, category := range categories {
+		if category.ExcludeFromDigest {
+			continue
+		}
+
+		builder := store.NewEntryQueryBuilder(recipient.UserID)
+		builder.WithCategoryID(category.ID)
+		builder.WithStatus(model.EntryStatusUnread)
+		builder.AfterDate(since)
+		builder.WithOrder(model.DefaultSortingOrder)
+		builder.WithDirection("desc")
+		builder.WithLimit(digestEntriesPerCategory)
+
+		entries, err := builder.GetEntries()
+		if err != nil {
+			return err
+		}
+
+		if len(entries) > 0 {
+			digest = append(digest, &digestCategory{Category: category, Entries: entries})
+			nbEntries += len(entries)
+		}
+	}
+
+	if nbEntries == 0 {
+		logger.Debug("[Scheduler:Digest] No unread entries for User #%d", recipient.UserID)
+		return nil
+	}
+
+	printer := locale.NewPrinter(recipient.Language)
+	subject := printer.Plural("email.digest.subject", nbEntries, nbEntries)
+
+	htmlBody := tpl.Render("digest.html", map[string]interface{}{
+		"language":   recipient.Language,
+		"timezone":   recipient.Timezone,
+		"subject":    subject,
+		"categories": digest,
+	})
+
+	logger.Debug("[Scheduler:Digest] Sending %d entries to User #%d", nbEntries, recipient.UserID)
+	return client.Send(&email.Message{
+		To:       recipient.Address,
+		Subject:  subject,
+		Body:     digestTextBody(digest),
+		HTMLBody: string(htmlBody),
+	})
+}
+
+func digestTextBody(digest []*digestCategory) string {
+	var builder strings.Builder
+	for _, category := range digest {
+		builder.WriteString(category.Category.Title + "\n\n")
+		for _, entry := range category.Entries {
+			builder.WriteString("- " + entry.Title + " (" + entry.Feed.Title + ")\n  " + entry.URL + "\n")
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// lastDigestSchedule returns the most recent time a digest was due, a zero time is returned for unknown frequencies.
+// Weekly digests are sent on Monday.
+func lastDigestSchedule(frequency string, now time.Time) time.Time {
+	scheduledAt := time.Date(now.Year(), now.Month(), now.Day(), digestHour, 0, 0, 0, now.Location())
+
+	switch frequency {
+	case model.DigestFrequencyDaily:
+		if now.Before(scheduledAt) {
+			scheduledAt = scheduledAt.AddDate(0, 0, -1)
+		}
+	case model.DigestFrequencyWeekly:
+		scheduledAt = scheduledAt.AddDate(0, 0, -(int(scheduledAt.Weekday())+6)%7)
+		if now.Before(scheduledAt) {
+			scheduledAt = scheduledAt.AddDate(0, 0, -7)
+		}
+	default:
+		return time.Time{}
+	}
+
+	return scheduledAt
+}
+
+// previousDigestSchedule returns the schedule that precedes the given one, the digest includes the entries published since then.
+func previousDigestSchedule(frequency string, scheduledAt time.Time) time.Time {
+	if frequency == model.DigestFrequencyWeekly {
+		return scheduledAt.AddDate(0, 0, -7)
+	}
+	return scheduledAt.AddDate(0, 0, -1)
+}
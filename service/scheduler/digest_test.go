@@ -0,0 +1,62 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package scheduler // import "miniflux.app/service/scheduler"
+
+import (
+	"testing"
+	"time"
+
+	"miniflux.app/model"
+)
+
+func TestLastDigestSchedule(t *testing.T) {
+	location, err := time.LoadLocation("America/Montreal")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	scenarios := []struct {
+		frequency string
+		now       time.Time
+		expected  time.Time
+	}{
+		// Wednesday morning, before and after the digest hour.
+		{model.DigestFrequencyDaily, time.Date(2023, time.March, 1, 6, 59, 0, 0, location), time.Date(2023, time.February, 28, 7, 0, 0, 0, location)},
+		{model.DigestFrequencyDaily, time.Date(2023, time.March, 1, 7, 0, 0, 0, location), time.Date(2023, time.March, 1, 7, 0, 0, 0, location)},
+		{model.DigestFrequencyWeekly, time.Date(2023, time.March, 1, 12, 0, 0, 0, location), time.Date(2023, time.February, 27, 7, 0, 0, 0, location)},
+
+		// Monday, before and after the digest hour.
+		{model.DigestFrequencyWeekly, time.Date(2023, time.March, 6, 6, 0, 0, 0, location), time.Date(2023, time.February, 27, 7, 0, 0, 0, location)},
+		{model.DigestFrequencyWeekly, time.Date(2023, time.March, 6, 8, 0, 0, 0, location), time.Date(2023, time.March, 6, 7, 0, 0, 0, location)},
+
+		// Sunday.
+		{model.DigestFrequencyWeekly, time.Date(2023, time.March, 5, 23, 0, 0, 0, location), time.Date(2023, time.February, 27, 7, 0, 0, 0, location)},
+	}
+
+	for _, scenario := range scenarios {
+		result := lastDigestSchedule(scenario.frequency, scenario.now)
+		if !result.Equal(scenario.expected) {
+			t.Errorf(`Unexpected %s schedule for %v, got %v instead of %v`, scenario.frequency, scenario.now, result, scenario.expected)
+		}
+	}
+}
+
+func TestLastDigestScheduleWhenDisabled(t *testing.T) {
+	if result := lastDigestSchedule(model.DigestFrequencyNone, time.Now()); !result.IsZero() {
+		t.Errorf(`Disabled digests should not be scheduled, got %v`, result)
+	}
+}
+
+func TestPreviousDigestSchedule(t *testing.T) {
+	scheduledAt := time.Date(2023, time.March, 6, 7, 0, 0, 0, time.UTC)
+
+	if result := previousDigestSchedule(model.DigestFrequencyDaily, scheduledAt); !result.Equal(scheduledAt.AddDate(0, 0, -1)) {
+		t.Errorf(`Unexpected previous daily schedule: %v`, result)
+	}
+
+	if result := previousDigestSchedule(model.DigestFrequencyWeekly, scheduledAt); !result.Equal(scheduledAt.AddDate(0, 0, -7)) {
+		t.Errorf(`Unexpected previous weekly schedule: %v`, result)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"miniflux.app/metric"
 	"miniflux.app/model"
 	"miniflux.app/storage"
+	"miniflux.app/websub"
 	"miniflux.app/worker"
 )
 
@@ -33,7 +34,16 @@ func Serve(store *storage.Storage, pool *worker.Pool) {
 		config.Opts.CleanupArchiveUnreadDays(),
 		config.Opts.CleanupArchiveBatchSize(),
 		config.Opts.CleanupRemoveSessionsDays(),
+		config.Opts.CleanupRemoveFeedHistoryDays(),
 	)
+
+	if config.Opts.HasWebSub() {
+		go webSubScheduler(store)
+	}
+
+	if config.Opts.HasSMTP() {
+		go digestScheduler(store)
+	}
 }
 
 func feedScheduler(store *storage.Storage, pool *worker.Pool, frequency, batchSize int) {
@@ -48,12 +58,21 @@ func feedScheduler(store *storage.Storage, pool *worker.Pool, frequency, batchSi
 	}
 }
 
-func cleanupScheduler(store *storage.Storage, frequency, archiveReadDays, archiveUnreadDays, archiveBatchSize, sessionsDays int) {
+func webSubScheduler(store *storage.Storage) {
+	for range time.Tick(time.Hour) {
+		websub.RenewSubscriptions(store)
+	}
+}
+
+func cleanupScheduler(store *storage.Storage, frequency, archiveReadDays, archiveUnreadDays, archiveBatchSize, sessionsDays, feedHistoryDays int) {
 	for range time.Tick(time.Duration(frequency) * time.Hour) {
 		nbSessions := store.CleanOldSessions(sessionsDays)
 		nbUserSessions := store.CleanOldUserSessions(sessionsDays)
 		logger.Info("[Scheduler:Cleanup] Cleaned %d sessions and %d user sessions", nbSessions, nbUserSessions)
 
+		nbFeedRefreshes := store.CleanOldFeedRefreshes(feedHistoryDays)
+		logger.Info("[Scheduler:Cleanup] Cleaned %d feed refreshes", nbFeedRefreshes)
+
 		startTime := time.Now()
 		if rowsAffected, err := store.ArchiveEntries(model.EntryStatusRead, archiveReadDays, archiveBatchSize); err != nil {
 			logger.Error("[Scheduler:ArchiveReadEntries] %v", err)
@@ -75,5 +94,11 @@ func cleanupScheduler(store *storage.Storage, frequency, archiveReadDays, archiv
 				metric.ArchiveEntriesDuration.WithLabelValues(model.EntryStatusUnread).Observe(time.Since(startTime).Seconds())
 			}
 		}
+
+		if nbMedia, err := store.RemoveArchivedMedia(); err != nil {
+			logger.Error("[Scheduler:Cleanup] %v", err)
+		} else {
+			logger.Info("[Scheduler:Cleanup] Removed %d files from the media cache", nbMedia)
+		}
 	}
 }
@@ -0,0 +1,239 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/model"
+)
+
+// Annotations returns the annotations of an entry sorted by position in the content.
+func (s *Storage) Annotations(userID, entryID int64) (model.Annotations, error) {
+	query := `
+		SELECT
+			id, user_id, entry_id, quote, start_offset, end_offset, note, created_at, updated_at
+		FROM
+			annotations
+		WHERE
+			user_id=$1 AND entry_id=$2
+		ORDER BY
+			start_offset ASC, id ASC
+	`
+	rows, err := s.db.Query(query, userID, entryID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch annotations: %v`, err)
+	}
+	defer rows.Close()
+
+	annotations := make(model.Annotations, 0)
+	for rows.Next() {
+		var annotation model.Annotation
+		if err := rows.Scan(
+			&annotation.ID,
+			&annotation.UserID,
+			&annotation.EntryID,
+			&annotation.Quote,
+			&annotation.StartOffset,
+			&annotation.EndOffset,
+			&annotation.Note,
+			&annotation.CreatedAt,
+			&annotation.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch annotation row: %v`, err)
+		}
+
+		annotations = append(annotations, &annotation)
+	}
+
+	return annotations, nil
+}
+
+// UserAnnotations returns all the annotations of the given user, grouped by entry.
+func (s *Storage) UserAnnotations(userID int64) (map[int64]model.Annotations, error) {
+	query := `
+		SELECT
+			id, user_id, entry_id, quote, start_offset, end_offset, note, created_at, updated_at
+		FROM
+			annotations
+		WHERE
+			user_id=$1
+		ORDER BY
+			entry_id ASC, start_offset ASC, id ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch annotations: %v`, err)
+	}
+	defer rows.Close()
+
+	annotations := make(map[int64]model.Annotations)
+	for rows.Next() {
+		var annotation model.Annotation
+		if err := rows.Scan(
+			&annotation.ID,
+			&annotation.UserID,
+			&annotation.EntryID,
+			&annotation.Quote,
+			&annotation.StartOffset,
+			&annotation.EndOffset,
+			&annotation.Note,
+			&annotation.CreatedAt,
+			&annotation.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch annotation row: %v`, err)
+		}
+
+		annotations[annotation.EntryID] = append(annotations[annotation.EntryID], &annotation)
+	}
+
+	return annotations, nil
+}
+
+// Annotation returns a single annotation that belongs to the given user.
+func (s *Storage) Annotation(userID, annotationID int64) (*model.Annotation, error) {
+	query := `
+		SELECT
+			id, user_id, entry_id, quote, start_offset, end_offset, note, created_at, updated_at
+		FROM
+			annotations
+		WHERE
+			id=$1 AND user_id=$2
+	`
+
+	var annotation model.Annotation
+	err := s.db.QueryRow(query, annotationID, userID).Scan(
+		&annotation.ID,
+		&annotation.UserID,
+		&annotation.EntryID,
+		&annotation.Quote,
+		&annotation.StartOffset,
+		&annotation.EndOffset,
+		&annotation.Note,
+		&annotation.CreatedAt,
+		&annotation.UpdatedAt,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch annotation: %v`, err)
+	default:
+		return &annotation, nil
+	}
+}
+
+// CreateAnnotation attaches a new annotation to an entry and refreshes the search index of the entry.
+func (s *Storage) CreateAnnotation(userID, entryID int64, request *model.AnnotationRequest) (*model.Annotation, error) {
+	annotation := &model.Annotation{UserID: userID, EntryID: entryID}
+	request.Patch(annotation)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	query := `
+		INSERT INTO annotations
+			(user_id, entry_id, quote, start_offset, end_offset, note)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		RETURNING
+			id, created_at, updated_at
+	`
+	err = tx.QueryRow(
+		query,
+		userID,
+		entryID,
+		annotation.Quote,
+		annotation.StartOffset,
+		annotation.EndOffset,
+		annotation.Note,
+	).Scan(&annotation.ID, &annotation.CreatedAt, &annotation.UpdatedAt)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf(`store: unable to create annotation for entry #%d: %v`, entryID, err)
+	}
+
+	if err := s.updateEntryDocumentVectors(tx, userID, entryID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return annotation, nil
+}
+
+// UpdateAnnotation updates an existing annotation and refreshes the search index of the entry.
+func (s *Storage) UpdateAnnotation(annotation *model.Annotation) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	query := `
+		UPDATE
+			annotations
+		SET
+			quote=$1, start_offset=$2, end_offset=$3, note=$4, updated_at=now()
+		WHERE
+			id=$5 AND user_id=$6
+		RETURNING
+			updated_at
+	`
+	err = tx.QueryRow(
+		query,
+		annotation.Quote,
+		annotation.StartOffset,
+		annotation.EndOffset,
+		annotation.Note,
+		annotation.ID,
+		annotation.UserID,
+	).Scan(&annotation.UpdatedAt)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to update annotation #%d: %v`, annotation.ID, err)
+	}
+
+	if err := s.updateEntryDocumentVectors(tx, annotation.UserID, annotation.EntryID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// RemoveAnnotation deletes an annotation and refreshes the search index of the entry.
+func (s *Storage) RemoveAnnotation(annotation *model.Annotation) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM annotations WHERE id=$1 AND user_id=$2`, annotation.ID, annotation.UserID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to remove annotation #%d: %v`, annotation.ID, err)
+	}
+
+	if err := s.updateEntryDocumentVectors(tx, annotation.UserID, annotation.EntryID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
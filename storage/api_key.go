@@ -18,6 +18,14 @@ func (s *Storage) APIKeyExists(userID int64, description string) bool {
 	return result
 }
 
+// APIKeyTokenExists checks if an API Key with the same token exists.
+func (s *Storage) APIKeyTokenExists(token string) bool {
+	var result bool
+	query := `SELECT true FROM api_keys WHERE token=$1 LIMIT 1`
+	s.db.QueryRow(query, token).Scan(&result)
+	return result
+}
+
 // SetAPIKeyUsedTimestamp updates the last used date of an API Key.
 func (s *Storage) SetAPIKeyUsedTimestamp(userID int64, token string) error {
 	query := `UPDATE api_keys SET last_used_at=now() WHERE user_id=$1 and token=$2`
@@ -0,0 +1,174 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"miniflux.app/database"
+
+	"github.com/lib/pq"
+)
+
+// The SQLite driver translates placeholders, "= ANY($n)" conditions, boolean literals and casts.
+// The functions below return the SQL fragments that cannot be translated automatically.
+
+// timestampInUserTimezone returns the column converted to the timezone of the user.
+func (s *Storage) timestampInUserTimezone(column string) string {
+	if s.sqlite {
+		// Dates are stored in UTC and converted with timezone.Convert().
+		return column
+	}
+	return column + " at time zone u.timezone"
+}
+
+// searchCondition returns the full-text search condition for the given placeholder.
+func (s *Storage) searchCondition(placeholder int) string {
+	if s.sqlite {
+		return fmt.Sprintf(
+			"(instr(lower(e.title), lower($%[1]d)) > 0 OR instr(lower(e.content), lower($%[1]d)) > 0 OR %[2]s)",
+			placeholder,
+			s.annotationsCondition(placeholder),
+		)
+	}
+	return fmt.Sprintf("e.document_vectors @@ plainto_tsquery($%d)", placeholder)
+}
+
+// wordsSearchCondition returns the full-text search condition matching all the words, the placeholders start at the given number.
+func (s *Storage) wordsSearchCondition(placeholder int, words []string) (string, []interface{}) {
+	if s.sqlite {
+		conditions := make([]string, 0, len(words))
+		args := make([]interface{}, 0, len(words))
+		for i, word := range words {
+			conditions = append(conditions, s.searchCondition(placeholder+i))
+			args = append(args, word)
+		}
+		return strings.Join(conditions, " AND "), args
+	}
+	return s.searchCondition(placeholder), []interface{}{strings.Join(words, " ")}
+}
+
+// phraseSearchCondition returns the full-text search condition matching the words in the given order.
+func (s *Storage) phraseSearchCondition(placeholder int) string {
+	if s.sqlite {
+		return s.searchCondition(placeholder)
+	}
+	return fmt.Sprintf("e.document_vectors @@ phraseto_tsquery($%d)", placeholder)
+}
+
+// containsCondition returns a case-insensitive substring condition on the given column.
+func (s *Storage) containsCondition(column string, placeholder int) string {
+	if s.sqlite {
+		return fmt.Sprintf("instr(lower(%s), lower($%d)) > 0", column, placeholder)
+	}
+	return fmt.Sprintf("strpos(lower(%s), lower($%d)) > 0", column, placeholder)
+}
+
+// annotationsCondition returns the condition matching the entries having a quote or a note that contains the given text.
+func (s *Storage) annotationsCondition(placeholder int) string {
+	return fmt.Sprintf(
+		"EXISTS (SELECT 1 FROM annotations an WHERE an.entry_id=e.id AND (%s OR %s))",
+		s.containsCondition("an.quote", placeholder),
+		s.containsCondition("an.note", placeholder),
+	)
+}
+
+// searchOrder returns the sorting expression of full-text search results.
+func (s *Storage) searchOrder(placeholder int) string {
+	if s.sqlite {
+		return "e.published_at"
+	}
+
+	// 0.0000001 = 0.1 / (seconds_in_a_day)
+	return fmt.Sprintf("ts_rank(document_vectors, plainto_tsquery($%d)) - extract (epoch from now() - published_at)::float * 0.0000001", placeholder)
+}
+
+// entryTagsColumn returns the sorted list of tag titles attached to the entry "e".
+func (s *Storage) entryTagsColumn() string {
+	if s.sqlite {
+		return `coalesce((SELECT json_group_array(title) FROM (SELECT t.title FROM entry_tags et JOIN tags t ON t.id=et.tag_id WHERE et.entry_id=e.id ORDER BY t.title)), '[]')`
+	}
+	return `coalesce((SELECT array_agg(t.title ORDER BY t.title) FROM entry_tags et JOIN tags t ON t.id=et.tag_id WHERE et.entry_id=e.id), '{}')`
+}
+
+// updateEntryDocumentVectors refreshes the full-text search index of the entry.
+func (s *Storage) updateEntryDocumentVectors(tx *sql.Tx, userID, entryID int64) error {
+	if s.sqlite {
+		// SQLite databases search directly in the title, the content and the annotations.
+		return nil
+	}
+
+	query := `
+		UPDATE
+			entries
+		SET
+			document_vectors = setweight(to_tsvector(left(coalesce(title, ''), 500000)), 'A') ||
+				setweight(to_tsvector(left(coalesce(content, ''), 500000)), 'B') ||
+				setweight(to_tsvector(coalesce((SELECT string_agg(quote || ' ' || note, ' ') FROM annotations WHERE entry_id=entries.id), '')), 'C')
+		WHERE
+			id=$1 AND user_id=$2
+	`
+	if _, err := tx.Exec(query, entryID, userID); err != nil {
+		return fmt.Errorf(`store: unable to update search index of entry #%d: %v`, entryID, err)
+	}
+
+	return nil
+}
+
+// stringList scans a list of strings returned as a PostgreSQL array or as a JSON array by SQLite.
+type stringList []string
+
+func (l *stringList) Scan(src interface{}) error {
+	var value string
+	switch v := src.(type) {
+	case []byte:
+		value = string(v)
+	case string:
+		value = v
+	default:
+		return fmt.Errorf(`store: unable to scan list of type %T`, src)
+	}
+
+	if strings.HasPrefix(value, "[") {
+		return json.Unmarshal([]byte(value), (*[]string)(l))
+	}
+
+	var values pq.StringArray
+	if err := values.Scan(value); err != nil {
+		return err
+	}
+
+	*l = stringList(values)
+	return nil
+}
+
+// nullTimestamp scans a nullable timestamp computed by an aggregate function, SQLite returns it as text.
+type nullTimestamp struct {
+	Time  time.Time
+	Valid bool
+}
+
+func (t *nullTimestamp) Scan(src interface{}) (err error) {
+	switch v := src.(type) {
+	case nil:
+		t.Time, t.Valid = time.Time{}, false
+		return nil
+	case time.Time:
+		t.Time = v
+	case []byte:
+		t.Time, err = time.Parse(database.SQLiteTimestampFormat, string(v))
+	case string:
+		t.Time, err = time.Parse(database.SQLiteTimestampFormat, v)
+	default:
+		return fmt.Errorf(`store: unable to scan timestamp of type %T`, src)
+	}
+
+	t.Valid = err == nil
+	return err
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+	"time"
+
+	"miniflux.app/model"
+)
+
+// DigestRecipients returns the users who have enabled the email digest.
+func (s *Storage) DigestRecipients() ([]*model.DigestRecipient, error) {
+	query := `
+		SELECT
+			u.id,
+			u.language,
+			u.timezone,
+			i.email_notification_address,
+			i.email_digest_frequency,
+			i.email_digest_sent_at
+		FROM
+			integrations i
+		JOIN
+			users u ON u.id=i.user_id
+		WHERE
+			i.email_digest_frequency <> $1 AND i.email_notification_address <> ''
+	`
+	rows, err := s.db.Query(query, model.DigestFrequencyNone)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch digest recipients: %v`, err)
+	}
+	defer rows.Close()
+
+	var recipients []*model.DigestRecipient
+	for rows.Next() {
+		var recipient model.DigestRecipient
+		if err := rows.Scan(
+			&recipient.UserID,
+			&recipient.Language,
+			&recipient.Timezone,
+			&recipient.Address,
+			&recipient.Frequency,
+			&recipient.SentAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch digest recipient row: %v`, err)
+		}
+
+		recipients = append(recipients, &recipient)
+	}
+
+	return recipients, nil
+}
+
+// SetDigestSentAt records when the last email digest has been sent to the user.
+func (s *Storage) SetDigestSentAt(userID int64, sentAt time.Time) error {
+	query := `UPDATE integrations SET email_digest_sent_at=$1 WHERE user_id=$2`
+	if _, err := s.db.Exec(query, sentAt, userID); err != nil {
+		return fmt.Errorf(`store: unable to update digest date: %v`, err)
+	}
+
+	return nil
+}
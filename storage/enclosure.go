@@ -6,9 +6,12 @@ package storage // import "miniflux.app/storage"
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 
 	"miniflux.app/model"
+
+	"github.com/lib/pq"
 )
 
 // GetEnclosures returns all attachments for the given entry.
@@ -20,7 +23,13 @@ func (s *Storage) GetEnclosures(entryID int64) (model.EnclosureList, error) {
 			entry_id,
 			url,
 			size,
-			mime_type
+			mime_type,
+			duration,
+			artwork_url,
+			chapters,
+			chapters_url,
+			media_progression,
+			played
 		FROM
 			enclosures
 		WHERE
@@ -37,6 +46,7 @@ func (s *Storage) GetEnclosures(entryID int64) (model.EnclosureList, error) {
 	enclosures := make(model.EnclosureList, 0)
 	for rows.Next() {
 		var enclosure model.Enclosure
+		var chapters string
 		err := rows.Scan(
 			&enclosure.ID,
 			&enclosure.UserID,
@@ -44,18 +54,155 @@ func (s *Storage) GetEnclosures(entryID int64) (model.EnclosureList, error) {
 			&enclosure.URL,
 			&enclosure.Size,
 			&enclosure.MimeType,
+			&enclosure.Duration,
+			&enclosure.ArtworkURL,
+			&chapters,
+			&enclosure.ChaptersURL,
+			&enclosure.MediaProgression,
+			&enclosure.Played,
 		)
 
 		if err != nil {
 			return nil, fmt.Errorf(`store: unable to fetch enclosure row: %v`, err)
 		}
 
+		enclosure.Chapters = decodeEnclosureChapters(chapters)
+
 		enclosures = append(enclosures, &enclosure)
 	}
 
 	return enclosures, nil
 }
 
+// UserEnclosures returns all the attachments of the given user, grouped by entry.
+func (s *Storage) UserEnclosures(userID int64) (map[int64]model.EnclosureList, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			entry_id,
+			url,
+			size,
+			mime_type,
+			duration,
+			artwork_url,
+			chapters,
+			chapters_url,
+			media_progression,
+			played
+		FROM
+			enclosures
+		WHERE
+			user_id = $1
+		ORDER BY id ASC
+	`
+
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch enclosures: %v`, err)
+	}
+	defer rows.Close()
+
+	enclosures := make(map[int64]model.EnclosureList)
+	for rows.Next() {
+		var enclosure model.Enclosure
+		var chapters string
+		err := rows.Scan(
+			&enclosure.ID,
+			&enclosure.UserID,
+			&enclosure.EntryID,
+			&enclosure.URL,
+			&enclosure.Size,
+			&enclosure.MimeType,
+			&enclosure.Duration,
+			&enclosure.ArtworkURL,
+			&chapters,
+			&enclosure.ChaptersURL,
+			&enclosure.MediaProgression,
+			&enclosure.Played,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch enclosure row: %v`, err)
+		}
+
+		enclosure.Chapters = decodeEnclosureChapters(chapters)
+
+		enclosures[enclosure.EntryID] = append(enclosures[enclosure.EntryID], &enclosure)
+	}
+
+	return enclosures, nil
+}
+
+// GetEnclosure returns an attachment of the given user.
+func (s *Storage) GetEnclosure(userID, enclosureID int64) (*model.Enclosure, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			entry_id,
+			url,
+			size,
+			mime_type,
+			duration,
+			artwork_url,
+			chapters,
+			chapters_url,
+			media_progression,
+			played
+		FROM
+			enclosures
+		WHERE
+			id = $1 AND user_id = $2
+	`
+
+	var enclosure model.Enclosure
+	var chapters string
+	err := s.db.QueryRow(query, enclosureID, userID).Scan(
+		&enclosure.ID,
+		&enclosure.UserID,
+		&enclosure.EntryID,
+		&enclosure.URL,
+		&enclosure.Size,
+		&enclosure.MimeType,
+		&enclosure.Duration,
+		&enclosure.ArtworkURL,
+		&chapters,
+		&enclosure.ChaptersURL,
+		&enclosure.MediaProgression,
+		&enclosure.Played,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch enclosure #%d: %v`, enclosureID, err)
+	}
+
+	enclosure.Chapters = decodeEnclosureChapters(chapters)
+	return &enclosure, nil
+}
+
+// UpdateEnclosurePlayback saves the playback position and the played state of an attachment.
+func (s *Storage) UpdateEnclosurePlayback(enclosure *model.Enclosure) error {
+	query := `
+		UPDATE
+			enclosures
+		SET
+			media_progression=$1,
+			played=$2
+		WHERE
+			id=$3 AND user_id=$4
+	`
+	_, err := s.db.Exec(query, enclosure.MediaProgression, enclosure.Played, enclosure.ID, enclosure.UserID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update enclosure #%d: %v`, enclosure.ID, err)
+	}
+
+	return nil
+}
+
 func (s *Storage) createEnclosure(tx *sql.Tx, enclosure *model.Enclosure) error {
 	if enclosure.URL == "" {
 		return nil
@@ -63,9 +210,9 @@ func (s *Storage) createEnclosure(tx *sql.Tx, enclosure *model.Enclosure) error
 
 	query := `
 		INSERT INTO enclosures
-			(url, size, mime_type, entry_id, user_id)
+			(url, size, mime_type, duration, artwork_url, chapters, chapters_url, entry_id, user_id)
 		VALUES
-			($1, $2, $3, $4, $5)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING
 			id
 	`
@@ -74,6 +221,10 @@ func (s *Storage) createEnclosure(tx *sql.Tx, enclosure *model.Enclosure) error
 		enclosure.URL,
 		enclosure.Size,
 		enclosure.MimeType,
+		enclosure.Duration,
+		enclosure.ArtworkURL,
+		encodeEnclosureChapters(enclosure.Chapters),
+		enclosure.ChaptersURL,
 		enclosure.EntryID,
 		enclosure.UserID,
 	).Scan(&enclosure.ID)
@@ -85,17 +236,95 @@ func (s *Storage) createEnclosure(tx *sql.Tx, enclosure *model.Enclosure) error
 	return nil
 }
 
-func (s *Storage) updateEnclosures(tx *sql.Tx, userID, entryID int64, enclosures model.EnclosureList) error {
-	// We delete all attachments in the transaction to keep only the ones visible in the feeds.
-	if _, err := tx.Exec(`DELETE FROM enclosures WHERE user_id=$1 AND entry_id=$2`, userID, entryID); err != nil {
-		return err
+// updateEnclosure refreshes the metadata of an existing attachment, the playback state is preserved.
+// It returns false when the attachment doesn't exist yet.
+func (s *Storage) updateEnclosure(tx *sql.Tx, enclosure *model.Enclosure) (bool, error) {
+	query := `
+		UPDATE
+			enclosures
+		SET
+			size=$1,
+			mime_type=$2,
+			duration=$3,
+			artwork_url=$4,
+			chapters=$5,
+			chapters_url=$6
+		WHERE
+			user_id=$7 AND entry_id=$8 AND url=$9
+		RETURNING
+			id, media_progression, played
+	`
+	err := tx.QueryRow(
+		query,
+		enclosure.Size,
+		enclosure.MimeType,
+		enclosure.Duration,
+		enclosure.ArtworkURL,
+		encodeEnclosureChapters(enclosure.Chapters),
+		enclosure.ChaptersURL,
+		enclosure.UserID,
+		enclosure.EntryID,
+		enclosure.URL,
+	).Scan(&enclosure.ID, &enclosure.MediaProgression, &enclosure.Played)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf(`store: unable to update enclosure %q: %v`, enclosure.URL, err)
 	}
 
+	return true, nil
+}
+
+func (s *Storage) updateEnclosures(tx *sql.Tx, userID, entryID int64, enclosures model.EnclosureList) error {
+	// Existing attachments are updated in place to keep their playback state.
+	enclosureIDs := make([]int64, 0, len(enclosures))
 	for _, enclosure := range enclosures {
-		if err := s.createEnclosure(tx, enclosure); err != nil {
+		if enclosure.URL == "" {
+			continue
+		}
+
+		found, err := s.updateEnclosure(tx, enclosure)
+		if err != nil {
 			return err
 		}
+
+		if !found {
+			if err := s.createEnclosure(tx, enclosure); err != nil {
+				return err
+			}
+		}
+
+		enclosureIDs = append(enclosureIDs, enclosure.ID)
+	}
+
+	// We delete the other attachments to keep only the ones visible in the feeds.
+	query := `DELETE FROM enclosures WHERE user_id=$1 AND entry_id=$2 AND NOT (id=ANY($3))`
+	if _, err := tx.Exec(query, userID, entryID, pq.Array(enclosureIDs)); err != nil {
+		return err
 	}
 
 	return nil
 }
+
+func encodeEnclosureChapters(chapters model.EnclosureChapters) string {
+	if len(chapters) == 0 {
+		return ""
+	}
+
+	data, err := json.Marshal(chapters)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+func decodeEnclosureChapters(data string) model.EnclosureChapters {
+	var chapters model.EnclosureChapters
+	if data != "" {
+		json.Unmarshal([]byte(data), &chapters)
+	}
+	return chapters
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"miniflux.app/crypto"
+	"miniflux.app/event"
 	"miniflux.app/logger"
 	"miniflux.app/model"
 
@@ -86,18 +87,9 @@ func (s *Storage) UpdateEntryContent(entry *model.Entry) error {
 		return fmt.Errorf(`store: unable to update content of entry #%d: %v`, entry.ID, err)
 	}
 
-	query = `
-		UPDATE
-			entries
-		SET
-			document_vectors = setweight(to_tsvector(left(coalesce(title, ''), 500000)), 'A') || setweight(to_tsvector(left(coalesce(content, ''), 500000)), 'B')
-		WHERE
-			id=$1 AND user_id=$2
-	`
-	_, err = tx.Exec(query, entry.ID, entry.UserID)
-	if err != nil {
+	if err := s.updateEntryDocumentVectors(tx, entry.UserID, entry.ID); err != nil {
 		tx.Rollback()
-		return fmt.Errorf(`store: unable to update content of entry #%d: %v`, entry.ID, err)
+		return err
 	}
 
 	return tx.Commit()
@@ -118,8 +110,9 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 				user_id,
 				feed_id,
 				reading_time,
-				changed_at,
-				document_vectors
+				status,
+				starred,
+				changed_at
 			)
 		VALUES
 			(
@@ -133,12 +126,17 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 				$8,
 				$9,
 				$10,
-				now(),
-				setweight(to_tsvector(left(coalesce($1, ''), 500000)), 'A') || setweight(to_tsvector(left(coalesce($6, ''), 500000)), 'B')
+				$11,
+				$12,
+				now()
 			)
 		RETURNING
 			id, status
 	`
+	if entry.Status == "" {
+		entry.Status = model.EntryStatusUnread
+	}
+
 	err := tx.QueryRow(
 		query,
 		entry.Title,
@@ -151,6 +149,8 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 		entry.UserID,
 		entry.FeedID,
 		entry.ReadingTime,
+		entry.Status,
+		entry.Starred,
 	).Scan(&entry.ID, &entry.Status)
 
 	if err != nil {
@@ -166,13 +166,35 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 		}
 	}
 
+	if err := s.updateEntryDocumentVectors(tx, entry.UserID, entry.ID); err != nil {
+		return err
+	}
+
+	for _, title := range normalizeTagTitles(entry.Tags) {
+		if err := s.tagEntries(tx, entry.UserID, []int64{entry.ID}, title); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 // updateEntry updates an entry when a feed is refreshed.
 // Note: we do not update the published date because some feeds do not contains any date,
 // it default to time.Now() which could change the order of items on the history page.
-func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
+// It returns true if the title or the content of the entry changed.
+func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) (bool, error) {
+	var title, content string
+	err := tx.QueryRow(
+		`SELECT title, coalesce(content, '') FROM entries WHERE user_id=$1 AND feed_id=$2 AND hash=$3`,
+		entry.UserID,
+		entry.FeedID,
+		entry.Hash,
+	).Scan(&title, &content)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to fetch entry %q: %v`, entry.URL, err)
+	}
+
 	query := `
 		UPDATE
 			entries
@@ -182,14 +204,13 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 			comments_url=$3,
 			content=$4,
 			author=$5,
-			reading_time=$6,
-			document_vectors = setweight(to_tsvector(left(coalesce($1, ''), 500000)), 'A') || setweight(to_tsvector(left(coalesce($4, ''), 500000)), 'B')
+			reading_time=$6
 		WHERE
 			user_id=$7 AND feed_id=$8 AND hash=$9
 		RETURNING
 			id
 	`
-	err := tx.QueryRow(
+	err = tx.QueryRow(
 		query,
 		entry.Title,
 		entry.URL,
@@ -203,7 +224,11 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 	).Scan(&entry.ID)
 
 	if err != nil {
-		return fmt.Errorf(`store: unable to update entry %q: %v`, entry.URL, err)
+		return false, fmt.Errorf(`store: unable to update entry %q: %v`, entry.URL, err)
+	}
+
+	if err := s.updateEntryDocumentVectors(tx, entry.UserID, entry.ID); err != nil {
+		return false, err
 	}
 
 	for _, enclosure := range entry.Enclosures {
@@ -211,7 +236,11 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 		enclosure.EntryID = entry.ID
 	}
 
-	return s.updateEnclosures(tx, entry.UserID, entry.ID, entry.Enclosures)
+	if err := s.updateEnclosures(tx, entry.UserID, entry.ID, entry.Enclosures); err != nil {
+		return false, err
+	}
+
+	return title != entry.Title || content != entry.Content, nil
 }
 
 // entryExists checks if an entry already exists based on its hash when refreshing a feed.
@@ -258,8 +287,12 @@ func (s *Storage) cleanupEntries(feedID int64, entryHashes []string) error {
 }
 
 // RefreshFeedEntries updates feed entries while refreshing a feed.
-func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (err error) {
+// It returns the created entries and the number of existing entries whose title or content changed.
+func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (model.Entries, int, error) {
 	var entryHashes []string
+	var newEntries model.Entries
+	var newEntryIDs []int64
+	var updatedCount int
 
 	for _, entry := range entries {
 		entry.UserID = userID
@@ -267,39 +300,235 @@ func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries
 
 		tx, err := s.db.Begin()
 		if err != nil {
-			return fmt.Errorf(`store: unable to start transaction: %v`, err)
+			return nil, 0, fmt.Errorf(`store: unable to start transaction: %v`, err)
 		}
 
 		if s.entryExists(tx, entry) {
 			if updateExistingEntries {
-				err = s.updateEntry(tx, entry)
+				var updated bool
+				if updated, err = s.updateEntry(tx, entry); updated {
+					updatedCount++
+				}
 			}
 		} else {
 			err = s.createEntry(tx, entry)
+			newEntries = append(newEntries, entry)
+			newEntryIDs = append(newEntryIDs, entry.ID)
 		}
 
 		if err != nil {
 			tx.Rollback()
-			return err
+			return nil, 0, err
 		}
 
 		if err := tx.Commit(); err != nil {
-			return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+			return nil, 0, fmt.Errorf(`store: unable to commit transaction: %v`, err)
 		}
 
 		entryHashes = append(entryHashes, entry.Hash)
 	}
 
+	if len(newEntryIDs) > 0 {
+		s.publishEvent(userID, &event.Event{
+			Type: event.NewEntriesType,
+			Data: &event.NewEntries{FeedID: feedID, EntryIDs: newEntryIDs},
+		})
+	}
+
 	go func() {
 		if err := s.cleanupEntries(feedID, entryHashes); err != nil {
 			logger.Error(`store: feed #%d: %v`, feedID, err)
 		}
 	}()
 
+	return newEntries, updatedCount, nil
+}
+
+// ImportFeedEntries creates or updates the entries of a feed from an account archive.
+// Existing entries are matched by their hash, only their status, bookmark, tags, enclosures, share and annotations are updated.
+func (s *Storage) ImportFeedEntries(userID, feedID int64, entries model.Entries) error {
+	for _, entry := range entries {
+		entry.UserID = userID
+		entry.FeedID = feedID
+
+		// The upsert of the attachments keeps the playback state stored in the database, the archived one is applied afterwards.
+		playback := make([]model.Enclosure, len(entry.Enclosures))
+		for i, enclosure := range entry.Enclosures {
+			playback[i] = *enclosure
+		}
+
+		tx, err := s.db.Begin()
+		if err != nil {
+			return fmt.Errorf(`store: unable to start transaction: %v`, err)
+		}
+
+		if s.entryExists(tx, entry) {
+			err = s.updateImportedEntry(tx, entry)
+		} else {
+			err = s.createEntry(tx, entry)
+		}
+
+		if err == nil {
+			err = s.importEntryState(tx, entry, playback)
+		}
+
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Storage) updateImportedEntry(tx *sql.Tx, entry *model.Entry) error {
+	query := `
+		UPDATE
+			entries
+		SET
+			status=$1,
+			starred=$2,
+			changed_at=now()
+		WHERE
+			user_id=$3 AND feed_id=$4 AND hash=$5
+		RETURNING
+			id
+	`
+	err := tx.QueryRow(
+		query,
+		entry.Status,
+		entry.Starred,
+		entry.UserID,
+		entry.FeedID,
+		entry.Hash,
+	).Scan(&entry.ID)
+
+	if err != nil {
+		return fmt.Errorf(`store: unable to update entry %q: %v`, entry.URL, err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM entry_tags WHERE entry_id=$1`, entry.ID); err != nil {
+		return fmt.Errorf(`store: unable to remove tags of entry #%d: %v`, entry.ID, err)
+	}
+
+	for _, title := range normalizeTagTitles(entry.Tags) {
+		if err := s.tagEntries(tx, entry.UserID, []int64{entry.ID}, title); err != nil {
+			return err
+		}
+	}
+
+	if err := s.removeOrphanTags(tx, entry.UserID); err != nil {
+		return err
+	}
+
+	for _, enclosure := range entry.Enclosures {
+		enclosure.UserID = entry.UserID
+		enclosure.EntryID = entry.ID
+	}
+
+	return s.updateEnclosures(tx, entry.UserID, entry.ID, entry.Enclosures)
+}
+
+// importEntryState restores the share code and comment, the playback state of the attachments and the annotations of an imported entry.
+func (s *Storage) importEntryState(tx *sql.Tx, entry *model.Entry, playback []model.Enclosure) error {
+	// Share codes are unique across all users, a code already used by another entry is not restored.
+	if entry.ShareCode != "" {
+		query := `
+			UPDATE
+				entries
+			SET
+				share_code=$1,
+				share_comment=$2
+			WHERE
+				id=$3 AND NOT EXISTS (SELECT 1 FROM entries WHERE share_code=$1 AND id <> $3)
+		`
+		if _, err := tx.Exec(query, entry.ShareCode, entry.ShareComment, entry.ID); err != nil {
+			return fmt.Errorf(`store: unable to restore share code of entry #%d: %v`, entry.ID, err)
+		}
+	}
+
+	for i, enclosure := range entry.Enclosures {
+		if enclosure.URL == "" {
+			continue
+		}
+
+		enclosure.MediaProgression = playback[i].MediaProgression
+		enclosure.Played = playback[i].Played
+
+		query := `UPDATE enclosures SET media_progression=$1, played=$2 WHERE id=$3 AND user_id=$4`
+		if _, err := tx.Exec(query, enclosure.MediaProgression, enclosure.Played, enclosure.ID, entry.UserID); err != nil {
+			return fmt.Errorf(`store: unable to restore playback state of enclosure #%d: %v`, enclosure.ID, err)
+		}
+	}
+
+	created := false
+	for _, annotation := range entry.Annotations {
+		// Annotations already imported are not duplicated.
+		var exists bool
+		query := `
+			SELECT
+				true
+			FROM
+				annotations
+			WHERE
+				user_id=$1 AND entry_id=$2 AND quote=$3 AND start_offset=$4 AND end_offset=$5 AND note=$6
+			LIMIT 1
+		`
+		tx.QueryRow(query, entry.UserID, entry.ID, annotation.Quote, annotation.StartOffset, annotation.EndOffset, annotation.Note).Scan(&exists)
+		if exists {
+			continue
+		}
+
+		if annotation.CreatedAt.IsZero() {
+			annotation.CreatedAt = time.Now()
+		}
+
+		if annotation.UpdatedAt.IsZero() {
+			annotation.UpdatedAt = annotation.CreatedAt
+		}
+
+		query = `
+			INSERT INTO annotations
+				(user_id, entry_id, quote, start_offset, end_offset, note, created_at, updated_at)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING
+				id
+		`
+		err := tx.QueryRow(
+			query,
+			entry.UserID,
+			entry.ID,
+			annotation.Quote,
+			annotation.StartOffset,
+			annotation.EndOffset,
+			annotation.Note,
+			annotation.CreatedAt,
+			annotation.UpdatedAt,
+		).Scan(&annotation.ID)
+		if err != nil {
+			return fmt.Errorf(`store: unable to import annotation for entry #%d: %v`, entry.ID, err)
+		}
+
+		annotation.UserID = entry.UserID
+		annotation.EntryID = entry.ID
+		created = true
+	}
+
+	if created {
+		return s.updateEntryDocumentVectors(tx, entry.UserID, entry.ID)
+	}
+
 	return nil
 }
 
 // ArchiveEntries changes the status of entries to "removed" after the given number of days.
+//
+// Starred, shared and annotated entries are never archived.
 func (s *Storage) ArchiveEntries(status string, days, limit int) (int64, error) {
 	if days < 0 || limit <= 0 {
 		return 0, nil
@@ -311,10 +540,10 @@ func (s *Storage) ArchiveEntries(status string, days, limit int) (int64, error)
 		SET
 			status='removed'
 		WHERE
-			id=ANY(SELECT id FROM entries WHERE status=$1 AND starred is false AND share_code='' AND created_at < now () - '%d days'::interval ORDER BY created_at ASC LIMIT %d)
+			id=ANY(SELECT id FROM entries WHERE status=$1 AND starred is false AND share_code='' AND NOT EXISTS (SELECT 1 FROM annotations an WHERE an.entry_id=entries.id) AND created_at < $2 ORDER BY created_at ASC LIMIT %d)
 	`
 
-	result, err := s.db.Exec(fmt.Sprintf(query, days, limit), status)
+	result, err := s.db.Exec(fmt.Sprintf(query, limit), status, time.Now().AddDate(0, 0, -days))
 	if err != nil {
 		return 0, fmt.Errorf(`store: unable to archive %s entries: %v`, status, err)
 	}
@@ -344,6 +573,11 @@ func (s *Storage) SetEntriesStatus(userID int64, entryIDs []int64, status string
 		return errors.New(`store: nothing has been updated`)
 	}
 
+	s.publishEvent(userID, &event.Event{
+		Type: event.EntriesStatusType,
+		Data: &event.EntriesStatus{EntryIDs: entryIDs, Status: status},
+	})
+
 	return nil
 }
 
@@ -388,25 +622,31 @@ func (s *Storage) SetEntriesBookmarkedState(userID int64, entryIDs []int64, star
 		return errors.New(`store: nothing has been updated`)
 	}
 
+	s.publishEvent(userID, &event.Event{
+		Type: event.BookmarkType,
+		Data: &event.Bookmark{EntryIDs: entryIDs, Starred: starred},
+	})
+
 	return nil
 }
 
 // ToggleBookmark toggles entry bookmark value.
 func (s *Storage) ToggleBookmark(userID int64, entryID int64) error {
-	query := `UPDATE entries SET starred = NOT starred, changed_at=now() WHERE user_id=$1 AND id=$2`
-	result, err := s.db.Exec(query, userID, entryID)
-	if err != nil {
-		return fmt.Errorf(`store: unable to toggle bookmark flag for entry #%d: %v`, entryID, err)
-	}
+	var starred bool
+	query := `UPDATE entries SET starred = NOT starred, changed_at=now() WHERE user_id=$1 AND id=$2 RETURNING starred`
+	err := s.db.QueryRow(query, userID, entryID).Scan(&starred)
 
-	count, err := result.RowsAffected()
-	if err != nil {
+	switch {
+	case err == sql.ErrNoRows:
+		return errors.New(`store: nothing has been updated`)
+	case err != nil:
 		return fmt.Errorf(`store: unable to toggle bookmark flag for entry #%d: %v`, entryID, err)
 	}
 
-	if count == 0 {
-		return errors.New(`store: nothing has been updated`)
-	}
+	s.publishEvent(userID, &event.Event{
+		Type: event.BookmarkType,
+		Data: &event.Bookmark{EntryIDs: []int64{entryID}, Starred: starred},
+	})
 
 	return nil
 }
@@ -420,7 +660,8 @@ func (s *Storage) FlushHistory(userID int64) error {
 			status=$1,
 			changed_at=now()
 		WHERE
-			user_id=$2 AND status=$3 AND starred is false AND share_code=''
+			user_id=$2 AND status=$3 AND starred is false AND share_code='' AND
+			NOT EXISTS (SELECT 1 FROM annotations an WHERE an.entry_id=entries.id)
 	`
 	_, err := s.db.Exec(query, model.EntryStatusRemoved, userID, model.EntryStatusRead)
 	if err != nil {
@@ -441,6 +682,11 @@ func (s *Storage) MarkAllAsRead(userID int64) error {
 	count, _ := result.RowsAffected()
 	logger.Debug("[Storage:MarkAllAsRead] %d items marked as read", count)
 
+	s.publishEvent(userID, &event.Event{
+		Type: event.EntriesStatusType,
+		Data: &event.EntriesStatus{Status: model.EntryStatusRead},
+	})
+
 	return nil
 }
 
@@ -463,6 +709,11 @@ func (s *Storage) MarkFeedAsRead(userID, feedID int64, before time.Time) error {
 	count, _ := result.RowsAffected()
 	logger.Debug("[Storage:MarkFeedAsRead] %d items marked as read", count)
 
+	s.publishEvent(userID, &event.Event{
+		Type: event.EntriesStatusType,
+		Data: &event.EntriesStatus{Status: model.EntryStatusRead},
+	})
+
 	return nil
 }
 
@@ -491,6 +742,11 @@ func (s *Storage) MarkCategoryAsRead(userID, categoryID int64, before time.Time)
 	count, _ := result.RowsAffected()
 	logger.Debug("[Storage:MarkCategoryAsRead] %d items marked as read", count)
 
+	s.publishEvent(userID, &event.Event{
+		Type: event.EntriesStatusType,
+		Data: &event.EntriesStatus{Status: model.EntryStatusRead},
+	})
+
 	return nil
 }
 
@@ -502,6 +758,14 @@ func (s *Storage) EntryURLExists(feedID int64, entryURL string) bool {
 	return result
 }
 
+// EntryIDExists checks if the given entry belongs to the user.
+func (s *Storage) EntryIDExists(userID, entryID int64) bool {
+	var result bool
+	query := `SELECT true FROM entries WHERE user_id=$1 AND id=$2`
+	s.db.QueryRow(query, userID, entryID).Scan(&result)
+	return result
+}
+
 // EntryShareCode returns the share code of the provided entry.
 // It generates a new one if not already defined.
 func (s *Storage) EntryShareCode(userID int64, entryID int64) (shareCode string, err error) {
@@ -526,9 +790,56 @@ func (s *Storage) EntryShareCode(userID int64, entryID int64) (shareCode string,
 	return
 }
 
-// UnshareEntry removes the share code for the given entry.
+// SharedEntriesCode returns the code of the public page listing the shared entries, empty when the page is disabled.
+func (s *Storage) SharedEntriesCode(userID int64) (code string, err error) {
+	query := `SELECT shared_entries_code FROM users WHERE id=$1`
+	err = s.db.QueryRow(query, userID).Scan(&code)
+	if err != nil {
+		err = fmt.Errorf(`store: unable to get shared entries code for user #%d: %v`, userID, err)
+	}
+	return
+}
+
+// SetSharedEntriesCode enables the public page of the shared entries with a new code, or disables it with an empty code.
+func (s *Storage) SetSharedEntriesCode(userID int64, code string) error {
+	query := `UPDATE users SET shared_entries_code=$1 WHERE id=$2`
+	_, err := s.db.Exec(query, code, userID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update shared entries code for user #%d: %v`, userID, err)
+	}
+
+	return nil
+}
+
+// UserIDBySharedEntriesCode returns the user who published the public page, or 0 if the code is unknown.
+func (s *Storage) UserIDBySharedEntriesCode(code string) (int64, error) {
+	var userID int64
+	query := `SELECT id FROM users WHERE shared_entries_code=$1 AND shared_entries_code <> ''`
+	err := s.db.QueryRow(query, code).Scan(&userID)
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, nil
+	case err != nil:
+		return 0, fmt.Errorf(`store: unable to fetch user by shared entries code: %v`, err)
+	default:
+		return userID, nil
+	}
+}
+
+// UpdateEntryShareComment changes the comment displayed with a shared entry.
+func (s *Storage) UpdateEntryShareComment(userID, entryID int64, comment string) error {
+	query := `UPDATE entries SET share_comment=$1 WHERE user_id=$2 AND id=$3 AND share_code <> ''`
+	_, err := s.db.Exec(query, comment, userID, entryID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update share comment for entry #%d: %v`, entryID, err)
+	}
+
+	return nil
+}
+
+// UnshareEntry removes the share code and the comment of the given entry.
 func (s *Storage) UnshareEntry(userID int64, entryID int64) (err error) {
-	query := `UPDATE entries SET share_code='' WHERE user_id=$1 AND id=$2`
+	query := `UPDATE entries SET share_code='', share_comment='' WHERE user_id=$1 AND id=$2`
 	_, err = s.db.Exec(query, userID, entryID)
 	if err != nil {
 		err = fmt.Errorf(`store: unable to remove share code for entry #%d: %v`, entryID, err)
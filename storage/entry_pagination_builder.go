@@ -24,11 +24,12 @@ type EntryPaginationBuilder struct {
 	direction  string
 }
 
-// WithSearchQuery adds full-text search query to the condition.
+// WithSearchQuery adds the conditions of a search query to the condition.
 func (e *EntryPaginationBuilder) WithSearchQuery(query string) {
 	if query != "" {
-		e.conditions = append(e.conditions, fmt.Sprintf("e.document_vectors @@ plainto_tsquery($%d)", len(e.args)+1))
-		e.args = append(e.args, query)
+		conditions, args, _ := e.store.searchQueryConditions(parseSearchQueryOrText(query), len(e.args))
+		e.conditions = append(e.conditions, conditions...)
+		e.args = append(e.args, args...)
 	}
 }
 
@@ -37,6 +38,14 @@ func (e *EntryPaginationBuilder) WithStarred() {
 	e.conditions = append(e.conditions, "e.starred is true")
 }
 
+// WithTag adds a tag to the condition.
+func (e *EntryPaginationBuilder) WithTag(tag string) {
+	if tag != "" {
+		e.conditions = append(e.conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM entry_tags et JOIN tags t ON t.id=et.tag_id WHERE et.entry_id=e.id AND t.title=$%d)", len(e.args)+1))
+		e.args = append(e.args, tag)
+	}
+}
+
 // WithFeedID adds feed_id to the condition.
 func (e *EntryPaginationBuilder) WithFeedID(feedID int64) {
 	if feedID != 0 {
@@ -61,6 +70,14 @@ func (e *EntryPaginationBuilder) WithStatus(status string) {
 	}
 }
 
+// WithSavedSearch adds the query and the restrictions of a saved search to the condition.
+func (e *EntryPaginationBuilder) WithSavedSearch(search *model.SavedSearch) {
+	e.WithSearchQuery(search.Query)
+	e.WithCategoryID(search.CategoryID)
+	e.WithFeedID(search.FeedID)
+	e.WithStatus(search.Status)
+}
+
 // WithGloballyVisible adds global visibility to the condition.
 func (e *EntryPaginationBuilder) WithGloballyVisible() {
 	e.conditions = append(e.conditions, "not c.hide_globally")
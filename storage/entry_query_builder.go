@@ -27,16 +27,16 @@ type EntryQueryBuilder struct {
 	offset     int
 }
 
-// WithSearchQuery adds full-text search query to the condition.
+// WithSearchQuery adds the conditions of a search query, see ParseSearchQuery for the syntax.
 func (e *EntryQueryBuilder) WithSearchQuery(query string) *EntryQueryBuilder {
 	if query != "" {
-		nArgs := len(e.args) + 1
-		e.conditions = append(e.conditions, fmt.Sprintf("e.document_vectors @@ plainto_tsquery($%d)", nArgs))
-		e.args = append(e.args, query)
-
-		// 0.0000001 = 0.1 / (seconds_in_a_day)
-		e.WithOrder(fmt.Sprintf("ts_rank(document_vectors, plainto_tsquery($%d)) - extract (epoch from now() - published_at)::float * 0.0000001", nArgs))
-		e.WithDirection("DESC")
+		conditions, args, rankPlaceholder := e.store.searchQueryConditions(parseSearchQueryOrText(query), len(e.args))
+		e.conditions = append(e.conditions, conditions...)
+		e.args = append(e.args, args...)
+		if rankPlaceholder > 0 {
+			e.WithOrder(e.store.searchOrder(rankPlaceholder))
+			e.WithDirection("DESC")
+		}
 	}
 	return e
 }
@@ -157,6 +157,24 @@ func (e *EntryQueryBuilder) WithShareCodeNotEmpty() *EntryQueryBuilder {
 	return e
 }
 
+// WithTags filter by a list of tags, entries must have all of them.
+func (e *EntryQueryBuilder) WithTags(tags []string) *EntryQueryBuilder {
+	for _, tag := range tags {
+		e.conditions = append(e.conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM entry_tags et JOIN tags t ON t.id=et.tag_id WHERE et.entry_id=e.id AND t.title=$%d)", len(e.args)+1))
+		e.args = append(e.args, tag)
+	}
+	return e
+}
+
+// WithSavedSearch filter by the query and the restrictions of a saved search.
+func (e *EntryQueryBuilder) WithSavedSearch(search *model.SavedSearch) *EntryQueryBuilder {
+	e.WithSearchQuery(search.Query)
+	e.WithCategoryID(search.CategoryID)
+	e.WithFeedID(search.FeedID)
+	e.WithStatus(search.Status)
+	return e
+}
+
 // WithOrder set the sorting order.
 func (e *EntryQueryBuilder) WithOrder(order string) *EntryQueryBuilder {
 	e.order = order
@@ -227,6 +245,11 @@ func (e *EntryQueryBuilder) GetEntry() (*model.Entry, error) {
 		return nil, err
 	}
 
+	entries[0].Annotations, err = e.store.Annotations(entries[0].UserID, entries[0].ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return entries[0], nil
 }
 
@@ -238,12 +261,13 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			e.user_id,
 			e.feed_id,
 			e.hash,
-			e.published_at at time zone u.timezone,
+			%s,
 			e.title,
 			e.url,
 			e.comments_url,
 			e.author,
 			e.share_code,
+			e.share_comment,
 			e.content,
 			e.status,
 			e.starred,
@@ -260,8 +284,11 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			f.crawler,
 			f.user_agent,
 			f.cookie,
+			f.cache_media,
+			f.newsletter_token,
 			fi.icon_id,
-			u.timezone
+			u.timezone,
+			%s as tags
 		FROM
 			entries e
 		LEFT JOIN
@@ -277,7 +304,7 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 
 	condition := e.buildCondition()
 	sorting := e.buildSorting()
-	query = fmt.Sprintf(query, condition, sorting)
+	query = fmt.Sprintf(query, e.store.timestampInUserTimezone("e.published_at"), e.store.entryTagsColumn(), condition, sorting)
 
 	rows, err := e.store.db.Query(query, e.args...)
 	if err != nil {
@@ -290,6 +317,7 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 		var entry model.Entry
 		var iconID sql.NullInt64
 		var tz string
+		var tags stringList
 
 		entry.Feed = &model.Feed{}
 		entry.Feed.Category = &model.Category{}
@@ -306,6 +334,7 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			&entry.CommentsURL,
 			&entry.Author,
 			&entry.ShareCode,
+			&entry.ShareComment,
 			&entry.Content,
 			&entry.Status,
 			&entry.Starred,
@@ -323,8 +352,11 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			&entry.Feed.Crawler,
 			&entry.Feed.UserAgent,
 			&entry.Feed.Cookie,
+			&entry.Feed.CacheMedia,
+			&entry.Feed.NewsletterToken,
 			&iconID,
 			&tz,
+			&tags,
 		)
 
 		if err != nil {
@@ -343,6 +375,7 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 		entry.ChangedAt = timezone.Convert(tz, entry.ChangedAt)
 		entry.Feed.CheckedAt = timezone.Convert(tz, entry.Feed.CheckedAt)
 
+		entry.Tags = tags
 		entry.Feed.ID = entry.FeedID
 		entry.Feed.UserID = entry.UserID
 		entry.Feed.Icon.FeedID = entry.FeedID
@@ -0,0 +1,49 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestRefreshFeedEntriesCountsChangedEntries(t *testing.T) {
+	store := newTestStorage(t)
+	user := createTestUser(t, store, "alice")
+	feed := createTestFeed(t, store, user, "https://example.org/feed.xml",
+		&model.Entry{URL: "https://example.org/1", Hash: "1", Title: "Title 1", Content: "Content 1"},
+		&model.Entry{URL: "https://example.org/2", Hash: "2", Title: "Title 2", Content: "Content 2"},
+		&model.Entry{URL: "https://example.org/3", Hash: "3", Title: "Title 3", Content: "Content 3"},
+	)
+
+	entries := model.Entries{
+		{URL: "https://example.org/1", Hash: "1", Title: "Title 1", Content: "Content 1"},
+		{URL: "https://example.org/2", Hash: "2", Title: "New title 2", Content: "Content 2"},
+		{URL: "https://example.org/3", Hash: "3", Title: "Title 3", Content: "New content 3"},
+		{URL: "https://example.org/4", Hash: "4", Title: "Title 4", Content: "Content 4"},
+	}
+
+	newEntries, updatedCount, err := store.RefreshFeedEntries(user.ID, feed.ID, entries, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(newEntries) != 1 || newEntries[0].Hash != "4" {
+		t.Fatalf(`Only the fourth entry should be new, got %d new entries`, len(newEntries))
+	}
+
+	if updatedCount != 2 {
+		t.Fatalf(`Only the entries with a new title or content should be counted, got %d`, updatedCount)
+	}
+
+	if _, updatedCount, _ := store.RefreshFeedEntries(user.ID, feed.ID, entries, true); updatedCount != 0 {
+		t.Fatalf(`The entries are not changed by the same document, got %d`, updatedCount)
+	}
+
+	if _, updatedCount, _ := store.RefreshFeedEntries(user.ID, feed.ID, model.Entries{{URL: "https://example.org/1", Hash: "1", Title: "Other title"}}, false); updatedCount != 0 {
+		t.Fatalf(`The entries should not be updated, got %d`, updatedCount)
+	}
+}
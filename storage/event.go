@@ -0,0 +1,113 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+
+	"miniflux.app/event"
+	"miniflux.app/logger"
+)
+
+// eventsChannel is the PostgreSQL notification channel used to share the events between the processes.
+const eventsChannel = "miniflux_events"
+
+// Events returns the broker used to push real-time events to the users.
+func (s *Storage) Events() *event.Broker {
+	return s.events
+}
+
+// ListenEvents relays the events published by all the processes using the same PostgreSQL database to the local broker,
+// this way the changes made by a scheduler running in another process reach the connected users.
+//
+// SQLite databases don't have notifications, the events are only sent to the users connected to the process that made the change.
+// This function must be called before starting the services.
+func (s *Storage) ListenEvents(databaseURL string) {
+	if s.sqlite {
+		return
+	}
+
+	listener := pq.NewListener(databaseURL, 10*time.Second, time.Minute, func(listenerEvent pq.ListenerEventType, err error) {
+		if err != nil {
+			logger.Error(`store: event listener: %v`, err)
+		}
+	})
+
+	if err := listener.Listen(eventsChannel); err != nil {
+		logger.Error(`store: unable to listen to events: %v`, err)
+		return
+	}
+
+	s.eventsListener = listener
+
+	go func() {
+		for notification := range listener.Notify {
+			// A nil notification is received after a reconnection, the events sent in the meantime are lost.
+			if notification == nil {
+				continue
+			}
+
+			userID, e, err := event.Unmarshal([]byte(notification.Extra))
+			if err != nil {
+				logger.Error(`store: %v`, err)
+				continue
+			}
+
+			s.dispatchEvent(userID, e)
+		}
+	}()
+}
+
+// publishEvent sends an event to the connected clients of the user.
+//
+// With PostgreSQL, the event is sent to all the processes listening to the database,
+// the clients connected to a process that doesn't listen receive the event directly.
+func (s *Storage) publishEvent(userID int64, e *event.Event) {
+	if !s.sqlite {
+		s.notifyEvent(userID, e)
+	}
+
+	if s.eventsListener == nil {
+		s.dispatchEvent(userID, e)
+	}
+}
+
+func (s *Storage) notifyEvent(userID int64, e *event.Event) {
+	payload, err := event.Marshal(userID, e)
+	if err != nil {
+		logger.Error(`store: unable to publish event for user #%d: %v`, userID, err)
+		return
+	}
+
+	if _, err := s.db.Exec(`SELECT pg_notify($1, $2)`, eventsChannel, string(payload)); err != nil {
+		logger.Error(`store: unable to publish event for user #%d: %v`, userID, err)
+	}
+}
+
+// dispatchEvent sends an event followed by the updated entry counters to the clients connected to this process.
+func (s *Storage) dispatchEvent(userID int64, e *event.Event) {
+	if !s.events.HasSubscribers(userID) {
+		return
+	}
+
+	s.events.Publish(userID, e)
+
+	counters, err := s.FetchCounters(userID)
+	if err != nil {
+		logger.Error(`store: unable to publish counters for user #%d: %v`, userID, err)
+		return
+	}
+
+	s.events.Publish(userID, &event.Event{
+		Type: event.CountersType,
+		Data: &event.Counters{
+			Unread:  s.CountUnreadEntries(userID),
+			Reads:   counters.ReadCounters,
+			Unreads: counters.UnreadCounters,
+		},
+	})
+}
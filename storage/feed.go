@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"runtime"
 	"sort"
+	"time"
 
 	"miniflux.app/config"
 	"miniflux.app/logger"
@@ -186,11 +187,11 @@ func (s *Storage) WeeklyFeedEntryCount(userID, feedID int64) (int, error) {
 		WHERE
 			entries.user_id=$1 AND 
 			entries.feed_id=$2 AND 
-			entries.published_at BETWEEN (now() - interval '1 week') AND now();
+			entries.published_at BETWEEN $3 AND now();
 	`
 
 	var weeklyCount int
-	err := s.db.QueryRow(query, userID, feedID).Scan(&weeklyCount)
+	err := s.db.QueryRow(query, userID, feedID, time.Now().AddDate(0, 0, -7)).Scan(&weeklyCount)
 
 	switch {
 	case errors.Is(err, sql.ErrNoRows):
@@ -218,6 +219,20 @@ func (s *Storage) FeedByID(userID, feedID int64) (*model.Feed, error) {
 	return feed, nil
 }
 
+// FeedByNewsletterToken returns the newsletter feed that receives the emails sent to the given token.
+func (s *Storage) FeedByNewsletterToken(token string) (*model.Feed, error) {
+	var userID, feedID int64
+	query := `SELECT user_id, id FROM feeds WHERE newsletter_token=$1`
+	switch err := s.db.QueryRow(query, token).Scan(&userID, &feedID); {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch newsletter feed: %v`, err)
+	}
+
+	return s.FeedByID(userID, feedID)
+}
+
 // CreateFeed creates a new feed.
 func (s *Storage) CreateFeed(feed *model.Feed) error {
 	sql := `
@@ -243,10 +258,19 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 			allow_self_signed_certificates,
 			fetch_via_proxy,
 			hide_globally,
-			url_rewrite_rules
+			url_rewrite_rules,
+			cache_media,
+			notify,
+			newsletter_token,
+			item_selector,
+			title_selector,
+			link_selector,
+			date_selector,
+			content_selector,
+			polling_hints
 		)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31)
 		RETURNING
 			id
 	`
@@ -274,6 +298,15 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 		feed.FetchViaProxy,
 		feed.HideGlobally,
 		feed.UrlRewriteRules,
+		feed.CacheMedia,
+		feed.Notify,
+		feed.NewsletterToken,
+		feed.ItemSelector,
+		feed.TitleSelector,
+		feed.LinkSelector,
+		feed.DateSelector,
+		feed.ContentSelector,
+		feed.PollingHints,
 	).Scan(&feed.ID)
 	if err != nil {
 		return fmt.Errorf(`store: unable to create feed %q: %v`, feed.FeedURL, err)
@@ -333,9 +366,19 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 			allow_self_signed_certificates=$22,
 			fetch_via_proxy=$23,
 			hide_globally=$24,
-			url_rewrite_rules=$25
+			url_rewrite_rules=$25,
+			cache_media=$26,
+			notify=$27,
+			item_selector=$28,
+			title_selector=$29,
+			link_selector=$30,
+			date_selector=$31,
+			content_selector=$32,
+			permanent_redirect_url=$33,
+			gone=$34,
+			polling_hints=$35
 		WHERE
-			id=$26 AND user_id=$27
+			id=$36 AND user_id=$37
 	`
 	_, err = s.db.Exec(query,
 		feed.FeedURL,
@@ -363,6 +406,16 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 		feed.FetchViaProxy,
 		feed.HideGlobally,
 		feed.UrlRewriteRules,
+		feed.CacheMedia,
+		feed.Notify,
+		feed.ItemSelector,
+		feed.TitleSelector,
+		feed.LinkSelector,
+		feed.DateSelector,
+		feed.ContentSelector,
+		feed.PermanentRedirectURL,
+		feed.Gone,
+		feed.PollingHints,
 		feed.ID,
 		feed.UserID,
 	)
@@ -383,15 +436,19 @@ func (s *Storage) UpdateFeedError(feed *model.Feed) (err error) {
 			parsing_error_msg=$1,
 			parsing_error_count=$2,
 			checked_at=$3,
-			next_check_at=$4
+			next_check_at=$4,
+			permanent_redirect_url=$5,
+			gone=$6
 		WHERE
-			id=$5 AND user_id=$6
+			id=$7 AND user_id=$8
 	`
 	_, err = s.db.Exec(query,
 		feed.ParsingErrorMsg,
 		feed.ParsingErrorCount,
 		feed.CheckedAt,
 		feed.NextCheckAt,
+		feed.PermanentRedirectURL,
+		feed.Gone,
 		feed.ID,
 		feed.UserID,
 	)
@@ -0,0 +1,64 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+	"time"
+
+	"miniflux.app/model"
+)
+
+// FeedHealthReport returns the feeds of the user that are gone, redirected, failing or stale.
+func (s *Storage) FeedHealthReport(userID int64, staleDays int) (model.FeedHealthReport, error) {
+	feeds, err := s.Feeds(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	lastEntryDates, err := s.lastEntryDates(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := make(model.FeedHealthReport, 0)
+	for _, feed := range feeds {
+		var lastEntryAt *time.Time
+		if createdAt, found := lastEntryDates[feed.ID]; found {
+			lastEntryAt = &createdAt
+		}
+
+		if health := model.CheckFeedHealth(feed, lastEntryAt, staleDays); health != nil {
+			report = append(report, health)
+		}
+	}
+
+	report.SortByPriority()
+	return report, nil
+}
+
+// lastEntryDates returns the creation date of the most recent entry of each feed of the user.
+func (s *Storage) lastEntryDates(userID int64) (map[int64]time.Time, error) {
+	rows, err := s.db.Query(`SELECT feed_id, max(created_at) FROM entries WHERE user_id=$1 GROUP BY feed_id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch last entry dates: %v`, err)
+	}
+	defer rows.Close()
+
+	dates := make(map[int64]time.Time)
+	for rows.Next() {
+		var feedID int64
+		var createdAt nullTimestamp
+		if err := rows.Scan(&feedID, &createdAt); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch last entry date row: %v`, err)
+		}
+
+		if createdAt.Valid {
+			dates[feedID] = createdAt.Time
+		}
+	}
+
+	return dates, nil
+}
@@ -0,0 +1,173 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/model"
+)
+
+const feedOutputColumns = `
+	o.id,
+	o.user_id,
+	o.kind,
+	coalesce(o.category_id, 0),
+	coalesce(o.saved_search_id, 0),
+	coalesce(c.title, s.title, ''),
+	o.token,
+	o.last_used_at,
+	o.created_at
+`
+
+// FeedOutputExists checks if the user already has an output for the same entries.
+func (s *Storage) FeedOutputExists(userID int64, kind string, targetID int64) bool {
+	var result bool
+	query := `
+		SELECT
+			true
+		FROM
+			feed_outputs
+		WHERE
+			user_id=$1 AND kind=$2 AND coalesce(category_id, saved_search_id, 0)=$3
+		LIMIT 1
+	`
+	s.db.QueryRow(query, userID, kind, targetID).Scan(&result)
+	return result
+}
+
+// FeedOutputs returns all the feed outputs of the given user.
+func (s *Storage) FeedOutputs(userID int64) (model.FeedOutputs, error) {
+	query := `
+		SELECT
+			` + feedOutputColumns + `
+		FROM
+			feed_outputs o
+		LEFT JOIN
+			categories c ON c.id=o.category_id
+		LEFT JOIN
+			saved_searches s ON s.id=o.saved_search_id
+		WHERE
+			o.user_id=$1
+		ORDER BY
+			o.kind ASC, coalesce(c.title, s.title, '') ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch feed outputs: %v`, err)
+	}
+	defer rows.Close()
+
+	outputs := make(model.FeedOutputs, 0)
+	for rows.Next() {
+		var output model.FeedOutput
+		if err := rows.Scan(
+			&output.ID,
+			&output.UserID,
+			&output.Kind,
+			&output.CategoryID,
+			&output.SavedSearchID,
+			&output.Title,
+			&output.Token,
+			&output.LastUsedAt,
+			&output.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch feed output row: %v`, err)
+		}
+
+		outputs = append(outputs, &output)
+	}
+
+	return outputs, nil
+}
+
+// FeedOutputByToken returns the feed output that has the given token.
+func (s *Storage) FeedOutputByToken(token string) (*model.FeedOutput, error) {
+	query := `
+		SELECT
+			` + feedOutputColumns + `
+		FROM
+			feed_outputs o
+		LEFT JOIN
+			categories c ON c.id=o.category_id
+		LEFT JOIN
+			saved_searches s ON s.id=o.saved_search_id
+		WHERE
+			o.token=$1
+	`
+
+	var output model.FeedOutput
+	err := s.db.QueryRow(query, token).Scan(
+		&output.ID,
+		&output.UserID,
+		&output.Kind,
+		&output.CategoryID,
+		&output.SavedSearchID,
+		&output.Title,
+		&output.Token,
+		&output.LastUsedAt,
+		&output.CreatedAt,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch feed output: %v`, err)
+	default:
+		return &output, nil
+	}
+}
+
+// CreateFeedOutput inserts a new feed output.
+func (s *Storage) CreateFeedOutput(output *model.FeedOutput) error {
+	query := `
+		INSERT INTO feed_outputs
+			(user_id, kind, category_id, saved_search_id, token)
+		VALUES
+			($1, $2, NULLIF($3, 0), NULLIF($4, 0), $5)
+		RETURNING
+			id, created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		output.UserID,
+		output.Kind,
+		output.CategoryID,
+		output.SavedSearchID,
+		output.Token,
+	).Scan(
+		&output.ID,
+		&output.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create feed output: %v`, err)
+	}
+
+	return nil
+}
+
+// SetFeedOutputUsedTimestamp updates the last used date of a feed output.
+func (s *Storage) SetFeedOutputUsedTimestamp(outputID int64) error {
+	query := `UPDATE feed_outputs SET last_used_at=now() WHERE id=$1`
+	_, err := s.db.Exec(query, outputID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update last used date for feed output: %v`, err)
+	}
+
+	return nil
+}
+
+// RemoveFeedOutput deletes a feed output, its token is revoked immediately.
+func (s *Storage) RemoveFeedOutput(userID, outputID int64) error {
+	query := `DELETE FROM feed_outputs WHERE id=$1 AND user_id=$2`
+	_, err := s.db.Exec(query, outputID, userID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove this feed output: %v`, err)
+	}
+
+	return nil
+}
@@ -150,7 +150,7 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			f.etag_header,
 			f.last_modified_header,
 			f.user_id,
-			f.checked_at at time zone u.timezone,
+			%s,
 			f.parsing_error_count,
 			f.parsing_error_msg,
 			f.scraper_rules,
@@ -168,9 +168,21 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			f.fetch_via_proxy,
 			f.disabled,
 			f.hide_globally,
+			f.cache_media,
+			f.notify,
+			f.newsletter_token,
+			f.item_selector,
+			f.title_selector,
+			f.link_selector,
+			f.date_selector,
+			f.content_selector,
+			f.permanent_redirect_url,
+			f.gone,
+			f.polling_hints,
 			f.category_id,
 			c.title as category_title,
 			c.hide_globally as category_hidden,
+			c.notify as category_notify,
 			fi.icon_id,
 			u.timezone
 		FROM
@@ -185,7 +197,7 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 		%s
 	`
 
-	query = fmt.Sprintf(query, f.buildCondition(), f.buildSorting())
+	query = fmt.Sprintf(query, f.store.timestampInUserTimezone("f.checked_at"), f.buildCondition(), f.buildSorting())
 
 	rows, err := f.store.db.Query(query, f.args...)
 	if err != nil {
@@ -231,9 +243,21 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			&feed.FetchViaProxy,
 			&feed.Disabled,
 			&feed.HideGlobally,
+			&feed.CacheMedia,
+			&feed.Notify,
+			&feed.NewsletterToken,
+			&feed.ItemSelector,
+			&feed.TitleSelector,
+			&feed.LinkSelector,
+			&feed.DateSelector,
+			&feed.ContentSelector,
+			&feed.PermanentRedirectURL,
+			&feed.Gone,
+			&feed.PollingHints,
 			&feed.Category.ID,
 			&feed.Category.Title,
 			&feed.Category.HideGlobally,
+			&feed.Category.Notify,
 			&iconID,
 			&tz,
 		)
@@ -0,0 +1,99 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+	"time"
+
+	"miniflux.app/model"
+)
+
+// CreateFeedRefresh records a feed refresh in the feed history.
+func (s *Storage) CreateFeedRefresh(refresh *model.FeedRefresh) error {
+	query := `
+		INSERT INTO feed_refreshes
+			(feed_id, created_at, status_code, effective_url, response_size, duration, etag_header, not_modified, new_entries, updated_entries, error_msg)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING
+			id
+	`
+	err := s.db.QueryRow(
+		query,
+		refresh.FeedID,
+		refresh.CreatedAt,
+		refresh.StatusCode,
+		refresh.EffectiveURL,
+		refresh.ResponseSize,
+		refresh.Duration,
+		refresh.EtagHeader,
+		refresh.NotModified,
+		refresh.NewEntries,
+		refresh.UpdatedEntries,
+		refresh.ErrorMsg,
+	).Scan(&refresh.ID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to record refresh of feed #%d: %v`, refresh.FeedID, err)
+	}
+
+	return nil
+}
+
+// FeedRefreshes returns the most recent refreshes of a feed.
+func (s *Storage) FeedRefreshes(feedID int64, limit int) (model.FeedRefreshes, error) {
+	query := `
+		SELECT
+			id, feed_id, created_at, status_code, effective_url, response_size, duration, etag_header, not_modified, new_entries, updated_entries, error_msg
+		FROM
+			feed_refreshes
+		WHERE
+			feed_id=$1
+		ORDER BY
+			created_at DESC, id DESC
+		LIMIT $2
+	`
+	rows, err := s.db.Query(query, feedID, limit)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch refresh history of feed #%d: %v`, feedID, err)
+	}
+	defer rows.Close()
+
+	refreshes := make(model.FeedRefreshes, 0)
+	for rows.Next() {
+		var refresh model.FeedRefresh
+		if err := rows.Scan(
+			&refresh.ID,
+			&refresh.FeedID,
+			&refresh.CreatedAt,
+			&refresh.StatusCode,
+			&refresh.EffectiveURL,
+			&refresh.ResponseSize,
+			&refresh.Duration,
+			&refresh.EtagHeader,
+			&refresh.NotModified,
+			&refresh.NewEntries,
+			&refresh.UpdatedEntries,
+			&refresh.ErrorMsg,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch feed refresh row: %v`, err)
+		}
+
+		refreshes = append(refreshes, &refresh)
+	}
+
+	return refreshes, nil
+}
+
+// CleanOldFeedRefreshes removes the feed refreshes older than the given number of days.
+func (s *Storage) CleanOldFeedRefreshes(days int) int64 {
+	result, err := s.db.Exec(`DELETE FROM feed_refreshes WHERE created_at < $1`, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return 0
+	}
+
+	n, _ := result.RowsAffected()
+	return n
+}
@@ -151,7 +151,19 @@ func (s *Storage) Integration(userID int64) (*model.Integration, error) {
 			matrix_bot_user,
 			matrix_bot_password,
 			matrix_bot_url,
-			matrix_bot_chat_id
+			matrix_bot_chat_id,
+			webhook_enabled,
+			webhook_url,
+			webhook_secret,
+			ntfy_enabled,
+			ntfy_url,
+			ntfy_token,
+			gotify_enabled,
+			gotify_url,
+			gotify_token,
+			email_notification_enabled,
+			email_notification_address,
+			email_digest_frequency
 		FROM
 			integrations
 		WHERE
@@ -201,6 +213,18 @@ func (s *Storage) Integration(userID int64) (*model.Integration, error) {
 		&integration.MatrixBotPassword,
 		&integration.MatrixBotURL,
 		&integration.MatrixBotChatID,
+		&integration.WebhookEnabled,
+		&integration.WebhookURL,
+		&integration.WebhookSecret,
+		&integration.NtfyEnabled,
+		&integration.NtfyURL,
+		&integration.NtfyToken,
+		&integration.GotifyEnabled,
+		&integration.GotifyURL,
+		&integration.GotifyToken,
+		&integration.EmailNotificationEnabled,
+		&integration.EmailNotificationAddress,
+		&integration.EmailDigestFrequency,
 	)
 	switch {
 	case err == sql.ErrNoRows:
@@ -264,9 +288,21 @@ func (s *Storage) UpdateIntegration(integration *model.Integration) error {
 			matrix_bot_user=$38,
 			matrix_bot_password=$39,
 			matrix_bot_url=$40,
-			matrix_bot_chat_id=$41
+			matrix_bot_chat_id=$41,
+			webhook_enabled=$42,
+			webhook_url=$43,
+			webhook_secret=$44,
+			ntfy_enabled=$45,
+			ntfy_url=$46,
+			ntfy_token=$47,
+			gotify_enabled=$48,
+			gotify_url=$49,
+			gotify_token=$50,
+			email_notification_enabled=$51,
+			email_notification_address=$52,
+			email_digest_frequency=$53
 		WHERE
-			user_id=$42
+			user_id=$54
 	`
 		_, err = s.db.Exec(
 			query,
@@ -311,6 +347,18 @@ func (s *Storage) UpdateIntegration(integration *model.Integration) error {
 			integration.MatrixBotPassword,
 			integration.MatrixBotURL,
 			integration.MatrixBotChatID,
+			integration.WebhookEnabled,
+			integration.WebhookURL,
+			integration.WebhookSecret,
+			integration.NtfyEnabled,
+			integration.NtfyURL,
+			integration.NtfyToken,
+			integration.GotifyEnabled,
+			integration.GotifyURL,
+			integration.GotifyToken,
+			integration.EmailNotificationEnabled,
+			integration.EmailNotificationAddress,
+			integration.EmailDigestFrequency,
 			integration.UserID,
 		)
 	} else {
@@ -358,9 +406,21 @@ func (s *Storage) UpdateIntegration(integration *model.Integration) error {
 		matrix_bot_user=$38,
 		matrix_bot_password=$39,
 		matrix_bot_url=$40,
-		matrix_bot_chat_id=$41
+		matrix_bot_chat_id=$41,
+		webhook_enabled=$42,
+		webhook_url=$43,
+		webhook_secret=$44,
+		ntfy_enabled=$45,
+		ntfy_url=$46,
+		ntfy_token=$47,
+		gotify_enabled=$48,
+		gotify_url=$49,
+		gotify_token=$50,
+		email_notification_enabled=$51,
+		email_notification_address=$52,
+		email_digest_frequency=$53
 	WHERE
-		user_id=$42
+		user_id=$54
 	`
 		_, err = s.db.Exec(
 			query,
@@ -405,6 +465,18 @@ func (s *Storage) UpdateIntegration(integration *model.Integration) error {
 			integration.MatrixBotPassword,
 			integration.MatrixBotURL,
 			integration.MatrixBotChatID,
+			integration.WebhookEnabled,
+			integration.WebhookURL,
+			integration.WebhookSecret,
+			integration.NtfyEnabled,
+			integration.NtfyURL,
+			integration.NtfyToken,
+			integration.GotifyEnabled,
+			integration.GotifyURL,
+			integration.GotifyToken,
+			integration.EmailNotificationEnabled,
+			integration.EmailNotificationAddress,
+			integration.EmailDigestFrequency,
 			integration.UserID,
 		)
 	}
@@ -426,7 +498,7 @@ func (s *Storage) HasSaveEntry(userID int64) (result bool) {
 		WHERE
 			user_id=$1
 		AND
-			(pinboard_enabled='t' OR instapaper_enabled='t' OR wallabag_enabled='t' OR nunux_keeper_enabled='t' OR espial_enabled='t' OR pocket_enabled='t' OR linkding_enabled='t')
+			(pinboard_enabled='t' OR instapaper_enabled='t' OR wallabag_enabled='t' OR nunux_keeper_enabled='t' OR espial_enabled='t' OR pocket_enabled='t' OR linkding_enabled='t' OR webhook_enabled='t')
 	`
 	if err := s.db.QueryRow(query, userID).Scan(&result); err != nil {
 		result = false
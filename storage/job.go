@@ -21,7 +21,7 @@ func (s *Storage) NewBatch(batchSize int) (jobs model.JobList, err error) {
 		FROM
 			feeds
 		WHERE
-			disabled is false AND next_check_at < now() AND 
+			disabled is false AND newsletter_token='' AND next_check_at < now() AND 
 			CASE WHEN $1 > 0 THEN parsing_error_count < $1 ELSE parsing_error_count >= 0 END
 		ORDER BY next_check_at ASC LIMIT $2
 	`
@@ -39,7 +39,7 @@ func (s *Storage) NewUserBatch(userID int64, batchSize int) (jobs model.JobList,
 		FROM
 			feeds
 		WHERE
-			user_id=$1 AND disabled is false
+			user_id=$1 AND disabled is false AND newsletter_token=''
 		ORDER BY next_check_at ASC LIMIT %d
 	`
 	return s.fetchBatchRows(fmt.Sprintf(query, batchSize), userID)
@@ -56,7 +56,7 @@ func (s *Storage) NewCategoryBatch(userID int64, categoryID int64, batchSize int
 		FROM
 			feeds
 		WHERE
-			user_id=$1 AND category_id=$2 AND disabled is false
+			user_id=$1 AND category_id=$2 AND disabled is false AND newsletter_token=''
 		ORDER BY next_check_at ASC LIMIT %d
 	`
 	return s.fetchBatchRows(fmt.Sprintf(query, batchSize), userID, categoryID)
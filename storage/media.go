@@ -0,0 +1,122 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/crypto"
+	"miniflux.app/model"
+)
+
+// HasEntryMedia checks if the file located at the given URL is cached for the entry.
+func (s *Storage) HasEntryMedia(entryID int64, mediaURL string) bool {
+	var result bool
+	query := `SELECT true FROM entry_media WHERE entry_id=$1 AND url_hash=$2`
+	s.db.QueryRow(query, entryID, crypto.Hash(mediaURL)).Scan(&result)
+	return result
+}
+
+// EntryMediaByURL returns the file located at the given URL cached for the entry.
+func (s *Storage) EntryMediaByURL(entryID int64, mediaURL string) (*model.Media, error) {
+	query := `
+		SELECT
+			m.id, m.hash, m.mime_type, m.size, m.content
+		FROM
+			media m
+		JOIN
+			entry_media em ON em.media_id=m.id
+		WHERE
+			em.entry_id=$1 AND em.url_hash=$2
+	`
+	var media model.Media
+	err := s.db.QueryRow(query, entryID, crypto.Hash(mediaURL)).Scan(&media.ID, &media.Hash, &media.MimeType, &media.Size, &media.Content)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch media: %v`, err)
+	default:
+		return &media, nil
+	}
+}
+
+// MediaCacheSize returns the size in bytes of the files cached for the entries of the user.
+func (s *Storage) MediaCacheSize(userID int64) (int64, error) {
+	query := `
+		SELECT
+			coalesce(sum(m.size), 0)
+		FROM
+			media m
+		WHERE
+			EXISTS (SELECT 1 FROM entry_media em JOIN entries e ON e.id=em.entry_id WHERE em.media_id=m.id AND e.user_id=$1)
+	`
+	var size int64
+	if err := s.db.QueryRow(query, userID).Scan(&size); err != nil {
+		return 0, fmt.Errorf(`store: unable to compute the media cache size: %v`, err)
+	}
+
+	return size, nil
+}
+
+// CreateEntryMedia stores a file and associates it to the entry, identical files are stored only once.
+func (s *Storage) CreateEntryMedia(entryID int64, mediaURL string, media *model.Media) error {
+	err := s.db.QueryRow(`SELECT id FROM media WHERE hash=$1`, media.Hash).Scan(&media.ID)
+	if err == sql.ErrNoRows {
+		query := `
+			INSERT INTO media
+				(hash, mime_type, size, content)
+			VALUES
+				($1, $2, $3, $4)
+			RETURNING
+				id
+		`
+		err = s.db.QueryRow(
+			query,
+			media.Hash,
+			media.MimeType,
+			media.Size,
+			media.Content,
+		).Scan(&media.ID)
+	}
+
+	if err != nil {
+		return fmt.Errorf(`store: unable to create media: %v`, err)
+	}
+
+	query := `
+		INSERT INTO entry_media
+			(entry_id, media_id, url_hash)
+		VALUES
+			($1, $2, $3)
+		ON CONFLICT (entry_id, url_hash) DO NOTHING
+	`
+	if _, err := s.db.Exec(query, entryID, media.ID, crypto.Hash(mediaURL)); err != nil {
+		return fmt.Errorf(`store: unable to create entry media: %v`, err)
+	}
+
+	return nil
+}
+
+// RemoveArchivedMedia evicts the files of the archived entries from the media cache.
+func (s *Storage) RemoveArchivedMedia() (int64, error) {
+	query := `DELETE FROM entry_media WHERE entry_id IN (SELECT id FROM entries WHERE status=$1)`
+	if _, err := s.db.Exec(query, model.EntryStatusRemoved); err != nil {
+		return 0, fmt.Errorf(`store: unable to remove media of archived entries: %v`, err)
+	}
+
+	result, err := s.db.Exec(`DELETE FROM media WHERE NOT EXISTS (SELECT 1 FROM entry_media em WHERE em.media_id=media.id)`)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to remove unused media: %v`, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to get the number of rows affected: %v`, err)
+	}
+
+	return count, nil
+}
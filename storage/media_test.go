@@ -0,0 +1,47 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"testing"
+
+	"miniflux.app/crypto"
+	"miniflux.app/model"
+)
+
+func TestEntryMediaByURLIsScopedToEntry(t *testing.T) {
+	store := newTestStorage(t)
+	alice := createTestUser(t, store, "alice")
+	bob := createTestUser(t, store, "bob")
+
+	// The senders of the newsletters choose the URLs, several users can have the same one.
+	const mediaURL = "mid:issue-42@example.org/0/report.pdf"
+	aliceFeed := createTestFeed(t, store, alice, "mailto:alice", &model.Entry{URL: "mid:issue-42@example.org", Hash: "issue-42", Title: "Issue 42"})
+	bobFeed := createTestFeed(t, store, bob, "mailto:bob", &model.Entry{URL: "mid:issue-42@example.org", Hash: "issue-42", Title: "Issue 42"})
+	aliceEntryID := aliceFeed.Entries[0].ID
+	bobEntryID := bobFeed.Entries[0].ID
+
+	content := []byte("%PDF-1.4\n")
+	if err := store.CreateEntryMedia(aliceEntryID, mediaURL, &model.Media{Hash: crypto.HashFromBytes(content), MimeType: "application/pdf", Size: int64(len(content)), Content: content}); err != nil {
+		t.Fatal(err)
+	}
+
+	media, err := store.EntryMediaByURL(aliceEntryID, mediaURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if media == nil || string(media.Content) != string(content) {
+		t.Fatalf(`The file should be cached for the entry, got %+v`, media)
+	}
+
+	if media, _ := store.EntryMediaByURL(bobEntryID, mediaURL); media != nil {
+		t.Fatal(`The file cached for an entry should not be returned for the entry of another user`)
+	}
+
+	if store.HasEntryMedia(bobEntryID, mediaURL) {
+		t.Fatal(`The file should not be cached for the entry of another user`)
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"miniflux.app/logger"
+	"miniflux.app/model"
+)
+
+// SavedSearchTitleExists checks if a saved search exists with the same title.
+func (s *Storage) SavedSearchTitleExists(userID int64, title string) bool {
+	var result bool
+	query := `SELECT true FROM saved_searches WHERE user_id=$1 AND lower(title)=lower($2) LIMIT 1`
+	s.db.QueryRow(query, userID, title).Scan(&result)
+	return result
+}
+
+// AnotherSavedSearchExists checks if another saved search exists with the same title.
+func (s *Storage) AnotherSavedSearchExists(userID, searchID int64, title string) bool {
+	var result bool
+	query := `SELECT true FROM saved_searches WHERE user_id=$1 AND id != $2 AND lower(title)=lower($3) LIMIT 1`
+	s.db.QueryRow(query, userID, searchID, title).Scan(&result)
+	return result
+}
+
+// SavedSearch returns a saved search from the database.
+func (s *Storage) SavedSearch(userID, searchID int64) (*model.SavedSearch, error) {
+	query := `
+		SELECT
+			id, user_id, title, query, coalesce(category_id, 0), coalesce(feed_id, 0), coalesce(status, ''), created_at
+		FROM
+			saved_searches
+		WHERE
+			user_id=$1 AND id=$2
+	`
+	return s.fetchSavedSearch(query, userID, searchID)
+}
+
+// SavedSearchByTitle finds a saved search by the title.
+func (s *Storage) SavedSearchByTitle(userID int64, title string) (*model.SavedSearch, error) {
+	query := `
+		SELECT
+			id, user_id, title, query, coalesce(category_id, 0), coalesce(feed_id, 0), coalesce(status, ''), created_at
+		FROM
+			saved_searches
+		WHERE
+			user_id=$1 AND title=$2
+	`
+	return s.fetchSavedSearch(query, userID, title)
+}
+
+func (s *Storage) fetchSavedSearch(query string, args ...interface{}) (*model.SavedSearch, error) {
+	var search model.SavedSearch
+	err := s.db.QueryRow(query, args...).Scan(
+		&search.ID,
+		&search.UserID,
+		&search.Title,
+		&search.Query,
+		&search.CategoryID,
+		&search.FeedID,
+		&search.Status,
+		&search.CreatedAt,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch saved search: %v`, err)
+	default:
+		return &search, nil
+	}
+}
+
+// SavedSearches returns all saved searches that belongs to the given user.
+func (s *Storage) SavedSearches(userID int64) (model.SavedSearches, error) {
+	query := `
+		SELECT
+			id, user_id, title, query, coalesce(category_id, 0), coalesce(feed_id, 0), coalesce(status, ''), created_at
+		FROM
+			saved_searches
+		WHERE
+			user_id=$1
+		ORDER BY
+			title ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch saved searches: %v`, err)
+	}
+	defer rows.Close()
+
+	searches := make(model.SavedSearches, 0)
+	for rows.Next() {
+		var search model.SavedSearch
+		if err := rows.Scan(
+			&search.ID,
+			&search.UserID,
+			&search.Title,
+			&search.Query,
+			&search.CategoryID,
+			&search.FeedID,
+			&search.Status,
+			&search.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch saved search row: %v`, err)
+		}
+
+		searches = append(searches, &search)
+	}
+
+	return searches, nil
+}
+
+// SavedSearchesWithUnreadCount returns all saved searches that belongs to the given user with their number of unread entries.
+func (s *Storage) SavedSearchesWithUnreadCount(userID int64) (model.SavedSearches, error) {
+	searches, err := s.SavedSearches(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.countSavedSearchesUnreadEntries(userID, searches); err != nil {
+		return nil, err
+	}
+
+	return searches, nil
+}
+
+// MenuSavedSearches returns the saved searches displayed in the navigation menu, errors are only logged.
+func (s *Storage) MenuSavedSearches(userID int64) model.SavedSearches {
+	searches, err := s.SavedSearchesWithUnreadCount(userID)
+	if err != nil {
+		logger.Error(`store: unable to fetch saved searches for user #%d: %v`, userID, err)
+		return nil
+	}
+
+	return searches
+}
+
+// countSavedSearchesUnreadEntries counts the unread entries of all the searches in a single query:
+// each search adds a conditional counter evaluated during the same scan of the unread entries.
+func (s *Storage) countSavedSearchesUnreadEntries(userID int64, searches model.SavedSearches) error {
+	builder := s.NewEntryQueryBuilder(userID)
+	builder.WithStatus(model.EntryStatusUnread)
+	condition := builder.buildCondition()
+
+	var counters []string
+	var counted model.SavedSearches
+	for _, search := range searches {
+		if search.Status == model.EntryStatusRead {
+			continue
+		}
+
+		// The arguments are shared, the placeholders of each search follow the previous ones.
+		builder.conditions = []string{"true"}
+		builder.WithSavedSearch(search)
+		counters = append(counters, fmt.Sprintf(`count(CASE WHEN %s THEN 1 END)`, builder.buildCondition()))
+		counted = append(counted, search)
+	}
+
+	if len(counted) == 0 {
+		return nil
+	}
+
+	query := `
+		SELECT %s
+		FROM entries e
+			JOIN feeds f ON f.id = e.feed_id
+			JOIN categories c ON c.id = f.category_id
+		WHERE %s
+	`
+	destinations := make([]interface{}, len(counted))
+	for i, search := range counted {
+		destinations[i] = &search.TotalUnread
+	}
+
+	err := s.db.QueryRow(fmt.Sprintf(query, strings.Join(counters, ", "), condition), builder.args...).Scan(destinations...)
+	if err != nil {
+		return fmt.Errorf(`store: unable to count unread entries of saved searches: %v`, err)
+	}
+
+	return nil
+}
+
+// CountSavedSearchUnreadEntries returns the number of unread entries matching a saved search.
+func (s *Storage) CountSavedSearchUnreadEntries(search *model.SavedSearch) (int, error) {
+	if search.Status == model.EntryStatusRead {
+		return 0, nil
+	}
+
+	builder := s.NewEntryQueryBuilder(search.UserID)
+	builder.WithSavedSearch(search)
+	builder.WithStatus(model.EntryStatusUnread)
+
+	count, err := builder.CountEntries()
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to count unread entries of saved search #%d: %v`, search.ID, err)
+	}
+
+	return count, nil
+}
+
+// MarkSavedSearchAsRead updates the unread entries matching a saved search to read.
+func (s *Storage) MarkSavedSearchAsRead(search *model.SavedSearch, before time.Time) error {
+	builder := s.NewEntryQueryBuilder(search.UserID)
+	builder.WithSavedSearch(search)
+	builder.WithStatus(model.EntryStatusUnread)
+	builder.BeforeDate(before)
+
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		return fmt.Errorf(`store: unable to fetch unread entries of saved search #%d: %v`, search.ID, err)
+	}
+
+	if len(entryIDs) == 0 {
+		return nil
+	}
+
+	return s.SetEntriesStatus(search.UserID, entryIDs, model.EntryStatusRead)
+}
+
+// CreateSavedSearch creates a new saved search.
+func (s *Storage) CreateSavedSearch(userID int64, request *model.SavedSearchRequest) (*model.SavedSearch, error) {
+	search := &model.SavedSearch{UserID: userID}
+	request.Patch(search)
+
+	query := `
+		INSERT INTO saved_searches
+			(user_id, title, query, category_id, feed_id, status)
+		VALUES
+			($1, $2, $3, NULLIF($4, 0), NULLIF($5, 0), NULLIF($6, ''))
+		RETURNING
+			id, created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		userID,
+		search.Title,
+		search.Query,
+		search.CategoryID,
+		search.FeedID,
+		search.Status,
+	).Scan(&search.ID, &search.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to create saved search %q: %v`, search.Title, err)
+	}
+
+	return search, nil
+}
+
+// UpdateSavedSearch updates an existing saved search.
+func (s *Storage) UpdateSavedSearch(search *model.SavedSearch) error {
+	query := `
+		UPDATE
+			saved_searches
+		SET
+			title=$1, query=$2, category_id=NULLIF($3, 0), feed_id=NULLIF($4, 0), status=NULLIF($5, '')
+		WHERE
+			id=$6 AND user_id=$7
+	`
+	_, err := s.db.Exec(
+		query,
+		search.Title,
+		search.Query,
+		search.CategoryID,
+		search.FeedID,
+		search.Status,
+		search.ID,
+		search.UserID,
+	)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update saved search: %v`, err)
+	}
+
+	return nil
+}
+
+// RemoveSavedSearch deletes a saved search.
+func (s *Storage) RemoveSavedSearch(userID, searchID int64) error {
+	result, err := s.db.Exec(`DELETE FROM saved_searches WHERE id = $1 AND user_id = $2`, searchID, userID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove this saved search: %v`, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove this saved search: %v`, err)
+	}
+
+	if count == 0 {
+		return fmt.Errorf(`store: no saved search has been removed`)
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"miniflux.app/model"
+)
+
+// Fields supported by the search query language.
+const (
+	SearchFieldText     = ""
+	SearchFieldTitle    = "title"
+	SearchFieldAuthor   = "author"
+	SearchFieldFeed     = "feed"
+	SearchFieldCategory = "category"
+	SearchFieldTag      = "tag"
+	SearchFieldNote     = "note"
+	SearchFieldBefore   = "before"
+	SearchFieldAfter    = "after"
+	SearchFieldIs       = "is"
+)
+
+// searchDateLayout is the format of the before: and after: operators, dates are interpreted in UTC.
+const searchDateLayout = "2006-01-02"
+
+// SearchTerm represents a single criterion of a search query.
+type SearchTerm struct {
+	Field   string
+	Value   string
+	Phrase  bool
+	Negated bool
+
+	// ID is set when the feed: or category: operators are used with an identifier instead of a title.
+	ID int64
+
+	// Date is set for the before: and after: operators.
+	Date time.Time
+}
+
+// SearchQuery represents a search query parsed into terms, all the terms must match.
+type SearchQuery struct {
+	Terms []*SearchTerm
+}
+
+// ParseSearchQuery parses a search query such as:
+//
+//	title:"rate limit" author:alice feed:12 before:2024-01-01 -kubernetes is:starred
+//
+// Words and quoted phrases are searched in the title, the content and the annotations of the entries,
+// a leading minus excludes the entries matching the term. Unknown operators are searched as plain text.
+func ParseSearchQuery(input string) (*SearchQuery, error) {
+	query := &SearchQuery{Terms: make([]*SearchTerm, 0)}
+
+	for _, token := range tokenizeSearchQuery(input) {
+		term, err := parseSearchTerm(token)
+		if err != nil {
+			return nil, err
+		}
+
+		if term != nil {
+			query.Terms = append(query.Terms, term)
+		}
+	}
+
+	return query, nil
+}
+
+// tokenizeSearchQuery splits the query on whitespaces that are not enclosed in double quotes.
+func tokenizeSearchQuery(input string) []string {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+
+	for _, r := range input {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuotes:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens
+}
+
+func parseSearchTerm(token string) (*SearchTerm, error) {
+	term := &SearchTerm{}
+
+	if strings.HasPrefix(token, "-") {
+		term.Negated = true
+		token = token[1:]
+	}
+
+	if index := strings.IndexByte(token, ':'); index > 0 && !strings.ContainsRune(token[:index], '"') {
+		field := strings.ToLower(token[:index])
+		if isSearchField(field) {
+			term.Field = field
+			token = token[index+1:]
+		}
+	}
+
+	if strings.HasPrefix(token, `"`) {
+		term.Phrase = true
+		token = strings.TrimSuffix(strings.TrimPrefix(token, `"`), `"`)
+	}
+
+	term.Value = strings.TrimSpace(token)
+	if term.Value == "" {
+		if term.Field != SearchFieldText {
+			return nil, fmt.Errorf(`search: missing value for the operator "%s:"`, term.Field)
+		}
+
+		// Empty phrases and isolated minus signs are ignored.
+		return nil, nil
+	}
+
+	switch term.Field {
+	case SearchFieldFeed, SearchFieldCategory:
+		if id, err := strconv.ParseInt(term.Value, 10, 64); err == nil && !term.Phrase {
+			term.ID = id
+		}
+	case SearchFieldBefore, SearchFieldAfter:
+		date, err := time.Parse(searchDateLayout, term.Value)
+		if err != nil {
+			return nil, fmt.Errorf(`search: invalid date %q for the operator "%s:", the expected format is YYYY-MM-DD`, term.Value, term.Field)
+		}
+		term.Date = date
+	case SearchFieldIs:
+		term.Value = strings.ToLower(term.Value)
+		switch term.Value {
+		case "starred", model.EntryStatusUnread, model.EntryStatusRead:
+		default:
+			return nil, fmt.Errorf(`search: invalid value %q for the operator "is:", valid values are "starred", "unread" and "read"`, term.Value)
+		}
+	}
+
+	return term, nil
+}
+
+func isSearchField(field string) bool {
+	switch field {
+	case SearchFieldTitle, SearchFieldAuthor, SearchFieldFeed, SearchFieldCategory, SearchFieldTag, SearchFieldNote, SearchFieldBefore, SearchFieldAfter, SearchFieldIs:
+		return true
+	}
+
+	return false
+}
+
+// words returns the positive words searched in the title and the content of the entries.
+func (q *SearchQuery) words() []string {
+	var words []string
+	for _, term := range q.Terms {
+		if term.Field == SearchFieldText && !term.Phrase && !term.Negated {
+			words = append(words, term.Value)
+		}
+	}
+	return words
+}
+
+// searchQueryConditions translates a search query into SQL conditions.
+//
+// The placeholders start after the given number of arguments. The returned placeholder refers
+// to the text used to rank the results, it is zero when the query doesn't search any text.
+func (s *Storage) searchQueryConditions(query *SearchQuery, nArgs int) (conditions []string, args []interface{}, rankPlaceholder int) {
+	placeholder := func(arg interface{}) int {
+		args = append(args, arg)
+		return nArgs + len(args)
+	}
+
+	if words := query.words(); len(words) > 0 {
+		condition, wordArgs := s.wordsSearchCondition(nArgs+1, words)
+		conditions = append(conditions, condition)
+		rankPlaceholder = nArgs + 1
+		args = append(args, wordArgs...)
+	}
+
+	for _, term := range query.Terms {
+		var condition string
+
+		switch term.Field {
+		case SearchFieldText:
+			switch {
+			case term.Phrase:
+				condition = s.phraseSearchCondition(placeholder(term.Value))
+				if rankPlaceholder == 0 && !term.Negated {
+					rankPlaceholder = nArgs + len(args)
+				}
+			case term.Negated:
+				condition = s.searchCondition(placeholder(term.Value))
+			default:
+				// Positive words are grouped in a single condition.
+				continue
+			}
+		case SearchFieldTitle:
+			condition = s.containsCondition("e.title", placeholder(term.Value))
+		case SearchFieldAuthor:
+			condition = s.containsCondition("e.author", placeholder(term.Value))
+		case SearchFieldFeed:
+			if term.ID > 0 {
+				condition = fmt.Sprintf("e.feed_id = $%d", placeholder(term.ID))
+			} else {
+				condition = s.containsCondition("f.title", placeholder(term.Value))
+			}
+		case SearchFieldCategory:
+			if term.ID > 0 {
+				condition = fmt.Sprintf("f.category_id = $%d", placeholder(term.ID))
+			} else {
+				condition = fmt.Sprintf(
+					"EXISTS (SELECT 1 FROM categories sc WHERE sc.id=f.category_id AND %s)",
+					s.containsCondition("sc.title", placeholder(term.Value)),
+				)
+			}
+		case SearchFieldTag:
+			condition = fmt.Sprintf("EXISTS (SELECT 1 FROM entry_tags et JOIN tags t ON t.id=et.tag_id WHERE et.entry_id=e.id AND t.title=$%d)", placeholder(term.Value))
+		case SearchFieldNote:
+			condition = s.annotationsCondition(placeholder(term.Value))
+		case SearchFieldBefore:
+			condition = fmt.Sprintf("e.published_at < $%d", placeholder(term.Date))
+		case SearchFieldAfter:
+			condition = fmt.Sprintf("e.published_at >= $%d", placeholder(term.Date))
+		case SearchFieldIs:
+			if term.Value == "starred" {
+				condition = "e.starred is true"
+			} else {
+				condition = fmt.Sprintf("e.status = $%d", placeholder(term.Value))
+			}
+		}
+
+		if term.Negated {
+			condition = "NOT (" + condition + ")"
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, args, rankPlaceholder
+}
+
+// parseSearchQueryOrText parses the query, invalid queries are searched as plain text.
+func parseSearchQueryOrText(input string) *SearchQuery {
+	query, err := ParseSearchQuery(input)
+	if err != nil {
+		return &SearchQuery{Terms: []*SearchTerm{{Value: input}}}
+	}
+	return query
+}
@@ -0,0 +1,326 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSearchQueryWithPlainText(t *testing.T) {
+	query, err := ParseSearchQuery("  golang   generics ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*SearchTerm{
+		{Value: "golang"},
+		{Value: "generics"},
+	}
+	if !reflect.DeepEqual(query.Terms, expected) {
+		t.Errorf(`Unexpected terms: %+v`, query.Terms)
+	}
+}
+
+func TestParseEmptySearchQuery(t *testing.T) {
+	for _, input := range []string{"", "   ", `""`, "-", `- ""`} {
+		query, err := ParseSearchQuery(input)
+		if err != nil {
+			t.Fatalf(`The query %q should be valid: %v`, input, err)
+		}
+
+		if len(query.Terms) != 0 {
+			t.Errorf(`The query %q should not have any term, got %+v`, input, query.Terms)
+		}
+	}
+}
+
+func TestParseSearchQueryWithPhrase(t *testing.T) {
+	query, err := ParseSearchQuery(`"rate limit"  exceeded`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*SearchTerm{
+		{Value: "rate limit", Phrase: true},
+		{Value: "exceeded"},
+	}
+	if !reflect.DeepEqual(query.Terms, expected) {
+		t.Errorf(`Unexpected terms: %+v`, query.Terms)
+	}
+}
+
+func TestParseSearchQueryWithUnterminatedPhrase(t *testing.T) {
+	query, err := ParseSearchQuery(`title:"rate limit`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*SearchTerm{{Field: SearchFieldTitle, Value: "rate limit", Phrase: true}}
+	if !reflect.DeepEqual(query.Terms, expected) {
+		t.Errorf(`Unexpected terms: %+v`, query.Terms)
+	}
+}
+
+func TestParseSearchQueryWithNegation(t *testing.T) {
+	query, err := ParseSearchQuery(`-kubernetes -"service mesh" -author:bob`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*SearchTerm{
+		{Value: "kubernetes", Negated: true},
+		{Value: "service mesh", Phrase: true, Negated: true},
+		{Field: SearchFieldAuthor, Value: "bob", Negated: true},
+	}
+	if !reflect.DeepEqual(query.Terms, expected) {
+		t.Errorf(`Unexpected terms: %+v`, query.Terms)
+	}
+}
+
+func TestParseSearchQueryWithHyphenatedWord(t *testing.T) {
+	query, err := ParseSearchQuery(`real-time`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*SearchTerm{{Value: "real-time"}}
+	if !reflect.DeepEqual(query.Terms, expected) {
+		t.Errorf(`Unexpected terms: %+v`, query.Terms)
+	}
+}
+
+func TestParseSearchQueryWithAllOperators(t *testing.T) {
+	query, err := ParseSearchQuery(`title:"rate limit" author:alice feed:12 category:News tag:later note:"key finding" before:2024-01-01 after:2023-06-15 -kubernetes is:starred`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*SearchTerm{
+		{Field: SearchFieldTitle, Value: "rate limit", Phrase: true},
+		{Field: SearchFieldAuthor, Value: "alice"},
+		{Field: SearchFieldFeed, Value: "12", ID: 12},
+		{Field: SearchFieldCategory, Value: "News"},
+		{Field: SearchFieldTag, Value: "later"},
+		{Field: SearchFieldNote, Value: "key finding", Phrase: true},
+		{Field: SearchFieldBefore, Value: "2024-01-01", Date: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{Field: SearchFieldAfter, Value: "2023-06-15", Date: time.Date(2023, time.June, 15, 0, 0, 0, 0, time.UTC)},
+		{Value: "kubernetes", Negated: true},
+		{Field: SearchFieldIs, Value: "starred"},
+	}
+	if !reflect.DeepEqual(query.Terms, expected) {
+		t.Errorf(`Unexpected terms: %+v`, query.Terms)
+	}
+}
+
+func TestParseSearchQueryWithUppercaseOperators(t *testing.T) {
+	query, err := ParseSearchQuery(`Title:Go IS:Unread`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*SearchTerm{
+		{Field: SearchFieldTitle, Value: "Go"},
+		{Field: SearchFieldIs, Value: "unread"},
+	}
+	if !reflect.DeepEqual(query.Terms, expected) {
+		t.Errorf(`Unexpected terms: %+v`, query.Terms)
+	}
+}
+
+func TestParseSearchQueryWithFeedAndCategoryTitles(t *testing.T) {
+	query, err := ParseSearchQuery(`feed:"Hacker News" category:"2023"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*SearchTerm{
+		{Field: SearchFieldFeed, Value: "Hacker News", Phrase: true},
+		{Field: SearchFieldCategory, Value: "2023", Phrase: true},
+	}
+	if !reflect.DeepEqual(query.Terms, expected) {
+		t.Errorf(`Unexpected terms: %+v`, query.Terms)
+	}
+}
+
+func TestParseSearchQueryWithUnknownOperator(t *testing.T) {
+	query, err := ParseSearchQuery(`https://example.org/ "note: this"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*SearchTerm{
+		{Value: "https://example.org/"},
+		{Value: "note: this", Phrase: true},
+	}
+	if !reflect.DeepEqual(query.Terms, expected) {
+		t.Errorf(`Unexpected terms: %+v`, query.Terms)
+	}
+}
+
+func TestParseSearchQueryWithInvalidOperators(t *testing.T) {
+	inputs := []string{
+		`title:`,
+		`author:""`,
+		`-feed:`,
+		`before:yesterday`,
+		`after:2024-13-01`,
+		`before:01/02/2024`,
+		`is:removed`,
+		`is:`,
+	}
+
+	for _, input := range inputs {
+		if _, err := ParseSearchQuery(input); err == nil {
+			t.Errorf(`The query %q should be rejected`, input)
+		}
+	}
+}
+
+func TestSearchQueryConditions(t *testing.T) {
+	query, err := ParseSearchQuery(`golang title:"rate limit" -kubernetes generics is:unread`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := &Storage{}
+	conditions, args, rankPlaceholder := store.searchQueryConditions(query, 2)
+
+	expectedConditions := []string{
+		"e.document_vectors @@ plainto_tsquery($3)",
+		"strpos(lower(e.title), lower($4)) > 0",
+		"NOT (e.document_vectors @@ plainto_tsquery($5))",
+		"e.status = $6",
+	}
+	if !reflect.DeepEqual(conditions, expectedConditions) {
+		t.Errorf(`Unexpected conditions: %q`, conditions)
+	}
+
+	expectedArgs := []interface{}{"golang generics", "rate limit", "kubernetes", "unread"}
+	if !reflect.DeepEqual(args, expectedArgs) {
+		t.Errorf(`Unexpected arguments: %v`, args)
+	}
+
+	if rankPlaceholder != 3 {
+		t.Errorf(`The results should be ranked with the placeholder $3 instead of $%d`, rankPlaceholder)
+	}
+}
+
+func TestSearchQueryConditionsWithSQLite(t *testing.T) {
+	query, err := ParseSearchQuery(`golang generics category:news`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := &Storage{sqlite: true}
+	conditions, args, _ := store.searchQueryConditions(query, 0)
+
+	if len(conditions) != 2 {
+		t.Fatalf(`Unexpected conditions: %q`, conditions)
+	}
+
+	if !strings.Contains(conditions[0], "lower($1)") || !strings.Contains(conditions[0], " AND ") || !strings.Contains(conditions[0], "lower($2)") {
+		t.Errorf(`Each word should have its own condition: %q`, conditions[0])
+	}
+
+	if conditions[1] != "EXISTS (SELECT 1 FROM categories sc WHERE sc.id=f.category_id AND instr(lower(sc.title), lower($3)) > 0)" {
+		t.Errorf(`Unexpected category condition: %q`, conditions[1])
+	}
+
+	expectedArgs := []interface{}{"golang", "generics", "news"}
+	if !reflect.DeepEqual(args, expectedArgs) {
+		t.Errorf(`Unexpected arguments: %v`, args)
+	}
+}
+
+func TestSearchQueryConditionsWithNote(t *testing.T) {
+	query, err := ParseSearchQuery(`-note:todo`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := &Storage{}
+	conditions, args, _ := store.searchQueryConditions(query, 0)
+
+	expectedConditions := []string{
+		"NOT (EXISTS (SELECT 1 FROM annotations an WHERE an.entry_id=e.id AND (strpos(lower(an.quote), lower($1)) > 0 OR strpos(lower(an.note), lower($1)) > 0)))",
+	}
+	if !reflect.DeepEqual(conditions, expectedConditions) {
+		t.Errorf(`Unexpected conditions: %q`, conditions)
+	}
+
+	if len(args) != 1 || args[0] != "todo" {
+		t.Errorf(`Unexpected arguments: %v`, args)
+	}
+}
+
+func TestSearchQueryConditionsWithSQLiteMatchesAnnotations(t *testing.T) {
+	query, err := ParseSearchQuery(`golang`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := &Storage{sqlite: true}
+	conditions, _, _ := store.searchQueryConditions(query, 0)
+
+	if len(conditions) != 1 || !strings.Contains(conditions[0], "instr(lower(an.note), lower($1)) > 0") {
+		t.Errorf(`Words should be searched in the annotations: %q`, conditions)
+	}
+}
+
+func TestSearchQueryConditionsWithoutText(t *testing.T) {
+	query, err := ParseSearchQuery(`feed:12 -is:starred before:2024-01-01`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := &Storage{}
+	conditions, args, rankPlaceholder := store.searchQueryConditions(query, 1)
+
+	expectedConditions := []string{
+		"e.feed_id = $2",
+		"NOT (e.starred is true)",
+		"e.published_at < $3",
+	}
+	if !reflect.DeepEqual(conditions, expectedConditions) {
+		t.Errorf(`Unexpected conditions: %q`, conditions)
+	}
+
+	if len(args) != 2 || args[0] != int64(12) {
+		t.Errorf(`Unexpected arguments: %v`, args)
+	}
+
+	if rankPlaceholder != 0 {
+		t.Errorf(`The results should not be ranked without text, got $%d`, rankPlaceholder)
+	}
+}
+
+func TestSearchQueryConditionsRankedByPhrase(t *testing.T) {
+	query, err := ParseSearchQuery(`author:alice "rate limit"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := &Storage{}
+	conditions, _, rankPlaceholder := store.searchQueryConditions(query, 0)
+
+	if conditions[1] != "e.document_vectors @@ phraseto_tsquery($2)" {
+		t.Errorf(`Unexpected phrase condition: %q`, conditions[1])
+	}
+
+	if rankPlaceholder != 2 {
+		t.Errorf(`The results should be ranked with the phrase placeholder, got $%d`, rankPlaceholder)
+	}
+}
+
+func TestParseSearchQueryOrTextWithInvalidQuery(t *testing.T) {
+	query := parseSearchQueryOrText("is:pinned golang")
+	expected := []*SearchTerm{{Value: "is:pinned golang"}}
+	if !reflect.DeepEqual(query.Terms, expected) {
+		t.Errorf(`Invalid queries should be searched as plain text, got %+v`, query.Terms)
+	}
+}
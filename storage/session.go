@@ -7,6 +7,7 @@ package storage // import "miniflux.app/storage"
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"miniflux.app/crypto"
 	"miniflux.app/model"
@@ -63,6 +64,10 @@ func (s *Storage) UpdateAppSessionField(sessionID, field string, value interface
 		WHERE
 			id=$2
 	`
+	if s.sqlite {
+		query = `UPDATE sessions SET data = json_set(CAST(data AS TEXT), '$.%s', CAST($1 AS TEXT)) WHERE id=$2`
+	}
+
 	_, err := s.db.Exec(fmt.Sprintf(query, field), value, sessionID)
 	if err != nil {
 		return fmt.Errorf(`store: unable to update session field: %v`, err)
@@ -112,9 +117,9 @@ func (s *Storage) CleanOldSessions(days int) int64 {
 		DELETE FROM
 			sessions
 		WHERE
-			id IN (SELECT id FROM sessions WHERE created_at < now() - interval '%d days')
+			id IN (SELECT id FROM sessions WHERE created_at < $1)
 	`
-	result, err := s.db.Exec(fmt.Sprintf(query, days))
+	result, err := s.db.Exec(query, time.Now().AddDate(0, 0, -days))
 	if err != nil {
 		return 0
 	}
@@ -8,22 +8,37 @@ import (
 	"context"
 	"database/sql"
 	"time"
+
+	"github.com/lib/pq"
+
+	"miniflux.app/database"
+	"miniflux.app/event"
 )
 
 // Storage handles all operations related to the database.
 type Storage struct {
-	db *sql.DB
+	db     *sql.DB
+	sqlite bool
+	events *event.Broker
+
+	// eventsListener receives the events of all the processes, it is nil with SQLite or when the process doesn't serve users.
+	eventsListener *pq.Listener
 }
 
 // NewStorage returns a new Storage.
 func NewStorage(db *sql.DB) *Storage {
-	return &Storage{db}
+	return &Storage{db: db, sqlite: database.IsSQLite(db), events: event.NewBroker()}
 }
 
 // DatabaseVersion returns the version of the database which is in use.
 func (s *Storage) DatabaseVersion() string {
+	query := `SELECT current_setting('server_version')`
+	if s.sqlite {
+		query = `SELECT 'SQLite ' || sqlite_version()`
+	}
+
 	var dbVersion string
-	err := s.db.QueryRow(`SELECT current_setting('server_version')`).Scan(&dbVersion)
+	err := s.db.QueryRow(query).Scan(&dbVersion)
 	if err != nil {
 		return err.Error()
 	}
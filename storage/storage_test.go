@@ -0,0 +1,66 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"miniflux.app/database"
+	"miniflux.app/model"
+)
+
+// newTestStorage returns a storage backed by a new SQLite database.
+func newTestStorage(t *testing.T) *Storage {
+	t.Helper()
+
+	db, err := database.NewConnectionPool("sqlite://"+filepath.Join(t.TempDir(), "miniflux.db"), 1, 1, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := database.Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewStorage(db)
+}
+
+func createTestUser(t *testing.T, store *Storage, username string) *model.User {
+	t.Helper()
+
+	user, err := store.CreateUser(&model.UserCreationRequest{Username: username, Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return user
+}
+
+// createTestFeed creates a feed of the user with the given entries.
+func createTestFeed(t *testing.T, store *Storage, user *model.User, feedURL string, entries ...*model.Entry) *model.Feed {
+	t.Helper()
+
+	category, err := store.FirstCategory(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feed := &model.Feed{
+		UserID:   user.ID,
+		Category: category,
+		FeedURL:  feedURL,
+		Title:    feedURL,
+		Entries:  entries,
+	}
+
+	if err := store.CreateFeed(feed); err != nil {
+		t.Fatal(err)
+	}
+
+	return feed
+}
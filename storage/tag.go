@@ -0,0 +1,272 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"miniflux.app/model"
+)
+
+// Tag returns a tag from the database.
+func (s *Storage) Tag(userID, tagID int64) (*model.Tag, error) {
+	var tag model.Tag
+
+	query := `SELECT id, user_id, title FROM tags WHERE user_id=$1 AND id=$2`
+	err := s.db.QueryRow(query, userID, tagID).Scan(&tag.ID, &tag.UserID, &tag.Title)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch tag: %v`, err)
+	default:
+		return &tag, nil
+	}
+}
+
+// TagByTitle finds a tag by the title.
+func (s *Storage) TagByTitle(userID int64, title string) (*model.Tag, error) {
+	var tag model.Tag
+
+	query := `SELECT id, user_id, title FROM tags WHERE user_id=$1 AND title=$2`
+	err := s.db.QueryRow(query, userID, title).Scan(&tag.ID, &tag.UserID, &tag.Title)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch tag: %v`, err)
+	default:
+		return &tag, nil
+	}
+}
+
+// Tags returns all tags that belongs to the given user with their number of entries.
+func (s *Storage) Tags(userID int64) (model.Tags, error) {
+	query := `
+		SELECT
+			t.id,
+			t.user_id,
+			t.title,
+			count(e.id) AS entry_count,
+			count(e.id) FILTER (WHERE e.status='unread') AS count_unread
+		FROM tags t
+		LEFT JOIN entry_tags et ON et.tag_id=t.id
+		LEFT JOIN entries e ON e.id=et.entry_id AND e.status <> 'removed'
+		WHERE t.user_id=$1
+		GROUP BY t.id
+		ORDER BY t.title ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch tags: %v`, err)
+	}
+	defer rows.Close()
+
+	tags := make(model.Tags, 0)
+	for rows.Next() {
+		var tag model.Tag
+		if err := rows.Scan(&tag.ID, &tag.UserID, &tag.Title, &tag.EntryCount, &tag.TotalUnread); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch tag row: %v`, err)
+		}
+
+		tags = append(tags, &tag)
+	}
+
+	return tags, nil
+}
+
+// EntryTags returns the titles of the tags attached to the given entry.
+func (s *Storage) EntryTags(userID, entryID int64) ([]string, error) {
+	query := `
+		SELECT
+			t.title
+		FROM tags t
+		JOIN entry_tags et ON et.tag_id=t.id
+		WHERE t.user_id=$1 AND et.entry_id=$2
+		ORDER BY t.title ASC
+	`
+	rows, err := s.db.Query(query, userID, entryID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch tags of entry #%d: %v`, entryID, err)
+	}
+	defer rows.Close()
+
+	titles := make([]string, 0)
+	for rows.Next() {
+		var title string
+		if err := rows.Scan(&title); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch tag row: %v`, err)
+		}
+
+		titles = append(titles, title)
+	}
+
+	return titles, nil
+}
+
+// SetEntryTags replaces all the tags attached to the given entry.
+func (s *Storage) SetEntryTags(userID, entryID int64, titles []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM entry_tags WHERE entry_id IN (SELECT id FROM entries WHERE user_id=$1 AND id=$2)`, userID, entryID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to remove tags of entry #%d: %v`, entryID, err)
+	}
+
+	for _, title := range normalizeTagTitles(titles) {
+		if err := s.tagEntries(tx, userID, []int64{entryID}, title); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := s.removeOrphanTags(tx, userID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// AddEntriesTag attaches a tag to the given list of entries, the tag is created if necessary.
+func (s *Storage) AddEntriesTag(userID int64, entryIDs []int64, title string) error {
+	titles := normalizeTagTitles([]string{title})
+	if len(titles) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	if err := s.tagEntries(tx, userID, entryIDs, titles[0]); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// RemoveEntriesTag detaches a tag from the given list of entries.
+func (s *Storage) RemoveEntriesTag(userID int64, entryIDs []int64, title string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	query := `
+		DELETE FROM
+			entry_tags
+		WHERE
+			entry_id=ANY($1)
+		AND
+			tag_id IN (SELECT id FROM tags WHERE user_id=$2 AND title=$3)
+	`
+	if _, err := tx.Exec(query, pq.Array(entryIDs), userID, strings.TrimSpace(title)); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to remove tag %q from entries %v: %v`, title, entryIDs, err)
+	}
+
+	if err := s.removeOrphanTags(tx, userID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// RemoveTag deletes a tag and detaches it from all entries.
+func (s *Storage) RemoveTag(userID, tagID int64) error {
+	result, err := s.db.Exec(`DELETE FROM tags WHERE id = $1 AND user_id = $2`, tagID, userID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove this tag: %v`, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove this tag: %v`, err)
+	}
+
+	if count == 0 {
+		return fmt.Errorf(`store: no tag has been removed`)
+	}
+
+	return nil
+}
+
+func (s *Storage) tagEntries(tx *sql.Tx, userID int64, entryIDs []int64, title string) error {
+	var tagID int64
+	err := tx.QueryRow(
+		`INSERT INTO tags (user_id, title) VALUES ($1, $2) ON CONFLICT (user_id, title) DO UPDATE SET title=EXCLUDED.title RETURNING id`,
+		userID,
+		title,
+	).Scan(&tagID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create tag %q: %v`, title, err)
+	}
+
+	query := `
+		INSERT INTO entry_tags
+			(entry_id, tag_id)
+		SELECT
+			id, $1
+		FROM
+			entries
+		WHERE
+			user_id=$2 AND id=ANY($3)
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.Exec(query, tagID, userID, pq.Array(entryIDs)); err != nil {
+		return fmt.Errorf(`store: unable to attach tag %q to entries %v: %v`, title, entryIDs, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) removeOrphanTags(tx *sql.Tx, userID int64) error {
+	query := `DELETE FROM tags WHERE user_id=$1 AND NOT EXISTS (SELECT 1 FROM entry_tags WHERE tag_id=tags.id)`
+	if _, err := tx.Exec(query, userID); err != nil {
+		return fmt.Errorf(`store: unable to remove unused tags: %v`, err)
+	}
+
+	return nil
+}
+
+func normalizeTagTitles(titles []string) []string {
+	seen := make(map[string]bool)
+	normalized := make([]string, 0, len(titles))
+	for _, title := range titles {
+		title = strings.TrimSpace(title)
+		if title == "" || seen[title] {
+			continue
+		}
+
+		seen[title] = true
+		normalized = append(normalized, title)
+	}
+
+	return normalized
+}
@@ -7,11 +7,20 @@ package storage // import "miniflux.app/storage"
 import (
 	"fmt"
 	"strings"
+
+	"miniflux.app/timezone"
 )
 
 // Timezones returns all timezones supported by the database.
 func (s *Storage) Timezones() (map[string]string, error) {
 	timezones := make(map[string]string)
+	if s.sqlite {
+		for _, name := range timezone.Available() {
+			timezones[name] = name
+		}
+		return timezones, nil
+	}
+
 	rows, err := s.db.Query(`SELECT name FROM pg_timezone_names() ORDER BY name ASC`)
 	if err != nil {
 		return nil, fmt.Errorf(`store: unable to fetch timezones: %v`, err)
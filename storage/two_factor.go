@@ -0,0 +1,220 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"miniflux.app/crypto"
+)
+
+// TwoFactorSecret returns the TOTP secret of the user, the secret is not enabled until the enrollment is confirmed.
+func (s *Storage) TwoFactorSecret(userID int64) (secret string, enabled bool, err error) {
+	query := `SELECT two_factor_secret, two_factor_enabled FROM users WHERE id=$1`
+	err = s.db.QueryRow(query, userID).Scan(&secret, &enabled)
+	switch {
+	case err == sql.ErrNoRows:
+		return "", false, nil
+	case err != nil:
+		return "", false, fmt.Errorf(`store: unable to fetch two-factor secret: %v`, err)
+	}
+
+	return secret, enabled, nil
+}
+
+// HasTwoFactor returns true if the user must provide a one-time code after the password.
+func (s *Storage) HasTwoFactor(userID int64) bool {
+	var result bool
+	query := `SELECT true FROM users WHERE id=$1 AND two_factor_enabled is true`
+	s.db.QueryRow(query, userID).Scan(&result)
+	return result
+}
+
+// SetPendingTwoFactorSecret stores the TOTP secret shown during the enrollment.
+func (s *Storage) SetPendingTwoFactorSecret(userID int64, secret string) error {
+	query := `UPDATE users SET two_factor_secret=$1 WHERE id=$2 AND two_factor_enabled is false`
+	if _, err := s.db.Exec(query, secret, userID); err != nil {
+		return fmt.Errorf(`store: unable to update two-factor secret: %v`, err)
+	}
+
+	return nil
+}
+
+// EnableTwoFactor activates the pending TOTP secret and replaces the recovery codes.
+func (s *Storage) EnableTwoFactor(userID int64, recoveryCodes []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	if _, err := tx.Exec(`UPDATE users SET two_factor_enabled='t' WHERE id=$1 AND two_factor_secret <> ''`, userID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to enable two-factor authentication: %v`, err)
+	}
+
+	if err := s.replaceRecoveryCodes(tx, userID, recoveryCodes); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// DisableTwoFactor removes the TOTP secret and the recovery codes of the user.
+func (s *Storage) DisableTwoFactor(userID int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	if _, err := tx.Exec(`UPDATE users SET two_factor_secret='', two_factor_enabled='f', two_factor_last_time_step=0, two_factor_failed_attempts=0, two_factor_locked_until=0 WHERE id=$1`, userID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to disable two-factor authentication: %v`, err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM user_recovery_codes WHERE user_id=$1`, userID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to remove recovery codes: %v`, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes invalidates the previous recovery codes of the user.
+func (s *Storage) ReplaceRecoveryCodes(userID int64, recoveryCodes []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	if err := s.replaceRecoveryCodes(tx, userID, recoveryCodes); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// UseTwoFactorTimeStep records the time step of an accepted one-time code.
+// It returns false if a code of the same or a later time step has already been accepted, this way each code can be used only once.
+func (s *Storage) UseTwoFactorTimeStep(userID, timeStep int64) (bool, error) {
+	query := `UPDATE users SET two_factor_last_time_step=$1 WHERE id=$2 AND two_factor_last_time_step < $1`
+	result, err := s.db.Exec(query, timeStep, userID)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to update two-factor time step: %v`, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to update two-factor time step: %v`, err)
+	}
+
+	return count == 1, nil
+}
+
+// TwoFactorLocked returns true if the user entered too many invalid one-time codes and must wait before trying again.
+func (s *Storage) TwoFactorLocked(userID int64) (bool, error) {
+	var lockedUntil int64
+	query := `SELECT two_factor_locked_until FROM users WHERE id=$1`
+	err := s.db.QueryRow(query, userID).Scan(&lockedUntil)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf(`store: unable to fetch two-factor lock: %v`, err)
+	}
+
+	return lockedUntil > time.Now().Unix(), nil
+}
+
+// AddTwoFactorFailure counts an invalid one-time code.
+// The user is locked out for the given duration after maxAttempts invalid codes, it returns true when that happens.
+func (s *Storage) AddTwoFactorFailure(userID int64, maxAttempts int, lockDuration time.Duration) (bool, error) {
+	query := `
+		UPDATE
+			users
+		SET
+			two_factor_failed_attempts = CASE WHEN two_factor_failed_attempts + 1 >= $2 THEN 0 ELSE two_factor_failed_attempts + 1 END,
+			two_factor_locked_until = CASE WHEN two_factor_failed_attempts + 1 >= $2 THEN $3 ELSE two_factor_locked_until END
+		WHERE
+			id=$1
+		RETURNING
+			two_factor_locked_until
+	`
+
+	var lockedUntil int64
+	if err := s.db.QueryRow(query, userID, maxAttempts, time.Now().Add(lockDuration).Unix()).Scan(&lockedUntil); err != nil {
+		return false, fmt.Errorf(`store: unable to update two-factor failures: %v`, err)
+	}
+
+	return lockedUntil > time.Now().Unix(), nil
+}
+
+// ResetTwoFactorFailures clears the invalid one-time codes counted for the user after a successful login.
+func (s *Storage) ResetTwoFactorFailures(userID int64) error {
+	query := `UPDATE users SET two_factor_failed_attempts=0 WHERE id=$1`
+	if _, err := s.db.Exec(query, userID); err != nil {
+		return fmt.Errorf(`store: unable to reset two-factor failures: %v`, err)
+	}
+
+	return nil
+}
+
+// Only a hash of the recovery codes is stored, the codes are random enough to not need a slow hash function.
+func (s *Storage) replaceRecoveryCodes(tx *sql.Tx, userID int64, recoveryCodes []string) error {
+	if _, err := tx.Exec(`DELETE FROM user_recovery_codes WHERE user_id=$1`, userID); err != nil {
+		return fmt.Errorf(`store: unable to remove recovery codes: %v`, err)
+	}
+
+	for _, code := range recoveryCodes {
+		query := `INSERT INTO user_recovery_codes (user_id, code_hash) VALUES ($1, $2)`
+		if _, err := tx.Exec(query, userID, crypto.Hash(code)); err != nil {
+			return fmt.Errorf(`store: unable to create recovery code: %v`, err)
+		}
+	}
+
+	return nil
+}
+
+// CountRecoveryCodes returns the number of recovery codes not used yet.
+func (s *Storage) CountRecoveryCodes(userID int64) (int, error) {
+	var count int
+	query := `SELECT count(*) FROM user_recovery_codes WHERE user_id=$1 AND used_at IS NULL`
+	if err := s.db.QueryRow(query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf(`store: unable to count recovery codes: %v`, err)
+	}
+
+	return count, nil
+}
+
+// UseRecoveryCode marks the recovery code as used, it returns false if the code is unknown or already used.
+func (s *Storage) UseRecoveryCode(userID int64, code string) (bool, error) {
+	query := `UPDATE user_recovery_codes SET used_at=now() WHERE user_id=$1 AND code_hash=$2 AND used_at IS NULL`
+	result, err := s.db.Exec(query, userID, crypto.Hash(code))
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to use recovery code: %v`, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to use recovery code: %v`, err)
+	}
+
+	return count == 1, nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTwoFactorEnrollment(t *testing.T) {
+	store := newTestStorage(t)
+	user := createTestUser(t, store, "alice")
+
+	if err := store.SetPendingTwoFactorSecret(user.ID, "JBSWY3DPEHPK3PXP"); err != nil {
+		t.Fatal(err)
+	}
+
+	if store.HasTwoFactor(user.ID) {
+		t.Fatal(`A pending secret should not enable two-factor authentication`)
+	}
+
+	if err := store.EnableTwoFactor(user.ID, []string{"aaaaa-aaaaa", "bbbbb-bbbbb"}); err != nil {
+		t.Fatal(err)
+	}
+
+	secret, enabled, err := store.TwoFactorSecret(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !enabled || secret != "JBSWY3DPEHPK3PXP" || !store.HasTwoFactor(user.ID) {
+		t.Fatalf(`Two-factor authentication should be enabled, got secret=%q enabled=%v`, secret, enabled)
+	}
+
+	if err := store.SetPendingTwoFactorSecret(user.ID, "KRSXG5CTMVRXEZLU"); err != nil {
+		t.Fatal(err)
+	}
+
+	if secret, _, _ := store.TwoFactorSecret(user.ID); secret != "JBSWY3DPEHPK3PXP" {
+		t.Fatalf(`The secret of an enabled account should not be replaced, got %q`, secret)
+	}
+
+	if count, err := store.CountRecoveryCodes(user.ID); err != nil || count != 2 {
+		t.Fatalf(`Unexpected number of recovery codes, got %d (%v)`, count, err)
+	}
+}
+
+func TestTwoFactorTimeStepIsUsedOnce(t *testing.T) {
+	store := newTestStorage(t)
+	user := createTestUser(t, store, "alice")
+
+	scenarios := []struct {
+		timeStep int64
+		expected bool
+	}{
+		{100, true},
+		{100, false},
+		{99, false},
+		{101, true},
+	}
+
+	for _, scenario := range scenarios {
+		used, err := store.UseTwoFactorTimeStep(user.ID, scenario.timeStep)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if used != scenario.expected {
+			t.Errorf(`Unexpected result for time step %d, got %v instead of %v`, scenario.timeStep, used, scenario.expected)
+		}
+	}
+
+	other := createTestUser(t, store, "bob")
+	if used, _ := store.UseTwoFactorTimeStep(other.ID, 100); !used {
+		t.Fatal(`The time steps used by a user should not affect the other users`)
+	}
+}
+
+func TestRecoveryCodeIsUsedOnce(t *testing.T) {
+	store := newTestStorage(t)
+	user := createTestUser(t, store, "alice")
+	other := createTestUser(t, store, "bob")
+
+	if err := store.EnableTwoFactor(user.ID, []string{"aaaaa-aaaaa"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if used, _ := store.UseRecoveryCode(other.ID, "aaaaa-aaaaa"); used {
+		t.Fatal(`The recovery code of another user should be rejected`)
+	}
+
+	if used, err := store.UseRecoveryCode(user.ID, "aaaaa-aaaaa"); err != nil || !used {
+		t.Fatalf(`The recovery code should be accepted, got %v (%v)`, used, err)
+	}
+
+	if used, _ := store.UseRecoveryCode(user.ID, "aaaaa-aaaaa"); used {
+		t.Fatal(`A recovery code should be accepted only once`)
+	}
+
+	if count, _ := store.CountRecoveryCodes(user.ID); count != 0 {
+		t.Fatalf(`The used recovery code should not be counted, got %d`, count)
+	}
+
+	if err := store.ReplaceRecoveryCodes(user.ID, []string{"ccccc-ccccc"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if used, _ := store.UseRecoveryCode(user.ID, "ccccc-ccccc"); !used {
+		t.Fatal(`The new recovery code should be accepted`)
+	}
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	store := newTestStorage(t)
+	user := createTestUser(t, store, "alice")
+
+	// This is what the -reset-2fa flag does.
+	store.SetPendingTwoFactorSecret(user.ID, "JBSWY3DPEHPK3PXP")
+	store.EnableTwoFactor(user.ID, []string{"aaaaa-aaaaa"})
+	store.UseTwoFactorTimeStep(user.ID, 100)
+	store.AddTwoFactorFailure(user.ID, 1, time.Hour)
+
+	if err := store.DisableTwoFactor(user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if store.HasTwoFactor(user.ID) {
+		t.Fatal(`Two-factor authentication should be disabled`)
+	}
+
+	if secret, enabled, _ := store.TwoFactorSecret(user.ID); secret != "" || enabled {
+		t.Fatalf(`The secret should be removed, got secret=%q enabled=%v`, secret, enabled)
+	}
+
+	if count, _ := store.CountRecoveryCodes(user.ID); count != 0 {
+		t.Fatalf(`The recovery codes should be removed, got %d`, count)
+	}
+
+	if locked, _ := store.TwoFactorLocked(user.ID); locked {
+		t.Fatal(`The lock should be removed`)
+	}
+
+	if used, _ := store.UseTwoFactorTimeStep(user.ID, 1); !used {
+		t.Fatal(`The time step should be reset for the next enrollment`)
+	}
+}
+
+func TestTwoFactorLockout(t *testing.T) {
+	store := newTestStorage(t)
+	user := createTestUser(t, store, "alice")
+
+	for i := 1; i < 3; i++ {
+		if locked, err := store.AddTwoFactorFailure(user.ID, 3, time.Hour); err != nil || locked {
+			t.Fatalf(`The user should not be locked after %d failures, got %v (%v)`, i, locked, err)
+		}
+	}
+
+	if locked, _ := store.AddTwoFactorFailure(user.ID, 3, time.Hour); !locked {
+		t.Fatal(`The user should be locked after 3 failures`)
+	}
+
+	if locked, err := store.TwoFactorLocked(user.ID); err != nil || !locked {
+		t.Fatalf(`The user should be locked, got %v (%v)`, locked, err)
+	}
+
+	other := createTestUser(t, store, "bob")
+	if locked, _ := store.TwoFactorLocked(other.ID); locked {
+		t.Fatal(`The other users should not be locked`)
+	}
+}
+
+func TestTwoFactorLockoutExpires(t *testing.T) {
+	store := newTestStorage(t)
+	user := createTestUser(t, store, "alice")
+
+	if locked, _ := store.AddTwoFactorFailure(user.ID, 1, -time.Minute); locked {
+		t.Fatal(`An expired lock should not be reported`)
+	}
+
+	if locked, _ := store.TwoFactorLocked(user.ID); locked {
+		t.Fatal(`The lock should be expired`)
+	}
+}
+
+func TestResetTwoFactorFailures(t *testing.T) {
+	store := newTestStorage(t)
+	user := createTestUser(t, store, "alice")
+
+	store.AddTwoFactorFailure(user.ID, 3, time.Hour)
+	store.AddTwoFactorFailure(user.ID, 3, time.Hour)
+
+	if err := store.ResetTwoFactorFailures(user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if locked, _ := store.AddTwoFactorFailure(user.ID, 3, time.Hour); locked {
+		t.Fatal(`The failures should be counted again from zero after a successful login`)
+	}
+}
@@ -39,6 +39,52 @@ func (s *Storage) SetLastLogin(userID int64) error {
 	return nil
 }
 
+// SetAdmin grants or removes the administrator role of a user.
+func (s *Storage) SetAdmin(userID int64, isAdmin bool) error {
+	query := `UPDATE users SET is_admin=$1 WHERE id=$2`
+	_, err := s.db.Exec(query, isAdmin, userID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update administrator role: %v`, err)
+	}
+
+	return nil
+}
+
+// SetDeniedByGroups remembers whether the identity provider groups of a user are allowed to log in.
+// The sessions of the user are removed when the user is denied.
+func (s *Storage) SetDeniedByGroups(userID int64, denied bool) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	if _, err := tx.Exec(`UPDATE users SET denied_by_groups=$1 WHERE id=$2`, denied, userID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to update groups restriction: %v`, err)
+	}
+
+	if denied {
+		if _, err := tx.Exec(`DELETE FROM user_sessions WHERE user_id=$1`, userID); err != nil {
+			tx.Rollback()
+			return fmt.Errorf(`store: unable to remove user sessions: %v`, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// IsDeniedByGroups returns true if the last groups received from the identity provider were not allowed to log in.
+func (s *Storage) IsDeniedByGroups(userID int64) bool {
+	var result bool
+	query := `SELECT true FROM users WHERE id=$1 AND denied_by_groups is true`
+	s.db.QueryRow(query, userID).Scan(&result)
+	return result
+}
+
 // UserExists checks if a user exists by using the given username.
 func (s *Storage) UserExists(username string) bool {
 	var result bool
@@ -90,7 +136,8 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 		    default_reading_speed,
 		    cjk_reading_speed,
 		    default_home_page,
-		    categories_sorting_order
+		    categories_sorting_order,
+		    filter_rules
 	`
 
 	tx, err := s.db.Begin()
@@ -128,6 +175,7 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 		&user.CJKReadingSpeed,
 		&user.DefaultHomePage,
 		&user.CategoriesSortingOrder,
+		&user.FilterRules,
 	)
 	if err != nil {
 		tx.Rollback()
@@ -183,9 +231,10 @@ func (s *Storage) UpdateUser(user *model.User) error {
 				default_reading_speed=$18,
 				cjk_reading_speed=$19,
 				default_home_page=$20,
-				categories_sorting_order=$21
+				categories_sorting_order=$21,
+				filter_rules=$22
 			WHERE
-				id=$22
+				id=$23
 		`
 
 		_, err = s.db.Exec(
@@ -211,6 +260,7 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			user.CJKReadingSpeed,
 			user.DefaultHomePage,
 			user.CategoriesSortingOrder,
+			user.FilterRules,
 			user.ID,
 		)
 		if err != nil {
@@ -238,9 +288,10 @@ func (s *Storage) UpdateUser(user *model.User) error {
 				default_reading_speed=$17,
 				cjk_reading_speed=$18,
 				default_home_page=$19,
-				categories_sorting_order=$20
+				categories_sorting_order=$20,
+				filter_rules=$21
 			WHERE
-				id=$21
+				id=$22
 		`
 
 		_, err := s.db.Exec(
@@ -265,6 +316,7 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			user.CJKReadingSpeed,
 			user.DefaultHomePage,
 			user.CategoriesSortingOrder,
+			user.FilterRules,
 			user.ID,
 		)
 
@@ -311,7 +363,8 @@ func (s *Storage) UserByID(userID int64) (*model.User, error) {
 			default_reading_speed,
 			cjk_reading_speed,
 			default_home_page,
-			categories_sorting_order
+			categories_sorting_order,
+			filter_rules
 		FROM
 			users
 		WHERE
@@ -345,7 +398,8 @@ func (s *Storage) UserByUsername(username string) (*model.User, error) {
 			default_reading_speed,
 			cjk_reading_speed,
 			default_home_page,
-			categories_sorting_order
+			categories_sorting_order,
+			filter_rules
 		FROM
 			users
 		WHERE
@@ -379,7 +433,8 @@ func (s *Storage) UserByField(field, value string) (*model.User, error) {
 			default_reading_speed,
 			cjk_reading_speed,
 			default_home_page,
-			categories_sorting_order
+			categories_sorting_order,
+			filter_rules
 		FROM
 			users
 		WHERE
@@ -420,7 +475,8 @@ func (s *Storage) UserByAPIKey(token string) (*model.User, error) {
 			u.default_reading_speed,
 			u.cjk_reading_speed,
 			u.default_home_page,
-			u.categories_sorting_order
+			u.categories_sorting_order,
+			u.filter_rules
 		FROM
 			users u
 		LEFT JOIN
@@ -456,6 +512,7 @@ func (s *Storage) fetchUser(query string, args ...interface{}) (*model.User, err
 		&user.CJKReadingSpeed,
 		&user.DefaultHomePage,
 		&user.CategoriesSortingOrder,
+		&user.FilterRules,
 	)
 
 	if err == sql.ErrNoRows {
@@ -552,7 +609,8 @@ func (s *Storage) Users() (model.Users, error) {
 			default_reading_speed,
 			cjk_reading_speed,
 			default_home_page,
-			categories_sorting_order
+			categories_sorting_order,
+			filter_rules
 		FROM
 			users
 		ORDER BY username ASC
@@ -589,6 +647,7 @@ func (s *Storage) Users() (model.Users, error) {
 			&user.CJKReadingSpeed,
 			&user.DefaultHomePage,
 			&user.CategoriesSortingOrder,
+			&user.FilterRules,
 		)
 
 		if err != nil {
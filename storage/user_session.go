@@ -7,6 +7,7 @@ package storage // import "miniflux.app/storage"
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"miniflux.app/crypto"
 	"miniflux.app/model"
@@ -171,9 +172,9 @@ func (s *Storage) CleanOldUserSessions(days int) int64 {
 		DELETE FROM
 			user_sessions
 		WHERE
-			id IN (SELECT id FROM user_sessions WHERE created_at < now() - interval '%d days')
+			id IN (SELECT id FROM user_sessions WHERE created_at < $1)
 	`
-	result, err := s.db.Exec(fmt.Sprintf(query, days))
+	result, err := s.db.Exec(query, time.Now().AddDate(0, 0, -days))
 	if err != nil {
 		return 0
 	}
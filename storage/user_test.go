@@ -0,0 +1,47 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import "testing"
+
+func TestSetDeniedByGroups(t *testing.T) {
+	store := newTestStorage(t)
+	user := createTestUser(t, store, "alice")
+	other := createTestUser(t, store, "bob")
+
+	for _, username := range []string{"alice", "bob"} {
+		if _, _, err := store.CreateUserSessionFromUsername(username, "test", "127.0.0.1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if store.IsDeniedByGroups(user.ID) {
+		t.Fatal(`The users should not be denied by default`)
+	}
+
+	if err := store.SetDeniedByGroups(user.ID, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if !store.IsDeniedByGroups(user.ID) || store.IsDeniedByGroups(other.ID) {
+		t.Fatal(`Only the given user should be denied`)
+	}
+
+	if sessions, _ := store.UserSessions(user.ID); len(sessions) != 0 {
+		t.Fatalf(`The sessions of the denied user should be removed, got %d`, len(sessions))
+	}
+
+	if sessions, _ := store.UserSessions(other.ID); len(sessions) != 1 {
+		t.Fatalf(`The sessions of the other users should be kept, got %d`, len(sessions))
+	}
+
+	if err := store.SetDeniedByGroups(user.ID, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if store.IsDeniedByGroups(user.ID) {
+		t.Fatal(`The user should be allowed again`)
+	}
+}
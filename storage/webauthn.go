@@ -0,0 +1,162 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/model"
+)
+
+// CreateWebAuthnCredential stores a credential registered by the user.
+func (s *Storage) CreateWebAuthnCredential(credential *model.WebAuthnCredential) error {
+	query := `
+		INSERT INTO webauthn_credentials
+			(user_id, handle, name, credential_id, public_key, attestation_type, aaguid, sign_count, clone_warning)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING
+			id, created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		credential.UserID,
+		credential.Handle,
+		credential.Name,
+		credential.Credential.ID,
+		credential.Credential.PublicKey,
+		credential.Credential.AttestationType,
+		credential.Credential.Authenticator.AAGUID,
+		int64(credential.Credential.Authenticator.SignCount),
+		credential.Credential.Authenticator.CloneWarning,
+	).Scan(&credential.ID, &credential.CreatedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create WebAuthn credential: %v`, err)
+	}
+
+	return nil
+}
+
+// WebAuthnCredentials returns the credentials registered by the user.
+func (s *Storage) WebAuthnCredentials(userID int64) (model.WebAuthnCredentials, error) {
+	query := `
+		SELECT
+			id, user_id, handle, name, credential_id, public_key, attestation_type, aaguid, sign_count, clone_warning, created_at, last_seen_at
+		FROM
+			webauthn_credentials
+		WHERE
+			user_id=$1
+		ORDER BY
+			created_at ASC, id ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch WebAuthn credentials: %v`, err)
+	}
+	defer rows.Close()
+
+	credentials := make(model.WebAuthnCredentials, 0)
+	for rows.Next() {
+		var credential model.WebAuthnCredential
+		var signCount int64
+		if err := rows.Scan(
+			&credential.ID,
+			&credential.UserID,
+			&credential.Handle,
+			&credential.Name,
+			&credential.Credential.ID,
+			&credential.Credential.PublicKey,
+			&credential.Credential.AttestationType,
+			&credential.Credential.Authenticator.AAGUID,
+			&signCount,
+			&credential.Credential.Authenticator.CloneWarning,
+			&credential.CreatedAt,
+			&credential.LastSeenAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch WebAuthn credential row: %v`, err)
+		}
+
+		credential.Credential.Authenticator.SignCount = uint32(signCount)
+		credentials = append(credentials, &credential)
+	}
+
+	return credentials, nil
+}
+
+// WebAuthnCredentialByHandle returns the credential identified by the user handle sent by the authenticator.
+func (s *Storage) WebAuthnCredentialByHandle(handle []byte) (*model.WebAuthnCredential, error) {
+	query := `
+		SELECT
+			id, user_id, handle, name, credential_id, public_key, attestation_type, aaguid, sign_count, clone_warning, created_at, last_seen_at
+		FROM
+			webauthn_credentials
+		WHERE
+			handle=$1
+	`
+
+	var credential model.WebAuthnCredential
+	var signCount int64
+	err := s.db.QueryRow(query, handle).Scan(
+		&credential.ID,
+		&credential.UserID,
+		&credential.Handle,
+		&credential.Name,
+		&credential.Credential.ID,
+		&credential.Credential.PublicKey,
+		&credential.Credential.AttestationType,
+		&credential.Credential.Authenticator.AAGUID,
+		&signCount,
+		&credential.Credential.Authenticator.CloneWarning,
+		&credential.CreatedAt,
+		&credential.LastSeenAt,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch WebAuthn credential: %v`, err)
+	default:
+		credential.Credential.Authenticator.SignCount = uint32(signCount)
+		return &credential, nil
+	}
+}
+
+// WebAuthnCredentialUsed saves the signature counter of the authenticator after a successful login.
+func (s *Storage) WebAuthnCredentialUsed(credential *model.WebAuthnCredential) error {
+	query := `UPDATE webauthn_credentials SET sign_count=$1, clone_warning=$2, last_seen_at=now() WHERE id=$3`
+	_, err := s.db.Exec(
+		query,
+		int64(credential.Credential.Authenticator.SignCount),
+		credential.Credential.Authenticator.CloneWarning,
+		credential.ID,
+	)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update WebAuthn credential #%d: %v`, credential.ID, err)
+	}
+
+	return nil
+}
+
+// DisableWebAuthnCredential flags a credential that may be cloned, it cannot be used to log in anymore.
+func (s *Storage) DisableWebAuthnCredential(credentialID int64) error {
+	query := `UPDATE webauthn_credentials SET clone_warning=true WHERE id=$1`
+	if _, err := s.db.Exec(query, credentialID); err != nil {
+		return fmt.Errorf(`store: unable to disable WebAuthn credential #%d: %v`, credentialID, err)
+	}
+
+	return nil
+}
+
+// RemoveWebAuthnCredential deletes a credential of the user.
+func (s *Storage) RemoveWebAuthnCredential(userID, credentialID int64) error {
+	query := `DELETE FROM webauthn_credentials WHERE id=$1 AND user_id=$2`
+	if _, err := s.db.Exec(query, credentialID, userID); err != nil {
+		return fmt.Errorf(`store: unable to remove WebAuthn credential #%d: %v`, credentialID, err)
+	}
+
+	return nil
+}
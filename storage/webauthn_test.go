@@ -0,0 +1,113 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+
+	"miniflux.app/model"
+)
+
+func createTestWebAuthnCredential(t *testing.T, store *Storage, user *model.User, handle string) *model.WebAuthnCredential {
+	t.Helper()
+
+	credential := &model.WebAuthnCredential{
+		UserID: user.ID,
+		Handle: []byte(handle),
+		Name:   handle,
+		Credential: webauthn.Credential{
+			ID:              []byte("id-" + handle),
+			PublicKey:       []byte("key-" + handle),
+			AttestationType: "none",
+			Authenticator:   webauthn.Authenticator{AAGUID: make([]byte, 16), SignCount: 1},
+		},
+	}
+
+	if err := store.CreateWebAuthnCredential(credential); err != nil {
+		t.Fatal(err)
+	}
+
+	return credential
+}
+
+func TestWebAuthnCredentialByHandle(t *testing.T) {
+	store := newTestStorage(t)
+	user := createTestUser(t, store, "alice")
+	createTestWebAuthnCredential(t, store, user, "laptop")
+
+	credential, err := store.WebAuthnCredentialByHandle([]byte("laptop"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if credential == nil || credential.UserID != user.ID || !bytes.Equal(credential.Credential.PublicKey, []byte("key-laptop")) || credential.Credential.Authenticator.SignCount != 1 {
+		t.Fatalf(`Unexpected credential, got %v`, credential)
+	}
+
+	if credential, err := store.WebAuthnCredentialByHandle([]byte("phone")); err != nil || credential != nil {
+		t.Fatalf(`No credential should be returned for an unknown handle, got %v (%v)`, credential, err)
+	}
+}
+
+func TestWebAuthnCredentialUsed(t *testing.T) {
+	store := newTestStorage(t)
+	user := createTestUser(t, store, "alice")
+	credential := createTestWebAuthnCredential(t, store, user, "laptop")
+
+	credential.Credential.Authenticator.SignCount = 42
+	if err := store.WebAuthnCredentialUsed(credential); err != nil {
+		t.Fatal(err)
+	}
+
+	credential, _ = store.WebAuthnCredentialByHandle([]byte("laptop"))
+	if credential.Credential.Authenticator.SignCount != 42 || credential.LastSeenAt == nil {
+		t.Fatalf(`The signature counter and the last use should be saved, got %d`, credential.Credential.Authenticator.SignCount)
+	}
+}
+
+func TestDisableWebAuthnCredential(t *testing.T) {
+	store := newTestStorage(t)
+	user := createTestUser(t, store, "alice")
+	credential := createTestWebAuthnCredential(t, store, user, "laptop")
+	createTestWebAuthnCredential(t, store, user, "phone")
+
+	if err := store.DisableWebAuthnCredential(credential.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if credential, _ := store.WebAuthnCredentialByHandle([]byte("laptop")); !credential.Credential.Authenticator.CloneWarning {
+		t.Fatal(`The credential should be disabled`)
+	}
+
+	if credential, _ := store.WebAuthnCredentialByHandle([]byte("phone")); credential.Credential.Authenticator.CloneWarning {
+		t.Fatal(`The other credentials should not be disabled`)
+	}
+}
+
+func TestRemoveWebAuthnCredential(t *testing.T) {
+	store := newTestStorage(t)
+	user := createTestUser(t, store, "alice")
+	other := createTestUser(t, store, "bob")
+	credential := createTestWebAuthnCredential(t, store, user, "laptop")
+
+	if err := store.RemoveWebAuthnCredential(other.ID, credential.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if credentials, _ := store.WebAuthnCredentials(user.ID); len(credentials) != 1 {
+		t.Fatal(`The credential of a user should not be removed by another user`)
+	}
+
+	if err := store.RemoveWebAuthnCredential(user.ID, credential.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if credentials, _ := store.WebAuthnCredentials(user.ID); len(credentials) != 0 {
+		t.Fatalf(`The credential should be removed, got %d credentials`, len(credentials))
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"miniflux.app/model"
+)
+
+// WebSubSubscription returns the WebSub subscription of a feed.
+func (s *Storage) WebSubSubscription(feedID int64) (*model.WebSubSubscription, error) {
+	query := `
+		SELECT
+			w.feed_id, f.user_id, w.hub_url, w.topic_url, w.secret, w.callback_token, w.state, w.lease_expires_at, w.created_at
+		FROM
+			websub_subscriptions w
+		JOIN
+			feeds f ON f.id=w.feed_id
+		WHERE
+			w.feed_id=$1
+	`
+
+	var subscription model.WebSubSubscription
+	err := s.db.QueryRow(query, feedID).Scan(
+		&subscription.FeedID,
+		&subscription.UserID,
+		&subscription.HubURL,
+		&subscription.TopicURL,
+		&subscription.Secret,
+		&subscription.CallbackToken,
+		&subscription.State,
+		&subscription.LeaseExpiresAt,
+		&subscription.CreatedAt,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch WebSub subscription of feed #%d: %v`, feedID, err)
+	}
+
+	return &subscription, nil
+}
+
+// HasActiveWebSubSubscription returns true if the feed receives push notifications from a hub.
+func (s *Storage) HasActiveWebSubSubscription(feedID int64) bool {
+	var result bool
+	query := `SELECT true FROM websub_subscriptions WHERE feed_id=$1 AND state='subscribed' AND lease_expires_at > now()`
+	s.db.QueryRow(query, feedID).Scan(&result)
+	return result
+}
+
+// CreateWebSubSubscription creates or replaces the WebSub subscription of a feed.
+func (s *Storage) CreateWebSubSubscription(subscription *model.WebSubSubscription) error {
+	query := `
+		INSERT INTO websub_subscriptions
+			(feed_id, hub_url, topic_url, secret, callback_token, state)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (feed_id) DO UPDATE SET
+			hub_url=EXCLUDED.hub_url,
+			topic_url=EXCLUDED.topic_url,
+			secret=EXCLUDED.secret,
+			callback_token=EXCLUDED.callback_token,
+			state=EXCLUDED.state,
+			created_at=now()
+	`
+	_, err := s.db.Exec(
+		query,
+		subscription.FeedID,
+		subscription.HubURL,
+		subscription.TopicURL,
+		subscription.Secret,
+		subscription.CallbackToken,
+		subscription.State,
+	)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create WebSub subscription of feed #%d: %v`, subscription.FeedID, err)
+	}
+
+	return nil
+}
+
+// UpdateWebSubSubscriptionState updates the state and the lease of a WebSub subscription.
+func (s *Storage) UpdateWebSubSubscriptionState(feedID int64, state string, leaseExpiresAt *time.Time) error {
+	query := `UPDATE websub_subscriptions SET state=$1, lease_expires_at=$2 WHERE feed_id=$3`
+	if _, err := s.db.Exec(query, state, leaseExpiresAt, feedID); err != nil {
+		return fmt.Errorf(`store: unable to update WebSub subscription of feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// RemoveWebSubSubscription deletes the WebSub subscription of a feed.
+func (s *Storage) RemoveWebSubSubscription(feedID int64) error {
+	if _, err := s.db.Exec(`DELETE FROM websub_subscriptions WHERE feed_id=$1`, feedID); err != nil {
+		return fmt.Errorf(`store: unable to remove WebSub subscription of feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// WebSubSubscriptionsToRenew returns the subscriptions with a lease expiring before the given date.
+func (s *Storage) WebSubSubscriptionsToRenew(before time.Time) (model.WebSubSubscriptions, error) {
+	query := `
+		SELECT
+			w.feed_id, f.user_id, w.hub_url, w.topic_url, w.secret, w.callback_token, w.state, w.lease_expires_at, w.created_at
+		FROM
+			websub_subscriptions w
+		JOIN
+			feeds f ON f.id=w.feed_id
+		WHERE
+			w.state='subscribed' AND w.lease_expires_at < $1 AND f.disabled='f'
+	`
+	rows, err := s.db.Query(query, before)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch WebSub subscriptions to renew: %v`, err)
+	}
+	defer rows.Close()
+
+	subscriptions := make(model.WebSubSubscriptions, 0)
+	for rows.Next() {
+		var subscription model.WebSubSubscription
+		if err := rows.Scan(
+			&subscription.FeedID,
+			&subscription.UserID,
+			&subscription.HubURL,
+			&subscription.TopicURL,
+			&subscription.Secret,
+			&subscription.CallbackToken,
+			&subscription.State,
+			&subscription.LeaseExpiresAt,
+			&subscription.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch WebSub subscription row: %v`, err)
+		}
+
+		subscriptions = append(subscriptions, &subscription)
+	}
+
+	return subscriptions, nil
+}
@@ -32,6 +32,7 @@ type funcMap struct {
 func (f *funcMap) Map() template.FuncMap {
 	return template.FuncMap{
 		"formatFileSize": formatFileSize,
+		"formatDuration": formatDuration,
 		"dict":           dict,
 		"hasKey":         hasKey,
 		"truncate":       truncate,
@@ -48,6 +49,9 @@ func (f *funcMap) Map() template.FuncMap {
 		"hasAuthProxy": func() bool {
 			return config.Opts.AuthProxyHeader() != ""
 		},
+		"hasWebAuthn": func() bool {
+			return config.Opts.WebAuthn()
+		},
 		"route": func(name string, args ...interface{}) string {
 			return route.Path(f.router, name, args...)
 		},
@@ -72,6 +76,27 @@ func (f *funcMap) Map() template.FuncMap {
 
 			return link
 		},
+		"mediaCacheFilter": func(entryID int64, data string) string {
+			return proxy.MediaCacheRewriter(f.router, entryID, data)
+		},
+		"mediaCacheURL": func(entryID int64, link string) string {
+			return proxy.ProxifyCachedURL(f.router, entryID, link)
+		},
+		"hasMediaCacheEnclosures": func() bool {
+			return config.Opts.HasMediaCacheEnclosures()
+		},
+		"feedOutputURL": func(output *model.FeedOutput, format string) string {
+			var path string
+			switch output.Kind {
+			case model.FeedOutputCategory:
+				path = route.Path(f.router, "categoryFeedOutput", "categoryID", output.CategoryID, "format", format)
+			case model.FeedOutputSavedSearch:
+				path = route.Path(f.router, "savedSearchFeedOutput", "searchID", output.SavedSearchID, "format", format)
+			default:
+				path = route.Path(f.router, "starredFeedOutput", "format", format)
+			}
+			return config.Opts.RootURL() + path + "?token=" + output.Token
+		},
 		"domain": func(websiteURL string) string {
 			return url.Domain(websiteURL)
 		},
@@ -206,3 +231,16 @@ func formatFileSize(b int64) string {
 	return fmt.Sprintf("%.1f %ciB",
 		float64(b)/float64(div), "KMGTPE"[exp])
 }
+
+// formatDuration formats a number of seconds as "m:ss" or "h:mm:ss".
+func formatDuration(seconds int) string {
+	if seconds < 0 {
+		seconds = 0
+	}
+
+	hours, minutes := seconds/3600, seconds%3600/60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds%60)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, seconds%60)
+}
@@ -144,3 +144,23 @@ func TestFormatFileSize(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatDuration(t *testing.T) {
+	scenarios := []struct {
+		input    int
+		expected string
+	}{
+		{0, "0:00"},
+		{59, "0:59"},
+		{330, "5:30"},
+		{3723, "1:02:03"},
+		{-5, "0:00"},
+	}
+
+	for _, scenario := range scenarios {
+		result := formatDuration(scenario.input)
+		if result != scenario.expected {
+			t.Errorf(`Unexpected result, got %q instead of %q for %d`, result, scenario.expected, scenario.input)
+		}
+	}
+}
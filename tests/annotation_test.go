@@ -0,0 +1,91 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"testing"
+
+	miniflux "miniflux.app/client"
+)
+
+func TestAnnotationLifecycle(t *testing.T) {
+	client := createClient(t)
+	createFeed(t, client)
+
+	result, err := client.Entries(&miniflux.Filter{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entryID := result.Entries[0].ID
+	annotation, err := client.CreateAnnotation(entryID, &miniflux.AnnotationRequest{Quote: "quoted text", StartOffset: 4, EndOffset: 15, Note: "zanzibarnote"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if annotation.ID == 0 || annotation.EntryID != entryID || annotation.Quote != "quoted text" {
+		t.Errorf(`Unexpected annotation: %+v`, annotation)
+	}
+
+	entry, err := client.Entry(entryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entry.Annotations) != 1 || entry.Annotations[0].Note != "zanzibarnote" {
+		t.Errorf(`The entry should include its annotations: %+v`, entry.Annotations)
+	}
+
+	searchResult, err := client.Entries(&miniflux.Filter{Search: "zanzibarnote"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if searchResult.Total != 1 || searchResult.Entries[0].ID != entryID {
+		t.Errorf(`The annotated entry should be found by its note, got %d entries`, searchResult.Total)
+	}
+
+	updatedAnnotation, err := client.UpdateAnnotation(annotation.ID, &miniflux.AnnotationRequest{Note: "updated note"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedAnnotation.Note != "updated note" || updatedAnnotation.Quote != "" {
+		t.Errorf(`The annotation was not updated: %+v`, updatedAnnotation)
+	}
+
+	if err := client.DeleteAnnotation(annotation.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Annotation(annotation.ID); err != miniflux.ErrNotFound {
+		t.Errorf(`A removed annotation should return a not found error, got %v`, err)
+	}
+}
+
+func TestCreateEmptyAnnotation(t *testing.T) {
+	client := createClient(t)
+	createFeed(t, client)
+
+	result, err := client.Entries(&miniflux.Filter{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateAnnotation(result.Entries[0].ID, &miniflux.AnnotationRequest{}); err == nil {
+		t.Error(`An annotation without quote and note should be rejected`)
+	}
+}
+
+func TestCreateAnnotationForUnknownEntry(t *testing.T) {
+	client := createClient(t)
+
+	if _, err := client.CreateAnnotation(123456789, &miniflux.AnnotationRequest{Note: "note"}); err != miniflux.ErrNotFound {
+		t.Errorf(`Annotating an unknown entry should return a not found error, got %v`, err)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var csrfPattern = regexp.MustCompile(`name="csrf" value="([^"]+)"`)
+
+// browser navigates the user interface with its own cookies, the redirects are not followed.
+type browser struct {
+	t      *testing.T
+	client *http.Client
+	csrf   string
+}
+
+func newBrowser(t *testing.T) *browser {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &browser{
+		t: t,
+		client: &http.Client{
+			Jar: jar,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// get returns the response and the body of a page, the CSRF token of the page is kept for the next form.
+func (b *browser) get(path string) (*http.Response, string) {
+	response, err := b.client.Get(testBaseURL + strings.TrimPrefix(path, "/"))
+	if err != nil {
+		b.t.Fatal(err)
+	}
+
+	return b.read(response)
+}
+
+// post submits a form with the last CSRF token received.
+func (b *browser) post(path string, values url.Values) (*http.Response, string) {
+	if b.csrf == "" {
+		b.get("/")
+	}
+
+	values.Set("csrf", b.csrf)
+	response, err := b.client.PostForm(testBaseURL+strings.TrimPrefix(path, "/"), values)
+	if err != nil {
+		b.t.Fatal(err)
+	}
+
+	return b.read(response)
+}
+
+// login submits the login form and returns the redirection.
+func (b *browser) login(username, password string) string {
+	b.get("/")
+	response, _ := b.post("/login", url.Values{"username": {username}, "password": {password}})
+	return response.Header.Get("Location")
+}
+
+func (b *browser) read(response *http.Response) (*http.Response, string) {
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		b.t.Fatal(err)
+	}
+
+	if matches := csrfPattern.FindStringSubmatch(string(body)); matches != nil {
+		b.csrf = matches[1]
+	}
+
+	return response, string(body)
+}
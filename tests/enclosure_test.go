@@ -0,0 +1,69 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"testing"
+
+	miniflux "miniflux.app/client"
+)
+
+func TestGetUnknownEnclosure(t *testing.T) {
+	client := createClient(t)
+
+	if _, err := client.Enclosure(123456789); err != miniflux.ErrNotFound {
+		t.Fatalf(`Fetching an unknown enclosure should return a not found error, got %v`, err)
+	}
+}
+
+func TestUpdateEnclosurePlayback(t *testing.T) {
+	client := createClient(t)
+	createFeed(t, client)
+
+	result, err := client.Entries(&miniflux.Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var enclosure *miniflux.Enclosure
+	for _, entry := range result.Entries {
+		if len(entry.Enclosures) > 0 {
+			enclosure = entry.Enclosures[0]
+			break
+		}
+	}
+
+	if enclosure == nil {
+		t.Skip(`The test feed doesn't have any enclosure`)
+	}
+
+	progression := 42
+	played := true
+	updatedEnclosure, err := client.UpdateEnclosure(enclosure.ID, &miniflux.EnclosureUpdateRequest{MediaProgression: &progression, Played: &played})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedEnclosure.MediaProgression != 42 || !updatedEnclosure.Played {
+		t.Errorf(`The playback state was not updated: %+v`, updatedEnclosure)
+	}
+
+	enclosure, err = client.Enclosure(enclosure.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if enclosure.MediaProgression != 42 || !enclosure.Played {
+		t.Errorf(`The playback state was not saved: %+v`, enclosure)
+	}
+
+	negativeProgression := -1
+	if _, err := client.UpdateEnclosure(enclosure.ID, &miniflux.EnclosureUpdateRequest{MediaProgression: &negativeProgression}); err == nil {
+		t.Error(`A negative media progression should be rejected`)
+	}
+}
@@ -8,6 +8,7 @@
 package tests
 
 import (
+	"fmt"
 	"testing"
 
 	miniflux "miniflux.app/client"
@@ -287,6 +288,33 @@ func TestSearchEntries(t *testing.T) {
 	}
 }
 
+func TestSearchEntriesWithOperators(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	results, err := client.Entries(&miniflux.Filter{Search: fmt.Sprintf(`2.0.8 feed:%d is:unread after:2000-01-01`, feed.ID)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results.Total != 1 {
+		t.Fatalf(`We should have only one entry instead of %d`, results.Total)
+	}
+
+	results, err = client.Entries(&miniflux.Filter{Search: `2.0.8 -is:unread`})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results.Total != 0 {
+		t.Fatalf(`We should not have any entry instead of %d`, results.Total)
+	}
+
+	if _, err := client.Entries(&miniflux.Filter{Search: `before:yesterday`}); err == nil {
+		t.Fatal(`Using an invalid search query should raise an error`)
+	}
+}
+
 func TestInvalidFilters(t *testing.T) {
 	client := createClient(t)
 	createFeed(t, client)
@@ -426,6 +454,73 @@ func TestToggleBookmark(t *testing.T) {
 	}
 }
 
+func TestUpdateEntryTags(t *testing.T) {
+	client := createClient(t)
+	createFeed(t, client)
+
+	result, err := client.Entries(&miniflux.Filter{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Entries[0].Tags) != 0 {
+		t.Fatal("The entry should not have any tag")
+	}
+
+	tags, err := client.UpdateEntryTags(result.Entries[0].ID, []string{"golang", " golang ", "to read"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tags) != 2 || tags[0] != "golang" || tags[1] != "to read" {
+		t.Fatalf(`Invalid tags: %v`, tags)
+	}
+
+	entry, err := client.Entry(result.Entries[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entry.Tags) != 2 {
+		t.Fatalf(`Invalid tags: %v`, entry.Tags)
+	}
+
+	filteredResults, err := client.Entries(&miniflux.Filter{Tags: []string{"golang", "to read"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if filteredResults.Total != 1 || filteredResults.Entries[0].ID != entry.ID {
+		t.Fatal(`Only the tagged entry should be returned`)
+	}
+
+	userTags, err := client.Tags()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(userTags) != 2 || userTags[0].EntryCount != 1 {
+		t.Fatalf(`Invalid tags: %v`, userTags)
+	}
+
+	if _, err := client.UpdateEntryTags(entry.ID, []string{}); err != nil {
+		t.Fatal(err)
+	}
+
+	userTags, err = client.Tags()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(userTags) != 0 {
+		t.Fatal(`Unused tags should be removed`)
+	}
+
+	if _, err := client.UpdateEntryTags(entry.ID, []string{""}); err == nil {
+		t.Fatal(`Empty tags should be rejected`)
+	}
+}
+
 func TestHistoryOrder(t *testing.T) {
 	client := createClient(t)
 	createFeed(t, client)
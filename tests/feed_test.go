@@ -172,6 +172,60 @@ func TestCreateFeedWithCrawlerEnabled(t *testing.T) {
 	}
 }
 
+func TestCreateScrapedFeed(t *testing.T) {
+	client := createClient(t)
+
+	categories, err := client.Categories()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feedID, err := client.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL:      testWebsiteURL,
+		CategoryID:   categories[0].ID,
+		ItemSelector: "a[href]",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feed, err := client.Feed(feedID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.ItemSelector != "a[href]" {
+		t.Errorf(`Unexpected item selector, got %q`, feed.ItemSelector)
+	}
+
+	entries, err := client.FeedEntries(feedID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entries.Total == 0 {
+		t.Error(`The links of the page should be converted into entries`)
+	}
+}
+
+func TestCreateFeedWithInvalidSelector(t *testing.T) {
+	client := createClient(t)
+
+	categories, err := client.Categories()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL:      testWebsiteURL,
+		CategoryID:   categories[0].ID,
+		ItemSelector: "div[",
+	})
+	if err == nil {
+		t.Fatal(`Feeds with invalid selectors should not be created`)
+	}
+}
+
 func TestCreateFeedWithSelfSignedCertificatesAllowed(t *testing.T) {
 	client := createClient(t)
 
@@ -432,6 +486,56 @@ func TestUpdateFeedAllowSelfSignedCertificates(t *testing.T) {
 	}
 }
 
+func TestUpdateFeedCacheMedia(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	cacheMedia := true
+	updatedFeed, err := client.UpdateFeed(feed.ID, &miniflux.FeedModificationRequest{CacheMedia: &cacheMedia})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedFeed.CacheMedia != cacheMedia {
+		t.Fatalf(`Wrong CacheMedia value, got "%v" instead of "%v"`, updatedFeed.CacheMedia, cacheMedia)
+	}
+
+	cacheMedia = false
+	updatedFeed, err = client.UpdateFeed(feed.ID, &miniflux.FeedModificationRequest{CacheMedia: &cacheMedia})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedFeed.CacheMedia != cacheMedia {
+		t.Fatalf(`Wrong CacheMedia value, got "%v" instead of "%v"`, updatedFeed.CacheMedia, cacheMedia)
+	}
+}
+
+func TestUpdateFeedNotify(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	notify := true
+	updatedFeed, err := client.UpdateFeed(feed.ID, &miniflux.FeedModificationRequest{Notify: &notify})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedFeed.Notify != notify {
+		t.Fatalf(`Wrong Notify value, got "%v" instead of "%v"`, updatedFeed.Notify, notify)
+	}
+
+	notify = false
+	updatedFeed, err = client.UpdateFeed(feed.ID, &miniflux.FeedModificationRequest{Notify: &notify})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedFeed.Notify != notify {
+		t.Fatalf(`Wrong Notify value, got "%v" instead of "%v"`, updatedFeed.Notify, notify)
+	}
+}
+
 func TestUpdateFeedScraperRules(t *testing.T) {
 	client := createClient(t)
 	feed, _ := createFeed(t, client)
@@ -725,6 +829,42 @@ func TestRefreshFeed(t *testing.T) {
 	}
 }
 
+func TestGetFeedHistory(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+	if err := client.RefreshFeed(feed.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	refreshes, err := client.FeedHistory(feed.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(refreshes) != 1 {
+		t.Fatalf(`Invalid number of refreshes, got %d instead of 1`, len(refreshes))
+	}
+
+	if refreshes[0].FeedID != feed.ID {
+		t.Fatalf(`Invalid feed ID, got %d instead of %d`, refreshes[0].FeedID, feed.ID)
+	}
+
+	if refreshes[0].StatusCode != 200 && refreshes[0].StatusCode != 304 {
+		t.Fatalf(`Invalid status code, got %d`, refreshes[0].StatusCode)
+	}
+
+	if refreshes[0].ErrorMsg != "" {
+		t.Fatalf(`The refresh should not have any error, got %q`, refreshes[0].ErrorMsg)
+	}
+}
+
+func TestGetFeedHistoryNotFound(t *testing.T) {
+	client := createClient(t)
+	if _, err := client.FeedHistory(42); err == nil {
+		t.Fatalf(`The feed history should not be found`)
+	}
+}
+
 func TestGetFeed(t *testing.T) {
 	client := createClient(t)
 	feed, category := createFeed(t, client)
@@ -12,6 +12,8 @@ import (
 	"io"
 	"strings"
 	"testing"
+
+	miniflux "miniflux.app/client"
 )
 
 func TestExport(t *testing.T) {
@@ -45,3 +47,167 @@ func TestImport(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestExportAndImportUser(t *testing.T) {
+	client := createClient(t)
+	adminClient := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
+
+	user, err := client.Me()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	theme := "dark_serif"
+	if _, err := client.UpdateUser(user.ID, &miniflux.UserModificationRequest{Theme: &theme}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateCategory("Exported Category"); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := adminClient.ExportUser(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherUser, err := adminClient.CreateUser(getRandomUsername(), testStandardPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherClient := miniflux.New(testBaseURL, otherUser.Username, testStandardPassword)
+
+	// Importing the same archive twice must not create duplicates.
+	for i := 0; i < 2; i++ {
+		if err := adminClient.ImportUser(otherUser.ID, io.NopCloser(bytes.NewReader(output))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	importedUser, err := otherClient.Me()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if importedUser.Theme != theme {
+		t.Fatalf(`Invalid theme, got %q instead of %q`, importedUser.Theme, theme)
+	}
+
+	categories, err := otherClient.Categories()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(categories) != 2 {
+		t.Fatalf(`Invalid number of categories, got %d instead of %d`, len(categories), 2)
+	}
+}
+
+func TestExportUserAsStandardUser(t *testing.T) {
+	client := createClient(t)
+
+	user, err := client.Me()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.ExportUser(user.ID); err == nil {
+		t.Fatal(`Standard users should not be able to export accounts`)
+	}
+}
+
+func TestImportUserWithInvalidArchive(t *testing.T) {
+	client := createClient(t)
+	adminClient := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
+
+	user, err := client.Me()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = adminClient.ImportUser(user.ID, io.NopCloser(strings.NewReader(`{"version": 42}`)))
+	if err == nil {
+		t.Fatal(`Importing an invalid archive should raise an error`)
+	}
+}
+
+func TestExportAndImportUserWithAnnotationsAndSavedSearches(t *testing.T) {
+	client := createClient(t)
+	adminClient := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
+	feed, _ := createFeed(t, client)
+
+	user, err := client.Me()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := client.FeedEntries(feed.ID, &miniflux.Filter{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Total == 0 {
+		t.Fatal(`The feed should have entries`)
+	}
+
+	entry := result.Entries[0]
+	if _, err := client.CreateAnnotation(entry.ID, &miniflux.AnnotationRequest{Note: "Exported note"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{Title: "Exported search", Query: "miniflux", FeedID: feed.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := adminClient.ExportUser(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherUser, err := adminClient.CreateUser(getRandomUsername(), testStandardPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherClient := miniflux.New(testBaseURL, otherUser.Username, testStandardPassword)
+
+	for i := 0; i < 2; i++ {
+		if err := adminClient.ImportUser(otherUser.ID, io.NopCloser(bytes.NewReader(output))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	searches, err := otherClient.SavedSearches()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(searches) != 1 || searches[0].Title != "Exported search" || searches[0].FeedID == 0 {
+		t.Fatalf(`Invalid saved searches, got %+v`, searches)
+	}
+
+	importedEntries, err := otherClient.FeedEntries(searches[0].FeedID, &miniflux.Filter{Limit: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, importedEntry := range importedEntries.Entries {
+		if importedEntry.Hash != entry.Hash {
+			continue
+		}
+
+		annotations, err := otherClient.EntryAnnotations(importedEntry.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(annotations) != 1 || annotations[0].Note != "Exported note" {
+			t.Fatalf(`Invalid annotations, got %+v`, annotations)
+		}
+
+		return
+	}
+
+	t.Fatal(`The annotated entry has not been imported`)
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"testing"
+
+	miniflux "miniflux.app/client"
+)
+
+func TestCreateSavedSearch(t *testing.T) {
+	client := createClient(t)
+	feed, category := createFeed(t, client)
+
+	search, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{
+		Title:      "Releases",
+		Query:      "2.0.8",
+		CategoryID: category.ID,
+		FeedID:     feed.ID,
+		Status:     "unread",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if search.ID == 0 {
+		t.Fatalf(`Invalid saved search ID, got "%v"`, search.ID)
+	}
+
+	if search.Title != "Releases" || search.Query != "2.0.8" {
+		t.Fatalf(`Invalid saved search, got %+v`, search)
+	}
+
+	if search.CategoryID != category.ID || search.FeedID != feed.ID || search.Status != "unread" {
+		t.Fatalf(`Invalid saved search restrictions, got %+v`, search)
+	}
+}
+
+func TestCreateSavedSearchWithoutQuery(t *testing.T) {
+	client := createClient(t)
+	if _, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{Title: "Releases"}); err == nil {
+		t.Fatal(`The search query should be mandatory`)
+	}
+}
+
+func TestCreateSavedSearchWithInvalidStatus(t *testing.T) {
+	client := createClient(t)
+	if _, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{Title: "Releases", Query: "miniflux", Status: "removed"}); err == nil {
+		t.Fatal(`Only the unread and read statuses should be allowed`)
+	}
+}
+
+func TestCannotCreateDuplicatedSavedSearch(t *testing.T) {
+	client := createClient(t)
+	if _, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{Title: "Releases", Query: "miniflux"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{Title: "Releases", Query: "other"}); err == nil {
+		t.Fatal(`Duplicated saved searches should not be allowed`)
+	}
+}
+
+func TestUpdateSavedSearch(t *testing.T) {
+	client := createClient(t)
+	search, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{Title: "Releases", Query: "miniflux"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	search, err = client.UpdateSavedSearch(search.ID, &miniflux.SavedSearchRequest{Title: "Updated", Query: "2.0.8", Status: "read"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if search.Title != "Updated" || search.Query != "2.0.8" || search.Status != "read" {
+		t.Fatalf(`Invalid saved search, got %+v`, search)
+	}
+}
+
+func TestSavedSearchEntriesAndUnreadCount(t *testing.T) {
+	client := createClient(t)
+	createFeed(t, client)
+
+	search, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{Title: "Releases", Query: "2.0.8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := client.SavedSearchEntries(search.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results.Total != 1 {
+		t.Fatalf(`We should have only one entry instead of %d`, results.Total)
+	}
+
+	searches, err := client.SavedSearches()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(searches) != 1 || searches[0].TotalUnread != 1 {
+		t.Fatalf(`Invalid saved searches, got %+v`, searches)
+	}
+
+	if err := client.MarkSavedSearchAsRead(search.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	search, err = client.SavedSearch(search.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if search.TotalUnread != 0 {
+		t.Fatalf(`All entries should be read, got %d unread entries`, search.TotalUnread)
+	}
+}
+
+func TestDeleteSavedSearch(t *testing.T) {
+	client := createClient(t)
+	search, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{Title: "Releases", Query: "miniflux"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.DeleteSavedSearch(search.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.SavedSearch(search.ID); err == nil {
+		t.Fatal(`The saved search should be removed`)
+	}
+}
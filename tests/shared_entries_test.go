@@ -0,0 +1,127 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	miniflux "miniflux.app/client"
+)
+
+const testShareComment = `<script>alert("comment")</script> & more`
+
+// createSharedEntry returns a browser logged in as a new user who shared an entry with a comment and published the shared entries page.
+func createSharedEntry(t *testing.T) (*browser, string, string) {
+	username := getRandomUsername()
+	client := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
+	if _, err := client.CreateUser(username, testStandardPassword, false); err != nil {
+		t.Fatal(err)
+	}
+
+	client = miniflux.New(testBaseURL, username, testStandardPassword)
+	feed, _ := createFeed(t, client)
+	result, err := client.FeedEntries(feed.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Entries) == 0 {
+		t.Fatal(`The feed should have entries`)
+	}
+	entryID := result.Entries[0].ID
+
+	b := newBrowser(t)
+	b.login(username, testStandardPassword)
+
+	response, _ := b.get(fmt.Sprintf("/entry/share/%d", entryID))
+	sharePath := response.Header.Get("Location")
+	if !strings.Contains(sharePath, "/share/") {
+		t.Fatalf(`Sharing the entry should redirect to the shared entry, got %q`, sharePath)
+	}
+
+	b.get(fmt.Sprintf("/entry/share/%d/comment", entryID))
+	b.post(fmt.Sprintf("/entry/share/%d/comment", entryID), url.Values{"comment": {testShareComment}})
+
+	b.get("/shares")
+	b.post("/shares/public/enable", url.Values{})
+
+	_, body := b.get("/shares")
+	matches := regexp.MustCompile(`/shares/([a-f0-9]+)"`).FindStringSubmatch(body)
+	if matches == nil {
+		t.Fatal(`The link to the public page should be displayed`)
+	}
+
+	return b, sharePath, "/shares/" + matches[1]
+}
+
+func TestSharedEntryCommentIsEscaped(t *testing.T) {
+	_, sharePath, publicPath := createSharedEntry(t)
+	anonymous := newBrowser(t)
+
+	for _, path := range []string{sharePath, publicPath} {
+		response, body := anonymous.get(path)
+		if response.StatusCode != http.StatusOK {
+			t.Fatalf(`The page %q should be public, got %d`, path, response.StatusCode)
+		}
+
+		if strings.Contains(body, `<script>alert`) {
+			t.Fatalf(`The comment should be escaped on the page %q`, path)
+		}
+
+		if !strings.Contains(body, `&lt;script&gt;`) {
+			t.Fatalf(`The comment should be displayed on the page %q`, path)
+		}
+	}
+
+	_, body := anonymous.get(sharePath)
+	if !regexp.MustCompile(`<meta property="og:description" content="&lt;script&gt;[^"]+">`).MatchString(body) {
+		t.Fatal(`The comment should be the escaped OpenGraph description`)
+	}
+
+	response, body := anonymous.get(publicPath + ".atom")
+	if response.StatusCode != http.StatusOK || !strings.HasPrefix(response.Header.Get("Content-Type"), "application/atom+xml") {
+		t.Fatalf(`The Atom feed should be public, got %d %q`, response.StatusCode, response.Header.Get("Content-Type"))
+	}
+
+	if strings.Contains(body, `<script>`) || strings.Contains(body, `&lt;script&gt;alert`) {
+		t.Fatal(`The comment should be escaped in the content of the Atom feed`)
+	}
+
+	if !strings.Contains(body, `&lt;blockquote&gt;&amp;lt;script&amp;gt;`) {
+		t.Fatal(`The comment should be prepended to the content of the Atom feed`)
+	}
+}
+
+func TestDisabledSharedEntriesPage(t *testing.T) {
+	b, _, publicPath := createSharedEntry(t)
+
+	b.post("/shares/public/disable", url.Values{})
+
+	anonymous := newBrowser(t)
+	for _, path := range []string{publicPath, publicPath + ".atom"} {
+		if response, _ := anonymous.get(path); response.StatusCode != http.StatusNotFound {
+			t.Fatalf(`The page %q should not be found once disabled, got %d`, path, response.StatusCode)
+		}
+	}
+
+	b.post("/shares/public/enable", url.Values{})
+	if response, _ := anonymous.get(publicPath); response.StatusCode != http.StatusNotFound {
+		t.Fatalf(`The previous address should not be published again, got %d`, response.StatusCode)
+	}
+}
+
+func TestUnknownSharedEntriesPage(t *testing.T) {
+	if response, _ := newBrowser(t).get("/shares/0123456789abcdef"); response.StatusCode != http.StatusNotFound {
+		t.Fatalf(`An unknown code should not be found, got %d`, response.StatusCode)
+	}
+}
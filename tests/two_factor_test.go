@@ -0,0 +1,126 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+
+	miniflux "miniflux.app/client"
+)
+
+// createTwoFactorUser returns a new user with two-factor authentication enabled, its secret and its recovery codes.
+func createTwoFactorUser(t *testing.T) (string, string, []string) {
+	username := getRandomUsername()
+	client := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
+	if _, err := client.CreateUser(username, testStandardPassword, false); err != nil {
+		t.Fatal(err)
+	}
+
+	b := newBrowser(t)
+	if location := b.login(username, testStandardPassword); strings.Contains(location, "login") {
+		t.Fatalf(`The user should be logged in, got redirected to %q`, location)
+	}
+
+	_, body := b.get("/settings/two-factor")
+	matches := regexp.MustCompile(`<code>([A-Z2-7]+)</code>`).FindStringSubmatch(body)
+	if matches == nil {
+		t.Fatal(`The secret should be displayed during the enrollment`)
+	}
+	secret := matches[1]
+
+	code, err := totp.GenerateCode(secret, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, body = b.post("/settings/two-factor/enable", url.Values{"code": {code}})
+	recoveryCodes := regexp.MustCompile(`[0-9a-f]{5}-[0-9a-f]{5}`).FindAllString(body, -1)
+	if len(recoveryCodes) == 0 {
+		t.Fatal(`The recovery codes should be displayed after the enrollment`)
+	}
+
+	return username, secret, recoveryCodes
+}
+
+func TestTwoFactorLoginRequiresCode(t *testing.T) {
+	username, secret, _ := createTwoFactorUser(t)
+
+	b := newBrowser(t)
+	if location := b.login(username, testStandardPassword); !strings.HasSuffix(location, "/login/two-factor") {
+		t.Fatalf(`The one-time code should be requested, got redirected to %q`, location)
+	}
+
+	if response, _ := b.get("/unread"); response.StatusCode != http.StatusFound {
+		t.Fatalf(`The user should not be logged in before entering the code, got %d`, response.StatusCode)
+	}
+
+	code, _ := totp.GenerateCode(secret, time.Now().Add(30*time.Second))
+	if response, _ := b.post("/login/two-factor", url.Values{"code": {code}}); response.StatusCode != http.StatusFound {
+		t.Fatalf(`A valid code should complete the login, got %d`, response.StatusCode)
+	}
+
+	if response, _ := b.get("/unread"); response.StatusCode != http.StatusOK {
+		t.Fatalf(`The user should be logged in, got %d`, response.StatusCode)
+	}
+
+	b = newBrowser(t)
+	b.login(username, testStandardPassword)
+	if response, _ := b.post("/login/two-factor", url.Values{"code": {code}}); response.StatusCode != http.StatusOK {
+		t.Fatalf(`A code already used should be rejected, got %d`, response.StatusCode)
+	}
+}
+
+func TestTwoFactorRecoveryCodeIsUsedOnce(t *testing.T) {
+	username, _, recoveryCodes := createTwoFactorUser(t)
+
+	b := newBrowser(t)
+	b.login(username, testStandardPassword)
+	if response, _ := b.post("/login/two-factor", url.Values{"code": {strings.ToUpper(recoveryCodes[0])}}); response.StatusCode != http.StatusFound {
+		t.Fatalf(`A recovery code should complete the login, got %d`, response.StatusCode)
+	}
+
+	b = newBrowser(t)
+	b.login(username, testStandardPassword)
+	if response, _ := b.post("/login/two-factor", url.Values{"code": {recoveryCodes[0]}}); response.StatusCode != http.StatusOK {
+		t.Fatalf(`A recovery code should be accepted only once, got %d`, response.StatusCode)
+	}
+}
+
+func TestTwoFactorLockout(t *testing.T) {
+	username, secret, _ := createTwoFactorUser(t)
+
+	// Entering the password again must not give more attempts.
+	for i := 0; i < 5; i++ {
+		b := newBrowser(t)
+		b.login(username, testStandardPassword)
+		b.post("/login/two-factor", url.Values{"code": {"000000"}})
+	}
+
+	b := newBrowser(t)
+	b.login(username, testStandardPassword)
+	code, _ := totp.GenerateCode(secret, time.Now().Add(30*time.Second))
+	if response, body := b.post("/login/two-factor", url.Values{"code": {code}}); response.StatusCode != http.StatusOK || !strings.Contains(body, "Too many invalid authentication codes") {
+		t.Fatalf(`A valid code should be rejected while the user is locked out, got %d`, response.StatusCode)
+	}
+}
+
+func TestBasicAuthIsRejectedWithTwoFactor(t *testing.T) {
+	username, _, _ := createTwoFactorUser(t)
+
+	client := miniflux.New(testBaseURL, username, testStandardPassword)
+	if _, err := client.Me(); err != miniflux.ErrNotAuthorized {
+		t.Fatalf(`The password alone should not give access to the API, got %v`, err)
+	}
+}
@@ -386,6 +386,40 @@ func TestUpdateUserThemeWithInvalidValue(t *testing.T) {
 	}
 }
 
+func TestUpdateUserFilterRules(t *testing.T) {
+	username := getRandomUsername()
+	client := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
+	user, err := client.CreateUser(username, testStandardPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filterRules := "block|EntryTitle=(?i)sponsored\ntag:Go|EntryContent=golang"
+	user, err = client.UpdateUser(user.ID, &miniflux.UserModificationRequest{FilterRules: &filterRules})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.FilterRules != filterRules {
+		t.Fatalf(`Unable to update user filter rules: got %q instead of %q`, user.FilterRules, filterRules)
+	}
+}
+
+func TestUpdateUserFilterRulesWithInvalidValue(t *testing.T) {
+	username := getRandomUsername()
+	client := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
+	user, err := client.CreateUser(username, testStandardPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filterRules := "drop|EntryTitle=test"
+	_, err = client.UpdateUser(user.ID, &miniflux.UserModificationRequest{FilterRules: &filterRules})
+	if err == nil {
+		t.Fatal(`Updating user filter rules with an invalid value should raise an error`)
+	}
+}
+
 func TestUpdateUserLanguageWithInvalidValue(t *testing.T) {
 	username := getRandomUsername()
 	client := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
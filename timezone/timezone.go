@@ -5,6 +5,10 @@
 package timezone // import "miniflux.app/timezone"
 
 import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -45,3 +49,39 @@ func getLocation(tz string) *time.Location {
 	}
 	return loc
 }
+
+// Available returns the timezones found in the system time zone database.
+// It is used when the database engine does not provide the list of timezones.
+func Available() []string {
+	timezones := []string{"UTC"}
+
+	root := os.Getenv("ZONEINFO")
+	if root == "" {
+		root = "/usr/share/zoneinfo"
+	}
+
+	filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		name := filepath.ToSlash(strings.TrimPrefix(path, root+string(filepath.Separator)))
+		if entry.IsDir() {
+			if name == "posix" || name == "right" || name == "Etc" || name == "SystemV" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if name == "UTC" || name[0] < 'A' || name[0] > 'Z' || strings.Contains(name, ".") {
+			return nil
+		}
+
+		if _, err := time.LoadLocation(name); err == nil {
+			timezones = append(timezones, name)
+		}
+		return nil
+	})
+
+	return timezones
+}
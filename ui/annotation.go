@@ -0,0 +1,82 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/validator"
+)
+
+func (h *handler) createAnnotation(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithEntryID(request.RouteInt64Param(r, "entryID"))
+	builder.WithoutStatus(model.EntryStatusRemoved)
+
+	entry, err := builder.GetEntry()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	annotationRequest := form.NewAnnotationForm(r).Request()
+	if validationErr := validator.ValidateAnnotationRequest(annotationRequest); validationErr != nil {
+		html.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	if _, err := h.store.CreateAnnotation(userID, entry.ID, annotationRequest); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "feedEntry", "feedID", entry.FeedID, "entryID", entry.ID)+"#annotations")
+}
+
+func (h *handler) removeAnnotation(w http.ResponseWriter, r *http.Request) {
+	annotation, err := h.store.Annotation(request.UserID(r), request.RouteInt64Param(r, "annotationID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if annotation == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.RemoveAnnotation(annotation); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	builder := h.store.NewEntryQueryBuilder(annotation.UserID)
+	builder.WithEntryID(annotation.EntryID)
+
+	entry, err := builder.GetEntry()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "feedEntry", "feedID", entry.FeedID, "entryID", entry.ID)+"#annotations")
+}
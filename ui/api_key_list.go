@@ -34,6 +34,7 @@ func (h *handler) showAPIKeysPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	view.Set("menuSavedSearches", h.store.MenuSavedSearches(user.ID))
 
 	html.OK(w, r, view.Render("api_keys"))
 }
@@ -33,6 +33,7 @@ func (h *handler) saveAPIKey(w http.ResponseWriter, r *http.Request) {
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	view.Set("menuSavedSearches", h.store.MenuSavedSearches(user.ID))
 
 	if err := apiKeyForm.Validate(); err != nil {
 		view.Set("errorMessage", err.Error())
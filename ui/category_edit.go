@@ -43,6 +43,12 @@ func (h *handler) showEditCategoryPage(w http.ResponseWriter, r *http.Request) {
 	if category.HideGlobally {
 		categoryForm.HideGlobally = "checked"
 	}
+	if category.Notify {
+		categoryForm.Notify = "checked"
+	}
+	if category.ExcludeFromDigest {
+		categoryForm.ExcludeFromDigest = "checked"
+	}
 
 	view.Set("form", categoryForm)
 	view.Set("category", category)
@@ -50,6 +56,7 @@ func (h *handler) showEditCategoryPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	view.Set("menuSavedSearches", h.store.MenuSavedSearches(user.ID))
 
 	html.OK(w, r, view.Render("edit_category"))
 }
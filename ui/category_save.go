@@ -34,6 +34,7 @@ func (h *handler) saveCategory(w http.ResponseWriter, r *http.Request) {
 	view.Set("user", loggedUser)
 	view.Set("countUnread", h.store.CountUnreadEntries(loggedUser.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(loggedUser.ID))
+	view.Set("menuSavedSearches", h.store.MenuSavedSearches(loggedUser.ID))
 
 	categoryRequest := &model.CategoryRequest{Title: categoryForm.Title}
 
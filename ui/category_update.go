@@ -47,10 +47,13 @@ func (h *handler) updateCategory(w http.ResponseWriter, r *http.Request) {
 	view.Set("user", loggedUser)
 	view.Set("countUnread", h.store.CountUnreadEntries(loggedUser.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(loggedUser.ID))
+	view.Set("menuSavedSearches", h.store.MenuSavedSearches(loggedUser.ID))
 
 	categoryRequest := &model.CategoryRequest{
-		Title:        categoryForm.Title,
-		HideGlobally: categoryForm.HideGlobally,
+		Title:             categoryForm.Title,
+		HideGlobally:      categoryForm.HideGlobally,
+		Notify:            categoryForm.Notify,
+		ExcludeFromDigest: categoryForm.ExcludeFromDigest,
 	}
 
 	if validationErr := validator.ValidateCategoryModification(h.store, loggedUser.ID, category.ID, categoryRequest); validationErr != nil {
@@ -0,0 +1,70 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+	"miniflux.app/validator"
+)
+
+func (h *handler) updateEntryTags(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	entryID := request.RouteInt64Param(r, "entryID")
+	builder := h.store.NewEntryQueryBuilder(user.ID)
+	builder.WithEntryID(entryID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+
+	entry, err := builder.GetEntry()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	entryTagsForm := form.NewEntryTagsForm(r)
+	entryTagsRequest := &model.EntryTagsRequest{Tags: entryTagsForm.TagList()}
+
+	if err := validator.ValidateEntryTagsRequest(entryTagsRequest); err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	if err := h.store.SetEntryTags(user.ID, entry.ID, entryTagsRequest.Tags); err != nil {
+		logger.Error("[UI:UpdateEntryTags] %v", err)
+
+		sess := session.New(h.store, request.SessionID(r))
+		view := view.New(h.tpl, r, sess)
+		view.Set("form", entryTagsForm)
+		view.Set("entry", entry)
+		view.Set("menu", "tags")
+		view.Set("user", user)
+		view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+		view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+		view.Set("menuSavedSearches", h.store.MenuSavedSearches(user.ID))
+		view.Set("errorMessage", "error.unable_to_update_entry_tags")
+		html.OK(w, r, view.Render("edit_entry_tags"))
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "feedEntry", "feedID", entry.FeedID, "entryID", entry.ID))
+}
@@ -15,6 +15,9 @@ import (
 	"miniflux.app/ui/view"
 )
 
+// feedHistoryLimit is the number of refreshes displayed on the feed edit page.
+const feedHistoryLimit = 20
+
 func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 	user, err := h.store.UserByID(request.UserID(r))
 	if err != nil {
@@ -40,6 +43,12 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	refreshes, err := h.store.FeedRefreshes(feed.ID, feedHistoryLimit)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	feedForm := form.FeedForm{
 		SiteURL:                     feed.SiteURL,
 		FeedURL:                     feed.FeedURL,
@@ -60,6 +69,13 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		FetchViaProxy:               feed.FetchViaProxy,
 		Disabled:                    feed.Disabled,
 		HideGlobally:                feed.HideGlobally,
+		CacheMedia:                  feed.CacheMedia,
+		Notify:                      feed.Notify,
+		ItemSelector:                feed.ItemSelector,
+		TitleSelector:               feed.TitleSelector,
+		LinkSelector:                feed.LinkSelector,
+		DateSelector:                feed.DateSelector,
+		ContentSelector:             feed.ContentSelector,
 		CategoryHidden:              feed.Category.HideGlobally,
 	}
 
@@ -68,10 +84,12 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("form", feedForm)
 	view.Set("categories", categories)
 	view.Set("feed", feed)
+	view.Set("refreshes", refreshes)
 	view.Set("menu", "feeds")
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	view.Set("menuSavedSearches", h.store.MenuSavedSearches(user.ID))
 	view.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
 	view.Set("hasProxyConfigured", config.Opts.HasHTTPClientProxyConfigured())
 
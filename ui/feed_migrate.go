@@ -0,0 +1,74 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+	"strings"
+
+	"miniflux.app/errors"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/locale"
+	"miniflux.app/logger"
+	feedHandler "miniflux.app/reader/handler"
+	"miniflux.app/ui/session"
+	"miniflux.app/validator"
+)
+
+func (h *handler) migrateFeed(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	feed, err := h.store.FeedByID(user.ID, request.RouteInt64Param(r, "feedID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if feed == nil || feed.IsNewsletter() {
+		html.NotFound(w, r)
+		return
+	}
+
+	// Without any URL in the form, the feed follows its permanent redirect.
+	feedURL := strings.TrimSpace(r.FormValue("url"))
+	if feedURL == "" {
+		feedURL = feed.PermanentRedirectURL
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	printer := locale.NewPrinter(user.Language)
+
+	switch {
+	case !validator.IsValidURL(feedURL):
+		sess.NewFlashErrorMessage(printer.Printf("error.invalid_feed_url"))
+	case h.store.AnotherFeedURLExists(user.ID, feed.ID, feedURL):
+		sess.NewFlashErrorMessage(printer.Printf("error.feed_already_exists"))
+	default:
+		logger.Info("[UI:MigrateFeed] Feed #%d moved from %q to %q", feed.ID, feed.FeedURL, feedURL)
+		feed.MigrateURL(feedURL)
+		if err := h.store.UpdateFeed(feed); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		if err := feedHandler.RefreshFeed(h.store, user.ID, feed.ID); err != nil {
+			if localizedErr, ok := err.(*errors.LocalizedError); ok {
+				sess.NewFlashErrorMessage(localizedErr.Localize(printer))
+			} else {
+				sess.NewFlashErrorMessage(err.Error())
+			}
+		} else {
+			sess.NewFlashMessage(printer.Printf("alert.feed_url_updated"))
+		}
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "feedHealth"))
+}
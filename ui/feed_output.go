@@ -0,0 +1,151 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"miniflux.app/config"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/locale"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/reader/atom"
+	"miniflux.app/reader/json"
+	"miniflux.app/reader/rss"
+)
+
+const feedOutputSize = 50
+
+var feedOutputFormats = map[string]struct {
+	contentType string
+	write       func(io.Writer, *model.Feed) error
+}{
+	"atom": {"application/atom+xml; charset=utf-8", atom.Write},
+	"rss":  {"application/rss+xml; charset=utf-8", rss.Write},
+	"json": {"application/feed+json; charset=utf-8", json.Write},
+}
+
+// showFeedOutput serializes the latest entries of a category, the starred entries or a saved search.
+//
+// These routes are public: the secret token given in the query string replaces the user session.
+func (h *handler) showFeedOutput(w http.ResponseWriter, r *http.Request) {
+	format, found := feedOutputFormats[request.RouteStringParam(r, "format")]
+	if !found {
+		html.NotFound(w, r)
+		return
+	}
+
+	token := request.QueryStringParam(r, "token", "")
+	if token == "" {
+		html.NotFound(w, r)
+		return
+	}
+
+	output, err := h.store.FeedOutputByToken(token)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	kind, targetID := feedOutputTarget(r)
+	if output == nil || output.Kind != kind || output.TargetID() != targetID {
+		html.NotFound(w, r)
+		return
+	}
+
+	user, err := h.store.UserByID(output.UserID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if user == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	builder := h.store.NewEntryQueryBuilder(user.ID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithOrder(model.DefaultSortingOrder)
+	builder.WithDirection("desc")
+	builder.WithLimit(feedOutputSize)
+
+	feed := &model.Feed{
+		Title:   output.Title,
+		FeedURL: config.Opts.RootURL() + r.URL.RequestURI(),
+	}
+
+	switch output.Kind {
+	case model.FeedOutputCategory:
+		builder.WithCategoryID(output.CategoryID)
+		feed.SiteURL = route.Path(h.router, "categoryEntries", "categoryID", output.CategoryID)
+	case model.FeedOutputStarred:
+		builder.WithStarred(true)
+		feed.Title = locale.NewPrinter(user.Language).Printf("page.starred.title")
+		feed.SiteURL = route.Path(h.router, "starred")
+	case model.FeedOutputSavedSearch:
+		search, err := h.store.SavedSearch(user.ID, output.SavedSearchID)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		if search == nil {
+			html.NotFound(w, r)
+			return
+		}
+
+		builder.WithSavedSearch(search)
+		feed.SiteURL = route.Path(h.router, "savedSearchEntries", "searchID", output.SavedSearchID)
+	}
+
+	feed.SiteURL = config.Opts.RootURL() + feed.SiteURL
+
+	feed.Entries, err = builder.GetEntries()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	for _, entry := range feed.Entries {
+		if entry.Enclosures, err = h.store.GetEnclosures(entry.ID); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := format.write(&buffer, feed); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if err := h.store.SetFeedOutputUsedTimestamp(output.ID); err != nil {
+		logger.Error("[UI:FeedOutput] %v", err)
+	}
+
+	responseBuilder := response.New(w, r)
+	responseBuilder.WithHeader("Content-Type", format.contentType)
+	responseBuilder.WithHeader("X-Robots-Tag", "noindex")
+	responseBuilder.WithBody(buffer.Bytes())
+	responseBuilder.Write()
+}
+
+func feedOutputTarget(r *http.Request) (string, int64) {
+	switch {
+	case request.RouteInt64Param(r, "categoryID") > 0:
+		return model.FeedOutputCategory, request.RouteInt64Param(r, "categoryID")
+	case request.RouteInt64Param(r, "searchID") > 0:
+		return model.FeedOutputSavedSearch, request.RouteInt64Param(r, "searchID")
+	default:
+		return model.FeedOutputStarred, 0
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+func (h *handler) showFeedOutputsPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	view, err := h.feedOutputsView(r, user, &form.FeedOutputForm{Kind: model.FeedOutputStarred})
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.OK(w, r, view.Render("feed_outputs"))
+}
+
+func (h *handler) feedOutputsView(r *http.Request, user *model.User, feedOutputForm *form.FeedOutputForm) (*view.View, error) {
+	outputs, err := h.store.FeedOutputs(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := h.store.Categories(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	searches, err := h.store.SavedSearches(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("outputs", outputs)
+	view.Set("categories", categories)
+	view.Set("searches", searches)
+	view.Set("form", feedOutputForm)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	view.Set("menuSavedSearches", h.store.MenuSavedSearches(user.ID))
+
+	return view, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+)
+
+func (h *handler) saveFeedOutput(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	feedOutputForm := form.NewFeedOutputForm(r)
+
+	view, err := h.feedOutputsView(r, user, feedOutputForm)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if err := feedOutputForm.Validate(); err != nil {
+		view.Set("errorMessage", err.Error())
+		html.OK(w, r, view.Render("feed_outputs"))
+		return
+	}
+
+	switch feedOutputForm.Kind {
+	case model.FeedOutputCategory:
+		category, err := h.store.Category(user.ID, feedOutputForm.TargetID)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		if category == nil {
+			html.NotFound(w, r)
+			return
+		}
+	case model.FeedOutputSavedSearch:
+		search, err := h.store.SavedSearch(user.ID, feedOutputForm.TargetID)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		if search == nil {
+			html.NotFound(w, r)
+			return
+		}
+	}
+
+	if h.store.FeedOutputExists(user.ID, feedOutputForm.Kind, feedOutputForm.TargetID) {
+		view.Set("errorMessage", "error.feed_output_already_exists")
+		html.OK(w, r, view.Render("feed_outputs"))
+		return
+	}
+
+	output := model.NewFeedOutput(user.ID, feedOutputForm.Kind, feedOutputForm.TargetID)
+	if err := h.store.CreateFeedOutput(output); err != nil {
+		logger.Error("[UI:SaveFeedOutput] %v", err)
+		view.Set("errorMessage", "error.unable_to_create_feed_output")
+		html.OK(w, r, view.Render("feed_outputs"))
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "feedOutputs"))
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/logger"
+	"miniflux.app/reader/subscription"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+func (h *handler) rediscoverFeed(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	feed, err := h.store.FeedByID(user.ID, request.RouteInt64Param(r, "feedID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if feed == nil || feed.IsNewsletter() {
+		html.NotFound(w, r)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("feed", feed)
+	view.Set("menu", "feeds")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	view.Set("menuSavedSearches", h.store.MenuSavedSearches(user.ID))
+
+	subscriptions, findErr := subscription.FindSubscriptions(
+		feed.SiteURL,
+		feed.UserAgent,
+		feed.Cookie,
+		feed.Username,
+		feed.Password,
+		feed.FetchViaProxy,
+		feed.AllowSelfSignedCertificates,
+	)
+
+	switch {
+	case findErr != nil:
+		logger.Error("[UI:RediscoverFeed] %q -> %s", feed.SiteURL, findErr)
+		view.Set("errorMessage", findErr)
+	case len(subscriptions) == 0:
+		view.Set("errorMessage", "error.subscription_not_found")
+	default:
+		view.Set("subscriptions", subscriptions)
+	}
+
+	html.OK(w, r, view.Render("rediscover_feed"))
+}
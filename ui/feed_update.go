@@ -44,6 +44,12 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	refreshes, err := h.store.FeedRefreshes(feed.ID, feedHistoryLimit)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	feedForm := form.NewFeedForm(r)
 
 	sess := session.New(h.store, request.SessionID(r))
@@ -51,10 +57,12 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 	view.Set("form", feedForm)
 	view.Set("categories", categories)
 	view.Set("feed", feed)
+	view.Set("refreshes", refreshes)
 	view.Set("menu", "feeds")
 	view.Set("user", loggedUser)
 	view.Set("countUnread", h.store.CountUnreadEntries(loggedUser.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(loggedUser.ID))
+	view.Set("menuSavedSearches", h.store.MenuSavedSearches(loggedUser.ID))
 	view.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
 
 	feedModificationRequest := &model.FeedModificationRequest{
@@ -65,6 +73,11 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		BlocklistRules:  model.OptionalString(feedForm.BlocklistRules),
 		KeeplistRules:   model.OptionalString(feedForm.KeeplistRules),
 		UrlRewriteRules: model.OptionalString(feedForm.UrlRewriteRules),
+		ItemSelector:    model.OptionalString(feedForm.ItemSelector),
+		TitleSelector:   model.OptionalString(feedForm.TitleSelector),
+		LinkSelector:    model.OptionalString(feedForm.LinkSelector),
+		DateSelector:    model.OptionalString(feedForm.DateSelector),
+		ContentSelector: model.OptionalString(feedForm.ContentSelector),
 	}
 
 	if validationErr := validator.ValidateFeedModification(h.store, loggedUser.ID, feedModificationRequest); validationErr != nil {
@@ -0,0 +1,51 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"miniflux.app/model"
+)
+
+// AnnotationForm represents the form used to highlight a passage or to write a note in the entry view.
+type AnnotationForm struct {
+	Quote       string
+	StartOffset int
+	EndOffset   int
+	Note        string
+}
+
+// Request returns the request to create the annotation.
+func (a AnnotationForm) Request() *model.AnnotationRequest {
+	return &model.AnnotationRequest{
+		Quote:       a.Quote,
+		StartOffset: a.StartOffset,
+		EndOffset:   a.EndOffset,
+		Note:        a.Note,
+	}
+}
+
+// NewAnnotationForm returns a new AnnotationForm.
+func NewAnnotationForm(r *http.Request) *AnnotationForm {
+	startOffset, err := strconv.Atoi(r.FormValue("start_offset"))
+	if err != nil {
+		startOffset = 0
+	}
+
+	endOffset, err := strconv.Atoi(r.FormValue("end_offset"))
+	if err != nil {
+		endOffset = 0
+	}
+
+	return &AnnotationForm{
+		Quote:       strings.TrimSpace(r.FormValue("quote")),
+		StartOffset: startOffset,
+		EndOffset:   endOffset,
+		Note:        strings.TrimSpace(r.FormValue("note")),
+	}
+}
@@ -10,14 +10,18 @@ import (
 
 // CategoryForm represents a feed form in the UI
 type CategoryForm struct {
-	Title        string
-	HideGlobally string
+	Title             string
+	HideGlobally      string
+	Notify            string
+	ExcludeFromDigest string
 }
 
 // NewCategoryForm returns a new CategoryForm.
 func NewCategoryForm(r *http.Request) *CategoryForm {
 	return &CategoryForm{
-		Title:        r.FormValue("title"),
-		HideGlobally: r.FormValue("hide_globally"),
+		Title:             r.FormValue("title"),
+		HideGlobally:      r.FormValue("hide_globally"),
+		Notify:            r.FormValue("notify"),
+		ExcludeFromDigest: r.FormValue("exclude_from_digest"),
 	}
 }
@@ -0,0 +1,33 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"strings"
+)
+
+// EntryTagsForm represents the form used to edit the tags of an entry.
+type EntryTagsForm struct {
+	Tags string
+}
+
+// TagList returns the comma-separated tags as a list.
+func (e EntryTagsForm) TagList() []string {
+	tags := make([]string, 0)
+	for _, tag := range strings.Split(e.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// NewEntryTagsForm returns a new EntryTagsForm.
+func NewEntryTagsForm(r *http.Request) *EntryTagsForm {
+	return &EntryTagsForm{
+		Tags: r.FormValue("tags"),
+	}
+}
@@ -7,6 +7,7 @@ package form // import "miniflux.app/ui/form"
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"miniflux.app/model"
 )
@@ -32,6 +33,13 @@ type FeedForm struct {
 	FetchViaProxy               bool
 	Disabled                    bool
 	HideGlobally                bool
+	CacheMedia                  bool
+	Notify                      bool
+	ItemSelector                string
+	TitleSelector               string
+	LinkSelector                string
+	DateSelector                string
+	ContentSelector             string
 	CategoryHidden              bool // Category has "hide_globally"
 }
 
@@ -58,6 +66,18 @@ func (f FeedForm) Merge(feed *model.Feed) *model.Feed {
 	feed.FetchViaProxy = f.FetchViaProxy
 	feed.Disabled = f.Disabled
 	feed.HideGlobally = f.HideGlobally
+	feed.CacheMedia = f.CacheMedia
+	feed.Notify = f.Notify
+
+	// Only scraped feeds have selectors, and they always keep an item selector.
+	if feed.IsScraped() && f.ItemSelector != "" {
+		feed.ItemSelector = f.ItemSelector
+		feed.TitleSelector = f.TitleSelector
+		feed.LinkSelector = f.LinkSelector
+		feed.DateSelector = f.DateSelector
+		feed.ContentSelector = f.ContentSelector
+	}
+
 	return feed
 }
 
@@ -87,5 +107,12 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		FetchViaProxy:               r.FormValue("fetch_via_proxy") == "1",
 		Disabled:                    r.FormValue("disabled") == "1",
 		HideGlobally:                r.FormValue("hide_globally") == "1",
+		CacheMedia:                  r.FormValue("cache_media") == "1",
+		Notify:                      r.FormValue("notify") == "1",
+		ItemSelector:                strings.TrimSpace(r.FormValue("item_selector")),
+		TitleSelector:               strings.TrimSpace(r.FormValue("title_selector")),
+		LinkSelector:                strings.TrimSpace(r.FormValue("link_selector")),
+		DateSelector:                strings.TrimSpace(r.FormValue("date_selector")),
+		ContentSelector:             strings.TrimSpace(r.FormValue("content_selector")),
 	}
 }
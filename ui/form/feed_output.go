@@ -0,0 +1,47 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"miniflux.app/errors"
+	"miniflux.app/model"
+)
+
+// FeedOutputForm represents the feed output form.
+type FeedOutputForm struct {
+	Kind     string
+	TargetID int64
+}
+
+// Validate makes sure the form values are valid.
+func (f FeedOutputForm) Validate() error {
+	switch f.Kind {
+	case model.FeedOutputStarred:
+		return nil
+	case model.FeedOutputCategory, model.FeedOutputSavedSearch:
+		if f.TargetID > 0 {
+			return nil
+		}
+	}
+
+	return errors.NewLocalizedError("error.fields_mandatory")
+}
+
+// NewFeedOutputForm returns a new FeedOutputForm.
+//
+// The target is either "starred", "category:<id>" or "search:<id>".
+func NewFeedOutputForm(r *http.Request) *FeedOutputForm {
+	kind, value, _ := strings.Cut(r.FormValue("target"), ":")
+	targetID, _ := strconv.ParseInt(value, 10, 64)
+
+	return &FeedOutputForm{
+		Kind:     kind,
+		TargetID: targetID,
+	}
+}
@@ -6,6 +6,7 @@ package form // import "miniflux.app/ui/form"
 
 import (
 	"net/http"
+	"strings"
 
 	"miniflux.app/model"
 )
@@ -53,6 +54,18 @@ type IntegrationForm struct {
 	MatrixBotPassword    string
 	MatrixBotURL         string
 	MatrixBotChatID      string
+	NtfyEnabled          bool
+	NtfyURL              string
+	NtfyToken            string
+	GotifyEnabled        bool
+	GotifyURL            string
+	GotifyToken          string
+	EmailEnabled         bool
+	EmailAddress         string
+	EmailDigestFrequency string
+	WebhookEnabled       bool
+	WebhookURL           string
+	WebhookSecret        string
 }
 
 // Merge copy form values to the model.
@@ -96,6 +109,17 @@ func (i IntegrationForm) Merge(integration *model.Integration) {
 	integration.MatrixBotPassword = i.MatrixBotPassword
 	integration.MatrixBotURL = i.MatrixBotURL
 	integration.MatrixBotChatID = i.MatrixBotChatID
+	integration.NtfyEnabled = i.NtfyEnabled
+	integration.NtfyURL = i.NtfyURL
+	integration.NtfyToken = i.NtfyToken
+	integration.GotifyEnabled = i.GotifyEnabled
+	integration.GotifyURL = i.GotifyURL
+	integration.GotifyToken = i.GotifyToken
+	integration.EmailNotificationEnabled = i.EmailEnabled
+	integration.EmailNotificationAddress = i.EmailAddress
+	integration.EmailDigestFrequency = i.EmailDigestFrequency
+	integration.WebhookEnabled = i.WebhookEnabled
+	integration.WebhookURL = i.WebhookURL
 }
 
 // NewIntegrationForm returns a new IntegrationForm.
@@ -142,5 +166,16 @@ func NewIntegrationForm(r *http.Request) *IntegrationForm {
 		MatrixBotPassword:    r.FormValue("matrix_bot_password"),
 		MatrixBotURL:         r.FormValue("matrix_bot_url"),
 		MatrixBotChatID:      r.FormValue("matrix_bot_chat_id"),
+		NtfyEnabled:          r.FormValue("ntfy_enabled") == "1",
+		NtfyURL:              r.FormValue("ntfy_url"),
+		NtfyToken:            r.FormValue("ntfy_token"),
+		GotifyEnabled:        r.FormValue("gotify_enabled") == "1",
+		GotifyURL:            r.FormValue("gotify_url"),
+		GotifyToken:          r.FormValue("gotify_token"),
+		EmailEnabled:         r.FormValue("email_notification_enabled") == "1",
+		EmailAddress:         strings.TrimSpace(r.FormValue("email_notification_address")),
+		EmailDigestFrequency: r.FormValue("email_digest_frequency"),
+		WebhookEnabled:       r.FormValue("webhook_enabled") == "1",
+		WebhookURL:           r.FormValue("webhook_url"),
 	}
 }
@@ -0,0 +1,53 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"strconv"
+
+	"miniflux.app/model"
+)
+
+// SavedSearchForm represents a saved search form in the UI.
+type SavedSearchForm struct {
+	Title      string
+	Query      string
+	CategoryID int64
+	FeedID     int64
+	Status     string
+}
+
+// Request returns the request to create or update the saved search.
+func (s SavedSearchForm) Request() *model.SavedSearchRequest {
+	return &model.SavedSearchRequest{
+		Title:      s.Title,
+		Query:      s.Query,
+		CategoryID: s.CategoryID,
+		FeedID:     s.FeedID,
+		Status:     s.Status,
+	}
+}
+
+// NewSavedSearchForm returns a new SavedSearchForm.
+func NewSavedSearchForm(r *http.Request) *SavedSearchForm {
+	categoryID, err := strconv.ParseInt(r.FormValue("category_id"), 10, 64)
+	if err != nil {
+		categoryID = 0
+	}
+
+	feedID, err := strconv.ParseInt(r.FormValue("feed_id"), 10, 64)
+	if err != nil {
+		feedID = 0
+	}
+
+	return &SavedSearchForm{
+		Title:      r.FormValue("title"),
+		Query:      r.FormValue("query"),
+		CategoryID: categoryID,
+		FeedID:     feedID,
+		Status:     r.FormValue("status"),
+	}
+}
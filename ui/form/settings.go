@@ -33,6 +33,7 @@ type SettingsForm struct {
 	CJKReadingSpeed        int
 	DefaultHomePage        string
 	CategoriesSortingOrder string
+	FilterRules            string
 }
 
 // Merge updates the fields of the given user.
@@ -54,6 +55,7 @@ func (s *SettingsForm) Merge(user *model.User) *model.User {
 	user.DefaultReadingSpeed = s.DefaultReadingSpeed
 	user.DefaultHomePage = s.DefaultHomePage
 	user.CategoriesSortingOrder = s.CategoriesSortingOrder
+	user.FilterRules = s.FilterRules
 
 	if s.Password != "" {
 		user.Password = s.Password
@@ -120,5 +122,6 @@ func NewSettingsForm(r *http.Request) *SettingsForm {
 		CJKReadingSpeed:        int(cjkReadingSpeed),
 		DefaultHomePage:        r.FormValue("default_home_page"),
 		CategoriesSortingOrder: r.FormValue("categories_sorting_order"),
+		FilterRules:            r.FormValue("filter_rules"),
 	}
 }
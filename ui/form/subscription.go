@@ -7,6 +7,7 @@ package form // import "miniflux.app/ui/form"
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"miniflux.app/errors"
 	"miniflux.app/validator"
@@ -28,6 +29,11 @@ type SubscriptionForm struct {
 	BlocklistRules              string
 	KeeplistRules               string
 	UrlRewriteRules             string
+	ItemSelector                string
+	TitleSelector               string
+	LinkSelector                string
+	DateSelector                string
+	ContentSelector             string
 }
 
 // Validate makes sure the form values are valid.
@@ -52,6 +58,12 @@ func (s *SubscriptionForm) Validate() error {
 		return errors.NewLocalizedError("error.feed_invalid_urlrewrite_rule")
 	}
 
+	for _, selector := range []string{s.ItemSelector, s.TitleSelector, s.LinkSelector, s.DateSelector, s.ContentSelector} {
+		if !validator.IsValidSelector(selector) {
+			return errors.NewLocalizedError("error.feed_invalid_selector")
+		}
+	}
+
 	return nil
 }
 
@@ -77,5 +89,10 @@ func NewSubscriptionForm(r *http.Request) *SubscriptionForm {
 		BlocklistRules:              r.FormValue("blocklist_rules"),
 		KeeplistRules:               r.FormValue("keeplist_rules"),
 		UrlRewriteRules:             r.FormValue("urlrewrite_rules"),
+		ItemSelector:                strings.TrimSpace(r.FormValue("item_selector")),
+		TitleSelector:               strings.TrimSpace(r.FormValue("title_selector")),
+		LinkSelector:                strings.TrimSpace(r.FormValue("link_selector")),
+		DateSelector:                strings.TrimSpace(r.FormValue("date_selector")),
+		ContentSelector:             strings.TrimSpace(r.FormValue("content_selector")),
 	}
 }
@@ -68,6 +68,18 @@ func (h *handler) showIntegrationPage(w http.ResponseWriter, r *http.Request) {
 		MatrixBotPassword:    integration.MatrixBotPassword,
 		MatrixBotURL:         integration.MatrixBotURL,
 		MatrixBotChatID:      integration.MatrixBotChatID,
+		NtfyEnabled:          integration.NtfyEnabled,
+		NtfyURL:              integration.NtfyURL,
+		NtfyToken:            integration.NtfyToken,
+		GotifyEnabled:        integration.GotifyEnabled,
+		GotifyURL:            integration.GotifyURL,
+		GotifyToken:          integration.GotifyToken,
+		EmailEnabled:         integration.EmailNotificationEnabled,
+		EmailAddress:         integration.EmailNotificationAddress,
+		EmailDigestFrequency: integration.EmailDigestFrequency,
+		WebhookEnabled:       integration.WebhookEnabled,
+		WebhookURL:           integration.WebhookURL,
+		WebhookSecret:        integration.WebhookSecret,
 	}
 
 	sess := session.New(h.store, request.SessionID(r))
@@ -77,7 +89,9 @@ func (h *handler) showIntegrationPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	view.Set("menuSavedSearches", h.store.MenuSavedSearches(user.ID))
 	view.Set("hasPocketConsumerKeyConfigured", config.Opts.PocketConsumerKey("") != "")
+	view.Set("hasSMTP", config.Opts.HasSMTP())
 
 	html.OK(w, r, view.Render("integrations"))
 }
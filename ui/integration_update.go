@@ -9,10 +9,12 @@ import (
 	"fmt"
 	"net/http"
 
+	"miniflux.app/crypto"
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
 	"miniflux.app/locale"
+	"miniflux.app/model"
 	"miniflux.app/ui/form"
 	"miniflux.app/ui/session"
 )
@@ -62,6 +64,35 @@ func (h *handler) updateIntegration(w http.ResponseWriter, r *http.Request) {
 	} else {
 		integration.GoogleReaderPassword = ""
 	}
+
+	if integration.WebhookEnabled {
+		if integration.WebhookURL == "" {
+			integration.WebhookEnabled = false
+			integration.WebhookSecret = ""
+		} else if integration.WebhookSecret == "" {
+			integration.WebhookSecret = crypto.GenerateRandomStringHex(32)
+		}
+	} else {
+		integration.WebhookURL = ""
+		integration.WebhookSecret = ""
+	}
+
+	if integration.NtfyEnabled && integration.NtfyURL == "" {
+		integration.NtfyEnabled = false
+	}
+
+	if integration.GotifyEnabled && (integration.GotifyURL == "" || integration.GotifyToken == "") {
+		integration.GotifyEnabled = false
+	}
+
+	if integration.EmailNotificationEnabled && integration.EmailNotificationAddress == "" {
+		integration.EmailNotificationEnabled = false
+	}
+
+	if _, found := model.DigestFrequencies()[integration.EmailDigestFrequency]; !found || integration.EmailNotificationAddress == "" {
+		integration.EmailDigestFrequency = model.DigestFrequencyNone
+	}
+
 	err = h.store.UpdateIntegration(integration)
 	if err != nil {
 		html.ServerError(w, r, err)
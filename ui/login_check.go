@@ -9,6 +9,7 @@ import (
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
 	"miniflux.app/logger"
+	"miniflux.app/model"
 	"miniflux.app/ui/form"
 	"miniflux.app/ui/session"
 	"miniflux.app/ui/view"
@@ -35,21 +36,53 @@ func (h *handler) checkLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sessionToken, userID, err := h.store.CreateUserSessionFromUsername(authForm.Username, r.UserAgent(), clientIP)
+	user, err := h.store.UserByUsername(authForm.Username)
 	if err != nil {
 		html.ServerError(w, r, err)
 		return
 	}
 
-	logger.Info("[UI:CheckLogin] username=%s just logged in", authForm.Username)
-	h.store.SetLastLogin(userID)
+	if user == nil {
+		html.OK(w, r, view.Render("login"))
+		return
+	}
 
-	user, err := h.store.UserByID(userID)
-	if err != nil {
+	if isDeniedByGroups(h.store, user.ID) {
+		logger.Error("[UI:CheckLogin] [ClientIP=%s] username=%s is not a member of the required groups", clientIP, user.Username)
+		html.Forbidden(w, r)
+		return
+	}
+
+	if h.store.HasTwoFactor(user.ID) {
+		logger.Info("[UI:CheckLogin] username=%s entered a valid password, waiting for the one-time code", user.Username)
+		sess.SetTwoFactorUser(user.ID)
+		html.Redirect(w, r, route.Path(h.router, "twoFactorLogin"))
+		return
+	}
+
+	h.startUserSession(w, r, sess, user)
+}
+
+// startUserSession logs the user in once all the credentials have been verified.
+func (h *handler) startUserSession(w http.ResponseWriter, r *http.Request, sess *session.Session, user *model.User) {
+	if err := h.createUserSession(w, r, sess, user); err != nil {
 		html.ServerError(w, r, err)
 		return
 	}
 
+	html.Redirect(w, r, route.Path(h.router, user.DefaultHomePage))
+}
+
+// createUserSession creates the user session and sets the session cookie, whatever the login method.
+func (h *handler) createUserSession(w http.ResponseWriter, r *http.Request, sess *session.Session, user *model.User) error {
+	sessionToken, _, err := h.store.CreateUserSessionFromUsername(user.Username, r.UserAgent(), request.ClientIP(r))
+	if err != nil {
+		return err
+	}
+
+	logger.Info("[UI:CheckLogin] username=%s just logged in", user.Username)
+	h.store.SetLastLogin(user.ID)
+
 	sess.SetLanguage(user.Language)
 	sess.SetTheme(user.Theme)
 
@@ -60,5 +93,5 @@ func (h *handler) checkLogin(w http.ResponseWriter, r *http.Request) {
 		config.Opts.BasePath(),
 	))
 
-	html.Redirect(w, r, route.Path(h.router, user.DefaultHomePage))
+	return nil
 }
@@ -0,0 +1,112 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+	"strings"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/logger"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+// checkTwoFactorLogin completes the login with a TOTP code or with a recovery code.
+func (h *handler) checkTwoFactorLogin(w http.ResponseWriter, r *http.Request) {
+	clientIP := request.ClientIP(r)
+	sess := session.New(h.store, request.SessionID(r))
+
+	userID := request.TwoFactorUserID(r)
+	if userID == 0 {
+		html.Redirect(w, r, route.Path(h.router, "login"))
+		return
+	}
+
+	user, err := h.store.UserByID(userID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	secret, enabled, err := h.store.TwoFactorSecret(userID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if user == nil || !enabled {
+		sess.SetTwoFactorUser(0)
+		html.Redirect(w, r, route.Path(h.router, "login"))
+		return
+	}
+
+	// The codes are not checked at all while the account is locked, even valid ones.
+	locked, err := h.store.TwoFactorLocked(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if locked {
+		logger.Error("[UI:CheckTwoFactorLogin] [ClientIP=%s] Two-factor authentication locked for username=%s", clientIP, user.Username)
+		sess.SetTwoFactorUser(0)
+		view := view.New(h.tpl, r, sess)
+		view.Set("errorMessage", "error.too_many_two_factor_attempts")
+		html.OK(w, r, view.Render("login"))
+		return
+	}
+
+	code := strings.TrimSpace(r.FormValue("code"))
+	valid, err := h.validateTwoFactorCode(user.ID, secret, code)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !valid && code != "" {
+		if valid, err = h.store.UseRecoveryCode(user.ID, normalizeRecoveryCode(code)); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		if valid {
+			logger.Info("[UI:CheckTwoFactorLogin] username=%s used a recovery code", user.Username)
+		}
+	}
+
+	if !valid {
+		logger.Error("[UI:CheckTwoFactorLogin] [ClientIP=%s] Invalid one-time code for username=%s", clientIP, user.Username)
+
+		locked, err := h.store.AddTwoFactorFailure(user.ID, twoFactorMaxAttempts, twoFactorLockDuration)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		view := view.New(h.tpl, r, sess)
+
+		if locked {
+			sess.SetTwoFactorUser(0)
+			view.Set("errorMessage", "error.too_many_two_factor_attempts")
+			html.OK(w, r, view.Render("login"))
+			return
+		}
+
+		view.Set("errorMessage", "error.invalid_two_factor_code")
+		html.OK(w, r, view.Render("login_two_factor"))
+		return
+	}
+
+	if err := h.store.ResetTwoFactorFailures(user.ID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess.SetTwoFactorUser(0)
+	h.startUserSession(w, r, sess, user)
+}
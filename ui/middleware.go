@@ -45,6 +45,18 @@ func (m *middleware) handleUserSession(next http.Handler) http.Handler {
 		} else {
 			logger.Debug("[UI:UserSession] %s", session)
 
+			// The authentication proxy sends the groups with every request, the sessions end as soon as the user leaves the required groups.
+			if isDeniedByProxyGroups(r) {
+				logger.Error("[UI:UserSession] [ClientIP=%s] User #%d is not a member of the required groups anymore", request.ClientIP(r), session.UserID)
+				if err := m.store.SetDeniedByGroups(session.UserID, true); err != nil {
+					html.ServerError(w, r, err)
+					return
+				}
+
+				html.Forbidden(w, r)
+				return
+			}
+
 			ctx := r.Context()
 			ctx = context.WithValue(ctx, request.UserIDContextKey, session.UserID)
 			ctx = context.WithValue(ctx, request.IsAuthenticatedContextKey, true)
@@ -90,7 +102,7 @@ func (m *middleware) handleAppSession(next http.Handler) http.Handler {
 			if session.Data.CSRF != formValue && session.Data.CSRF != headerValue {
 				logger.Error(`[UI:AppSession] Invalid or missing CSRF token: Form="%s", Header="%s"`, formValue, headerValue)
 
-				if mux.CurrentRoute(r).GetName() == "checkLogin" {
+				if routeName := mux.CurrentRoute(r).GetName(); routeName == "checkLogin" || routeName == "checkTwoFactorLogin" {
 					html.Redirect(w, r, route.Path(m.router, "login"))
 					return
 				}
@@ -109,6 +121,8 @@ func (m *middleware) handleAppSession(next http.Handler) http.Handler {
 		ctx = context.WithValue(ctx, request.UserLanguageContextKey, session.Data.Language)
 		ctx = context.WithValue(ctx, request.UserThemeContextKey, session.Data.Theme)
 		ctx = context.WithValue(ctx, request.PocketRequestTokenContextKey, session.Data.PocketRequestToken)
+		ctx = context.WithValue(ctx, request.TwoFactorUserIDContextKey, session.Data.TwoFactorUserID)
+		ctx = context.WithValue(ctx, request.WebAuthnSessionContextKey, session.Data.WebAuthnSession)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -133,6 +147,10 @@ func (m *middleware) isPublicRoute(r *http.Request) bool {
 	switch route.GetName() {
 	case "login",
 		"checkLogin",
+		"twoFactorLogin",
+		"checkTwoFactorLogin",
+		"webAuthnLoginBegin",
+		"webAuthnLoginFinish",
 		"stylesheet",
 		"javascript",
 		"oauth2Redirect",
@@ -142,9 +160,11 @@ func (m *middleware) isPublicRoute(r *http.Request) bool {
 		"webManifest",
 		"robots",
 		"sharedEntry",
+		"publicSharedEntries",
 		"healthcheck",
 		"offline",
-		"proxy":
+		"proxy",
+		"mediaProxy":
 		return true
 	default:
 		return false
@@ -182,12 +202,29 @@ func (m *middleware) handleAuthProxy(next http.Handler) http.Handler {
 		clientIP := request.ClientIP(r)
 		logger.Info("[AuthProxy] [ClientIP=%s] Received authenticated requested for %q", clientIP, username)
 
+		var groups []string
+		if groupsHeader := config.Opts.AuthProxyGroupsHeader(); groupsHeader != "" {
+			groups = authProxyGroups(r.Header.Get(groupsHeader))
+		}
+
 		user, err := m.store.UserByUsername(username)
 		if err != nil {
 			html.ServerError(w, r, err)
 			return
 		}
 
+		allowed, err := checkUserGroups(m.store, user, groups)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		if !allowed {
+			logger.Error("[AuthProxy] [ClientIP=%s] %q is not a member of the required groups: %v", clientIP, username, groups)
+			html.Forbidden(w, r)
+			return
+		}
+
 		if user == nil {
 			logger.Error("[AuthProxy] [ClientIP=%s] %q doesn't exist", clientIP, username)
 
@@ -202,6 +239,13 @@ func (m *middleware) handleAuthProxy(next http.Handler) http.Handler {
 			}
 		}
 
+		if groups != nil {
+			if err := applyAdminGroups(m.store, user, groups); err != nil {
+				html.ServerError(w, r, err)
+				return
+			}
+		}
+
 		sessionToken, _, err := m.store.CreateUserSessionFromUsername(user.Username, r.UserAgent(), clientIP)
 		if err != nil {
 			html.ServerError(w, r, err)
@@ -0,0 +1,67 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/config"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/logger"
+	feedHandler "miniflux.app/reader/handler"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+func (h *handler) saveNewsletter(w http.ResponseWriter, r *http.Request) {
+	if !config.Opts.HasNewsletterService() {
+		html.NotFound(w, r)
+		return
+	}
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	categories, err := h.store.Categories(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	newsletterForm := form.NewNewsletterForm(r)
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("categories", categories)
+	view.Set("form", newsletterForm)
+	view.Set("newsletterDomain", config.Opts.NewsletterDomain())
+	view.Set("menu", "feeds")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	view.Set("menuSavedSearches", h.store.MenuSavedSearches(user.ID))
+
+	if err := newsletterForm.Validate(); err != nil {
+		view.Set("errorMessage", err.Error())
+		html.OK(w, r, view.Render("create_newsletter"))
+		return
+	}
+
+	feed, err := feedHandler.CreateNewsletter(h.store, user.ID, newsletterForm.CategoryID, newsletterForm.Title)
+	if err != nil {
+		logger.Error("[UI:SaveNewsletter] %v", err)
+		view.Set("errorMessage", err.Error())
+		html.OK(w, r, view.Render("create_newsletter"))
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "editFeed", "feedID", feed.ID))
+}
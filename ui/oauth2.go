@@ -18,5 +18,7 @@ func getOAuth2Manager(ctx context.Context) *oauth2.Manager {
 		config.Opts.OAuth2ClientSecret(),
 		config.Opts.OAuth2RedirectURL(),
 		config.Opts.OAuth2OidcDiscoveryEndpoint(),
+		config.Opts.OAuth2OidcGroupsClaim(),
+		config.Opts.OAuth2OidcExtraScopes(),
 	)
 }
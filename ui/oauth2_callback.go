@@ -92,6 +92,19 @@ func (h *handler) oauth2Callback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Unknown groups are treated as no groups, the restriction never fails open.
+	allowed, err := checkUserGroups(h.store, user, profile.Groups)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !allowed {
+		logger.Error("[OAuth2] [ClientIP=%s] %s is not a member of the required groups", clientIP, profile)
+		html.Forbidden(w, r)
+		return
+	}
+
 	if user == nil {
 		if !config.Opts.IsOAuth2UserCreationAllowed() {
 			html.Forbidden(w, r)
@@ -113,6 +126,13 @@ func (h *handler) oauth2Callback(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if profile.Groups != nil {
+		if err := applyAdminGroups(h.store, user, profile.Groups); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
 	sessionToken, _, err := h.store.CreateUserSessionFromUsername(user.Username, r.UserAgent(), clientIP)
 	if err != nil {
 		html.ServerError(w, r, err)
@@ -5,6 +5,7 @@
 package ui // import "miniflux.app/ui"
 
 import (
+	"bytes"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
@@ -18,6 +19,7 @@ import (
 	"miniflux.app/http/response"
 	"miniflux.app/http/response/html"
 	"miniflux.app/logger"
+	"miniflux.app/proxy"
 )
 
 func (h *handler) imageProxy(w http.ResponseWriter, r *http.Request) {
@@ -27,35 +29,90 @@ func (h *handler) imageProxy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	encodedDigest := request.RouteStringParam(r, "encodedDigest")
-	encodedURL := request.RouteStringParam(r, "encodedURL")
-	if encodedURL == "" {
-		html.BadRequest(w, r, errors.New("No URL provided"))
+	decodedDigest, decodedURL, err := decodeProxyRoute(r)
+	if err != nil {
+		html.BadRequest(w, r, err)
 		return
 	}
 
-	decodedDigest, err := base64.URLEncoding.DecodeString(encodedDigest)
-	if err != nil {
-		html.BadRequest(w, r, errors.New("Unable to decode this Digest"))
+	mac := hmac.New(sha256.New, config.Opts.ProxyPrivateKey())
+	mac.Write(decodedURL)
+	expectedMAC := mac.Sum(nil)
+
+	if !hmac.Equal(decodedDigest, expectedMAC) {
+		html.Forbidden(w, r)
 		return
 	}
 
-	decodedURL, err := base64.URLEncoding.DecodeString(encodedURL)
-	if err != nil {
-		html.BadRequest(w, r, errors.New("Unable to decode this URL"))
+	h.fetchImage(w, r, decodedURL)
+}
+
+// mediaProxy serves the files cached for an entry, the files not cached yet are fetched like the other images.
+func (h *handler) mediaProxy(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("If-None-Match") != "" {
+		w.WriteHeader(http.StatusNotModified)
 		return
 	}
 
-	mac := hmac.New(sha256.New, config.Opts.ProxyPrivateKey())
-	mac.Write(decodedURL)
-	expectedMAC := mac.Sum(nil)
+	entryID := request.RouteInt64Param(r, "entryID")
+	decodedDigest, decodedURL, err := decodeProxyRoute(r)
+	if err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
 
-	if !hmac.Equal(decodedDigest, expectedMAC) {
+	mediaURL := string(decodedURL)
+	if !hmac.Equal(decodedDigest, proxy.MediaDigest(entryID, mediaURL)) {
 		html.Forbidden(w, r)
 		return
 	}
 
+	media, err := h.store.EntryMediaByURL(entryID, mediaURL)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if media == nil {
+		h.fetchImage(w, r, decodedURL)
+		return
+	}
+
+	logger.Debug(`[Proxy] Serving %q from the media cache`, mediaURL)
+
+	// Range requests are supported to seek in the cached audio and video files.
+	w.Header().Set("ETag", `"`+media.Hash+`"`)
+	w.Header().Set("Cache-Control", "public")
+	w.Header().Set("Expires", time.Now().Add(72*time.Hour).Format(time.RFC1123))
+	w.Header().Set("Content-Security-Policy", `default-src 'self'`)
+	w.Header().Set("Content-Type", media.MimeType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(media.Content))
+}
+
+func decodeProxyRoute(r *http.Request) ([]byte, []byte, error) {
+	encodedDigest := request.RouteStringParam(r, "encodedDigest")
+	encodedURL := request.RouteStringParam(r, "encodedURL")
+	if encodedURL == "" {
+		return nil, nil, errors.New("No URL provided")
+	}
+
+	decodedDigest, err := base64.URLEncoding.DecodeString(encodedDigest)
+	if err != nil {
+		return nil, nil, errors.New("Unable to decode this Digest")
+	}
+
+	decodedURL, err := base64.URLEncoding.DecodeString(encodedURL)
+	if err != nil {
+		return nil, nil, errors.New("Unable to decode this URL")
+	}
+
+	return decodedDigest, decodedURL, nil
+}
+
+func (h *handler) fetchImage(w http.ResponseWriter, r *http.Request, decodedURL []byte) {
 	imageURL := string(decodedURL)
+
 	logger.Debug(`[Proxy] Fetching %q`, imageURL)
 
 	req, err := http.NewRequest("GET", imageURL, nil)
@@ -0,0 +1,68 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+func (h *handler) showEditSavedSearchPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	search, err := h.store.SavedSearch(user.ID, request.RouteInt64Param(r, "searchID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if search == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	categories, err := h.store.Categories(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	feeds, err := h.store.Feeds(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	searchForm := form.SavedSearchForm{
+		Title:      search.Title,
+		Query:      search.Query,
+		CategoryID: search.CategoryID,
+		FeedID:     search.FeedID,
+		Status:     search.Status,
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", searchForm)
+	view.Set("search", search)
+	view.Set("categories", categories)
+	view.Set("feeds", feeds)
+	view.Set("menu", "searches")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	view.Set("menuSavedSearches", h.store.MenuSavedSearches(user.ID))
+
+	html.OK(w, r, view.Render("edit_saved_search"))
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+)
+
+func (h *handler) removeSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	search, err := h.store.SavedSearch(userID, request.RouteInt64Param(r, "searchID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if search == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.RemoveSavedSearch(userID, search.ID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "savedSearches"))
+}
@@ -11,6 +11,7 @@ import (
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
 	"miniflux.app/model"
+	"miniflux.app/storage"
 	"miniflux.app/ui/session"
 	"miniflux.app/ui/view"
 )
@@ -48,6 +49,9 @@ func (h *handler) showSearchEntriesPage(w http.ResponseWriter, r *http.Request)
 	pagination.SearchQuery = searchQuery
 
 	view.Set("searchQuery", searchQuery)
+	if _, err := storage.ParseSearchQuery(searchQuery); err != nil {
+		view.Set("errorMessage", "error.invalid_search_query")
+	}
 	view.Set("entries", entries)
 	view.Set("total", count)
 	view.Set("pagination", pagination)
@@ -55,6 +59,7 @@ func (h *handler) showSearchEntriesPage(w http.ResponseWriter, r *http.Request)
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	view.Set("menuSavedSearches", h.store.MenuSavedSearches(user.ID))
 	view.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID))
 
 	html.OK(w, r, view.Render("search_entries"))
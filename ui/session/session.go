@@ -63,6 +63,16 @@ func (s *Session) SetPocketRequestToken(requestToken string) {
 	s.store.UpdateAppSessionField(s.sessionID, "pocket_request_token", requestToken)
 }
 
+// SetTwoFactorUser remembers the user who must enter a one-time code to complete the login, zero clears the value.
+func (s *Session) SetTwoFactorUser(userID int64) {
+	s.store.UpdateAppSessionField(s.sessionID, "two_factor_user_id", userID)
+}
+
+// SetWebAuthnSession stores the JSON encoded state of the WebAuthn ceremony until the browser answers, an empty value clears it.
+func (s *Session) SetWebAuthnSession(data string) {
+	s.store.UpdateAppSessionField(s.sessionID, "webauthn_session", data)
+}
+
 // New returns a new session handler.
 func New(store *storage.Storage, sessionID string) *Session {
 	return &Session{store, sessionID}
@@ -6,15 +6,23 @@ package ui // import "miniflux.app/ui"
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
+	"miniflux.app/config"
+	"miniflux.app/crypto"
 	"miniflux.app/http/request"
 	"miniflux.app/http/response"
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
+	"miniflux.app/model"
+	"miniflux.app/reader/sanitizer"
 	"miniflux.app/storage"
 	"miniflux.app/ui/session"
 	"miniflux.app/ui/view"
+	"miniflux.app/url"
+
+	"github.com/PuerkitoBio/goquery"
 )
 
 func (h *handler) createSharedEntry(w http.ResponseWriter, r *http.Request) {
@@ -45,23 +53,61 @@ func (h *handler) sharedEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	etag := shareCode
-	response.New(w, r).WithCaching(etag, 72*time.Hour, func(b *response.Builder) {
-		builder := storage.NewAnonymousQueryBuilder(h.store)
-		builder.WithShareCode(shareCode)
+	builder := storage.NewAnonymousQueryBuilder(h.store)
+	builder.WithShareCode(shareCode)
 
-		entry, err := builder.GetEntry()
-		if err != nil || entry == nil {
-			html.NotFound(w, r)
-			return
-		}
+	entry, err := builder.GetEntry()
+	if err != nil || entry == nil {
+		html.NotFound(w, r)
+		return
+	}
 
+	etag := crypto.Hash(shareCode + entry.ShareComment)
+	response.New(w, r).WithCaching(etag, 72*time.Hour, func(b *response.Builder) {
 		sess := session.New(h.store, request.SessionID(r))
 		view := view.New(h.tpl, r, sess)
 		view.Set("entry", entry)
+		view.Set("openGraph", newOpenGraph(entry, config.Opts.RootURL()+route.Path(h.router, "sharedEntry", "shareCode", shareCode)))
 
 		b.WithHeader("Content-Type", "text/html; charset=utf-8")
 		b.WithBody(view.Render("entry"))
 		b.Write()
 	})
 }
+
+// openGraph holds the metadata used by chat applications and social networks to preview a shared entry.
+type openGraph struct {
+	Title       string
+	Description string
+	URL         string
+	Image       string
+	SiteName    string
+}
+
+func newOpenGraph(entry *model.Entry, shareURL string) *openGraph {
+	metadata := &openGraph{
+		Title:       entry.Title,
+		Description: entry.ShareComment,
+		URL:         shareURL,
+		SiteName:    entry.Feed.Title,
+	}
+
+	if metadata.Description == "" {
+		metadata.Description = sanitizer.TruncateHTML(entry.Content, 200)
+	}
+
+	for _, enclosure := range entry.Enclosures {
+		if strings.HasPrefix(enclosure.MimeType, "image/") && url.IsAbsoluteURL(enclosure.URL) {
+			metadata.Image = enclosure.URL
+			return metadata
+		}
+	}
+
+	if document, err := goquery.NewDocumentFromReader(strings.NewReader(entry.Content)); err == nil {
+		if src, found := document.Find("img[src]").First().Attr("src"); found && url.IsAbsoluteURL(src) {
+			metadata.Image = src
+		}
+	}
+
+	return metadata
+}
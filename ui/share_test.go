@@ -0,0 +1,62 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestOpenGraphWithShareComment(t *testing.T) {
+	entry := &model.Entry{
+		Title:        "Title",
+		Content:      `<p>Content</p><img src="https://example.org/content.png">`,
+		ShareComment: "Comment",
+		Feed:         &model.Feed{Title: "Feed"},
+		Enclosures:   model.EnclosureList{{URL: "https://example.org/enclosure.png", MimeType: "image/png"}},
+	}
+
+	metadata := newOpenGraph(entry, "https://miniflux.example.org/share/abc")
+	if metadata.Title != "Title" || metadata.SiteName != "Feed" || metadata.URL != "https://miniflux.example.org/share/abc" {
+		t.Fatalf(`Unexpected metadata, got %+v`, metadata)
+	}
+
+	if metadata.Description != "Comment" {
+		t.Errorf(`The comment should be the description, got %q`, metadata.Description)
+	}
+
+	if metadata.Image != "https://example.org/enclosure.png" {
+		t.Errorf(`The image enclosure should be preferred, got %q`, metadata.Image)
+	}
+}
+
+func TestOpenGraphWithoutShareComment(t *testing.T) {
+	entry := &model.Entry{
+		Content: `<p>Content</p><img src="https://example.org/content.png">`,
+		Feed:    &model.Feed{},
+	}
+
+	metadata := newOpenGraph(entry, "")
+	if metadata.Description != "Content" {
+		t.Errorf(`The content should be the description, got %q`, metadata.Description)
+	}
+
+	if metadata.Image != "https://example.org/content.png" {
+		t.Errorf(`The first image of the content should be used, got %q`, metadata.Image)
+	}
+}
+
+func TestOpenGraphWithRelativeImage(t *testing.T) {
+	entry := &model.Entry{
+		Content:    `<img src="/content.png">`,
+		Feed:       &model.Feed{},
+		Enclosures: model.EnclosureList{{URL: "/enclosure.png", MimeType: "image/png"}},
+	}
+
+	if metadata := newOpenGraph(entry, ""); metadata.Image != "" {
+		t.Errorf(`The relative images should be ignored, got %q`, metadata.Image)
+	}
+}
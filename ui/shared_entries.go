@@ -37,14 +37,22 @@ func (h *handler) sharedEntries(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	sharedEntriesCode, err := h.store.SharedEntriesCode(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
 	view.Set("entries", entries)
 	view.Set("total", count)
+	view.Set("sharedEntriesCode", sharedEntriesCode)
 	view.Set("menu", "history")
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	view.Set("menuSavedSearches", h.store.MenuSavedSearches(user.ID))
 	view.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID))
 
 	html.OK(w, r, view.Render("shared_entries"))
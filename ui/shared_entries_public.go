@@ -0,0 +1,163 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"bytes"
+	stdhtml "html"
+	"net/http"
+	"strings"
+
+	"miniflux.app/config"
+	"miniflux.app/crypto"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/locale"
+	"miniflux.app/model"
+	"miniflux.app/reader/atom"
+	"miniflux.app/storage"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+const publicSharedEntriesPageSize = 50
+
+func (h *handler) enableSharedEntriesPage(w http.ResponseWriter, r *http.Request) {
+	if err := h.store.SetSharedEntriesCode(request.UserID(r), crypto.GenerateRandomStringHex(20)); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "sharedEntries"))
+}
+
+func (h *handler) disableSharedEntriesPage(w http.ResponseWriter, r *http.Request) {
+	if err := h.store.SetSharedEntriesCode(request.UserID(r), ""); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "sharedEntries"))
+}
+
+// showPublicSharedEntriesPage lists the shared entries of a user who published this page.
+func (h *handler) showPublicSharedEntriesPage(w http.ResponseWriter, r *http.Request) {
+	code := request.RouteStringParam(r, "code")
+	user, found := h.publicSharedEntriesUser(w, r, code)
+	if !found {
+		return
+	}
+
+	offset := request.QueryIntParam(r, "offset", 0)
+	builder := h.publicSharedEntriesQuery(user.ID)
+	builder.WithOffset(offset)
+	builder.WithLimit(publicSharedEntriesPageSize)
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	count, err := builder.CountEntries()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("entries", entries)
+	view.Set("total", count)
+	view.Set("pagination", getPagination(route.Path(h.router, "publicSharedEntries", "code", code), count, offset, publicSharedEntriesPageSize))
+	view.Set("alternateFeedURL", route.Path(h.router, "publicSharedEntriesFeed", "code", code))
+
+	html.OK(w, r, view.Render("public_shared_entries"))
+}
+
+// showPublicSharedEntriesFeed returns the latest shared entries as an Atom feed, the comments are prepended to the content.
+func (h *handler) showPublicSharedEntriesFeed(w http.ResponseWriter, r *http.Request) {
+	code := request.RouteStringParam(r, "code")
+	user, found := h.publicSharedEntriesUser(w, r, code)
+	if !found {
+		return
+	}
+
+	builder := h.publicSharedEntriesQuery(user.ID)
+	builder.WithLimit(publicSharedEntriesPageSize)
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	for _, entry := range entries {
+		if entry.ShareComment != "" {
+			comment := strings.ReplaceAll(stdhtml.EscapeString(entry.ShareComment), "\n", "<br>")
+			entry.Content = "<blockquote>" + comment + "</blockquote>" + entry.Content
+		}
+
+		if entry.Enclosures, err = h.store.GetEnclosures(entry.ID); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	feed := &model.Feed{
+		Title:   locale.NewPrinter(user.Language).Printf("page.shared_entries.title"),
+		FeedURL: config.Opts.RootURL() + route.Path(h.router, "publicSharedEntriesFeed", "code", code),
+		SiteURL: config.Opts.RootURL() + route.Path(h.router, "publicSharedEntries", "code", code),
+		Entries: entries,
+	}
+
+	var buffer bytes.Buffer
+	if err := atom.Write(&buffer, feed); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	responseBuilder := response.New(w, r)
+	responseBuilder.WithHeader("Content-Type", "application/atom+xml; charset=utf-8")
+	responseBuilder.WithBody(buffer.Bytes())
+	responseBuilder.Write()
+}
+
+func (h *handler) publicSharedEntriesUser(w http.ResponseWriter, r *http.Request, code string) (*model.User, bool) {
+	userID, err := h.store.UserIDBySharedEntriesCode(code)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return nil, false
+	}
+
+	if userID == 0 {
+		html.NotFound(w, r)
+		return nil, false
+	}
+
+	user, err := h.store.UserByID(userID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return nil, false
+	}
+
+	if user == nil {
+		html.NotFound(w, r)
+		return nil, false
+	}
+
+	return user, true
+}
+
+func (h *handler) publicSharedEntriesQuery(userID int64) *storage.EntryQueryBuilder {
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithShareCodeNotEmpty()
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithOrder(model.DefaultSortingOrder)
+	builder.WithDirection("desc")
+	return builder
+}
@@ -315,6 +315,133 @@ function toggleBookmark(parentElement, toasting) {
     request.execute();
 }
 
+// Resume the audio and video players from the saved position and keep the position in sync.
+function handleMediaPlayers() {
+    let elements = document.querySelectorAll("audio[data-save-url], video[data-save-url]");
+    elements.forEach((element) => {
+        let lastSavedProgression = parseInt(element.dataset.mediaProgression, 10) || 0;
+
+        if (lastSavedProgression > 0) {
+            if (element.readyState > 0) {
+                element.currentTime = lastSavedProgression;
+            } else {
+                element.addEventListener("loadedmetadata", () => {
+                    element.currentTime = lastSavedProgression;
+                }, {once: true});
+            }
+        }
+
+        let saveProgression = (force) => {
+            let progression = Math.floor(element.currentTime);
+            if (force || Math.abs(progression - lastSavedProgression) >= 10) {
+                lastSavedProgression = progression;
+                saveMediaPlayback(element, {media_progression: progression});
+            }
+        };
+
+        element.addEventListener("timeupdate", () => saveProgression(false));
+        element.addEventListener("pause", () => {
+            if (!element.ended) {
+                saveProgression(true);
+            }
+        });
+        element.addEventListener("ended", () => {
+            lastSavedProgression = 0;
+            saveMediaPlayback(element, {media_progression: 0, played: true});
+        });
+    });
+}
+
+// Fill the annotation form with the passage selected in the entry content.
+function handleAnnotationSelection() {
+    let formElement = document.querySelector("form[data-annotation-form]");
+    let contentElement = document.querySelector(".entry-content");
+    if (!formElement || !contentElement) {
+        return;
+    }
+
+    document.addEventListener("selectionchange", () => {
+        let selection = window.getSelection();
+        if (selection.isCollapsed || selection.rangeCount === 0) {
+            return;
+        }
+
+        let range = selection.getRangeAt(0);
+        if (!contentElement.contains(range.startContainer) || !contentElement.contains(range.endContainer)) {
+            return;
+        }
+
+        // The offsets are positions in the text content of the entry.
+        let prefixRange = document.createRange();
+        prefixRange.selectNodeContents(contentElement);
+        prefixRange.setEnd(range.startContainer, range.startOffset);
+
+        let startOffset = prefixRange.toString().length;
+        let quote = range.toString();
+
+        formElement.elements.quote.value = quote.trim();
+        formElement.elements.start_offset.value = startOffset;
+        formElement.elements.end_offset.value = startOffset + quote.length;
+    });
+}
+
+// Highlight the annotated passages in the entry content.
+function highlightAnnotations() {
+    let contentElement = document.querySelector(".entry-content");
+    if (!contentElement) {
+        return;
+    }
+
+    document.querySelectorAll("[data-annotation-start]").forEach((annotationElement) => {
+        let start = parseInt(annotationElement.dataset.annotationStart, 10) || 0;
+        let end = parseInt(annotationElement.dataset.annotationEnd, 10) || 0;
+        if (end <= start) {
+            return;
+        }
+
+        let walker = document.createTreeWalker(contentElement, NodeFilter.SHOW_TEXT);
+        let textNodes = [];
+        while (walker.nextNode()) {
+            textNodes.push(walker.currentNode);
+        }
+
+        let position = 0;
+        textNodes.forEach((textNode) => {
+            let nodeStart = position;
+            let nodeEnd = position + textNode.length;
+            position = nodeEnd;
+
+            if (nodeEnd <= start || nodeStart >= end || textNode.parentNode.closest("mark.annotation")) {
+                return;
+            }
+
+            let range = document.createRange();
+            range.setStart(textNode, Math.max(start - nodeStart, 0));
+            range.setEnd(textNode, Math.min(end, nodeEnd) - nodeStart);
+
+            let markElement = document.createElement("mark");
+            markElement.className = "annotation";
+            range.surroundContents(markElement);
+        });
+    });
+}
+
+// Move the player to the beginning of a chapter.
+function seekMediaPlayer(element) {
+    let player = document.getElementById(element.dataset.mediaPlayer);
+    if (player) {
+        player.currentTime = parseInt(element.dataset.mediaSeek, 10) || 0;
+        player.play();
+    }
+}
+
+// Send the Ajax request to save the playback state of an attachment.
+function saveMediaPlayback(element, playback) {
+    let request = new RequestBuilder(element.dataset.saveUrl);
+    request.withBody(playback);
+    request.execute();
+}
+
 // Send the Ajax request to download the original web page.
 function handleFetchOriginalContent() {
     if (isListView()) {
@@ -343,6 +470,72 @@ function handleFetchOriginalContent() {
     request.execute();
 }
 
+// Show the first entries extracted from the web page with the CSS selectors of the subscription form.
+function handleScrapedFeedPreview(element) {
+    let form = element.closest("form");
+    let container = form.querySelector(".scraped-feed-preview");
+
+    let body = {};
+    new FormData(form).forEach((value, name) => {
+        if (name !== "csrf") {
+            body[name] = value;
+        }
+    });
+    body.fetch_via_proxy = body.fetch_via_proxy === "1";
+    body.allow_self_signed_certificates = body.allow_self_signed_certificates === "1";
+
+    let previousLabel = element.textContent;
+    element.textContent = element.dataset.labelLoading;
+
+    let request = new RequestBuilder(element.dataset.previewUrl);
+    request.withBody(body);
+    request.withCallback((response) => {
+        element.textContent = previousLabel;
+
+        response.json().then((data) => {
+            container.innerHTML = "";
+
+            let message = document.createElement("p");
+            if (data.hasOwnProperty("error_message")) {
+                message.className = "alert alert-error";
+                message.textContent = data.error_message;
+                container.appendChild(message);
+                return;
+            }
+
+            message.className = "alert alert-success";
+            message.textContent = data.summary;
+            container.appendChild(message);
+
+            let list = document.createElement("ul");
+            data.entries.forEach((entry) => {
+                let link = document.createElement("a");
+                link.href = entry.url;
+                link.target = "_blank";
+                link.rel = "noopener noreferrer";
+                link.textContent = entry.title;
+
+                let date = document.createElement("small");
+                date.textContent = " " + entry.date;
+
+                let item = document.createElement("li");
+                item.appendChild(link);
+                item.appendChild(date);
+
+                if (entry.content) {
+                    let content = document.createElement("p");
+                    content.textContent = entry.content.length > 200 ? entry.content.substring(0, 200) + "…" : entry.content;
+                    item.appendChild(content);
+                }
+
+                list.appendChild(item);
+            });
+            container.appendChild(list);
+        });
+    });
+    request.execute();
+}
+
 function openOriginalLink(openLinkInCurrentTab) {
     let entryLink = document.querySelector(".entry h1 a");
     if (entryLink !== null) {
@@ -515,7 +708,13 @@ function updateUnreadCounterValue(callback) {
     let counterElements = document.querySelectorAll("span.unread-counter");
     counterElements.forEach((element) => {
         let oldValue = parseInt(element.textContent, 10);
-        element.innerHTML = callback(oldValue);
+        let newValue = callback(oldValue);
+        element.innerHTML = newValue;
+
+        let wrapperElement = element.closest(".unread-counter-wrapper");
+        if (wrapperElement) {
+            wrapperElement.hidden = newValue <= 0;
+        }
     });
 
     if (window.location.href.endsWith('/unread')) {
@@ -43,12 +43,39 @@ document.addEventListener("DOMContentLoaded", function () {
     let touchHandler = new TouchHandler();
     touchHandler.listen();
 
+    let eventsElement = document.querySelector("body[data-events-url]");
+    if (eventsElement) {
+        let eventStreamHandler = new EventStreamHandler(eventsElement.dataset.eventsUrl);
+        eventStreamHandler.listen();
+    }
+
     onClick("a[data-save-entry]", (event) => handleSaveEntry(event.target));
     onClick("a[data-toggle-bookmark]", (event) => handleBookmark(event.target));
     onClick("a[data-fetch-content-entry]", () => handleFetchOriginalContent());
     onClick("a[data-action=search]", (event) => setFocusToSearchInput(event));
     onClick("a[data-action=markPageAsRead]", (event) => handleConfirmationMessage(event.target, () => markPageAsRead()));
     onClick("a[data-toggle-status]", (event) => handleEntryStatus("next", event.target));
+    onClick("a[data-action=reloadPage]", () => window.location.reload());
+    onClick("a[data-media-seek]", (event) => seekMediaPlayer(event.target));
+    onClick("a[data-preview-scraped-feed]", (event) => handleScrapedFeedPreview(event.target));
+
+    handleMediaPlayers();
+    highlightAnnotations();
+    handleAnnotationSelection();
+
+    if (WebAuthnHandler.isSupported()) {
+        document.querySelectorAll(".webauthn-login").forEach((element) => element.hidden = false);
+        onClick("button[data-webauthn-login]", (event) => WebAuthnHandler.login(event.target));
+
+        let registerFormElement = document.querySelector("form.webauthn-register");
+        if (registerFormElement) {
+            registerFormElement.hidden = false;
+            registerFormElement.addEventListener("submit", (event) => {
+                event.preventDefault();
+                WebAuthnHandler.register(registerFormElement);
+            });
+        }
+    }
 
     onClick("a[data-confirm]", (event) => handleConfirmationMessage(event.target, (url, redirectURL) => {
         let request = new RequestBuilder(url);
@@ -0,0 +1,45 @@
+class EventStreamHandler {
+    constructor(url) {
+        this.url = url;
+    }
+
+    listen() {
+        if (!("EventSource" in window)) {
+            return;
+        }
+
+        let eventSource = new EventSource(this.url);
+        eventSource.addEventListener("counters", (event) => this.updateCounters(JSON.parse(event.data)));
+        eventSource.addEventListener("new_entries", () => this.showNewEntriesBanner());
+    }
+
+    updateCounters(counters) {
+        updateUnreadCounterValue(() => counters.unread);
+
+        document.querySelectorAll("article[data-feed-id]").forEach((element) => {
+            let feedID = element.dataset.feedId;
+            let unreadCount = counters.unreads[feedID] || 0;
+
+            let unreadElement = element.querySelector(".feed-unread-counter");
+            if (unreadElement) {
+                unreadElement.textContent = unreadCount;
+            }
+
+            let readElement = element.querySelector(".feed-read-counter");
+            if (readElement) {
+                readElement.textContent = counters.reads[feedID] || 0;
+            }
+
+            if (!element.classList.contains("feed-parsing-error")) {
+                element.classList.toggle("feed-has-unread", unreadCount > 0);
+            }
+        });
+    }
+
+    showNewEntriesBanner() {
+        let banner = document.getElementById("new-entries-banner");
+        if (banner) {
+            banner.hidden = false;
+        }
+    }
+}
@@ -0,0 +1,105 @@
+class WebAuthnHandler {
+    static isSupported() {
+        return window.PublicKeyCredential !== undefined && navigator.credentials !== undefined;
+    }
+
+    // The WebAuthn library encodes the binary fields as base64url strings.
+    static decodeBuffer(value) {
+        return Uint8Array.from(atob(value.replace(/-/g, "+").replace(/_/g, "/")), (c) => c.charCodeAt(0));
+    }
+
+    static encodeBuffer(value) {
+        return btoa(String.fromCharCode(...new Uint8Array(value)))
+            .replace(/\+/g, "-")
+            .replace(/\//g, "_")
+            .replace(/=+$/, "");
+    }
+
+    static post(url, body) {
+        let request = new RequestBuilder(url);
+        if (body) {
+            request.withBody(body);
+        }
+
+        return fetch(new Request(request.url, request.options)).then((response) => {
+            if (!response.ok) {
+                throw new Error(response.statusText);
+            }
+            return response;
+        });
+    }
+
+    static showError(element, message) {
+        let errorElement = element.querySelector(".alert-error");
+        if (errorElement === null) {
+            errorElement = document.createElement("div");
+            errorElement.className = "alert alert-error";
+            element.prepend(errorElement);
+        }
+        errorElement.textContent = message;
+    }
+
+    static register(formElement) {
+        let buttonElement = formElement.querySelector("button");
+        let name = formElement.querySelector("input[name=name]").value;
+
+        WebAuthnHandler.post(formElement.dataset.beginUrl)
+            .then((response) => response.json())
+            .then((options) => {
+                options.publicKey.challenge = WebAuthnHandler.decodeBuffer(options.publicKey.challenge);
+                options.publicKey.user.id = WebAuthnHandler.decodeBuffer(options.publicKey.user.id);
+                if (options.publicKey.excludeCredentials) {
+                    options.publicKey.excludeCredentials.forEach((credential) => {
+                        credential.id = WebAuthnHandler.decodeBuffer(credential.id);
+                    });
+                }
+                return navigator.credentials.create(options);
+            })
+            .then((credential) => WebAuthnHandler.post(formElement.dataset.finishUrl + "?name=" + encodeURIComponent(name), {
+                id: credential.id,
+                rawId: WebAuthnHandler.encodeBuffer(credential.rawId),
+                type: credential.type,
+                response: {
+                    clientDataJSON: WebAuthnHandler.encodeBuffer(credential.response.clientDataJSON),
+                    attestationObject: WebAuthnHandler.encodeBuffer(credential.response.attestationObject)
+                }
+            }))
+            .then(() => {
+                window.location.href = formElement.dataset.redirectUrl;
+            })
+            .catch(() => {
+                buttonElement.innerHTML = buttonElement.dataset.label;
+                buttonElement.disabled = false;
+                WebAuthnHandler.showError(formElement, formElement.dataset.labelError);
+            });
+    }
+
+    static login(buttonElement) {
+        buttonElement.disabled = true;
+
+        WebAuthnHandler.post(buttonElement.dataset.beginUrl)
+            .then((response) => response.json())
+            .then((options) => {
+                options.publicKey.challenge = WebAuthnHandler.decodeBuffer(options.publicKey.challenge);
+                return navigator.credentials.get(options);
+            })
+            .then((assertion) => WebAuthnHandler.post(buttonElement.dataset.finishUrl, {
+                id: assertion.id,
+                rawId: WebAuthnHandler.encodeBuffer(assertion.rawId),
+                type: assertion.type,
+                response: {
+                    clientDataJSON: WebAuthnHandler.encodeBuffer(assertion.response.clientDataJSON),
+                    authenticatorData: WebAuthnHandler.encodeBuffer(assertion.response.authenticatorData),
+                    signature: WebAuthnHandler.encodeBuffer(assertion.response.signature),
+                    userHandle: WebAuthnHandler.encodeBuffer(assertion.response.userHandle)
+                }
+            }))
+            .then(() => {
+                window.location.href = buttonElement.dataset.redirectUrl;
+            })
+            .catch(() => {
+                buttonElement.disabled = false;
+                WebAuthnHandler.showError(buttonElement.parentNode, buttonElement.dataset.labelError);
+            });
+    }
+}
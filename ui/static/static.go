@@ -118,6 +118,8 @@ func GenerateJavascriptBundles() error {
 			"js/keyboard_handler.js",
 			"js/request_builder.js",
 			"js/modal_handler.js",
+			"js/event_stream_handler.js",
+			"js/webauthn_handler.js",
 			"js/app.js",
 			"js/bootstrap.js",
 		},
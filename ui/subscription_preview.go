@@ -0,0 +1,131 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	json_parser "encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/http/client"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/json"
+	"miniflux.app/locale"
+	"miniflux.app/reader/browser"
+	"miniflux.app/reader/sanitizer"
+	"miniflux.app/reader/scraper"
+	"miniflux.app/timezone"
+	"miniflux.app/validator"
+)
+
+const scrapedFeedPreviewSize = 10
+
+type scrapedFeedPreviewRequest struct {
+	URL                         string `json:"url"`
+	UserAgent                   string `json:"user_agent"`
+	Cookie                      string `json:"cookie"`
+	Username                    string `json:"feed_username"`
+	Password                    string `json:"feed_password"`
+	FetchViaProxy               bool   `json:"fetch_via_proxy"`
+	AllowSelfSignedCertificates bool   `json:"allow_self_signed_certificates"`
+	ItemSelector                string `json:"item_selector"`
+	TitleSelector               string `json:"title_selector"`
+	LinkSelector                string `json:"link_selector"`
+	DateSelector                string `json:"date_selector"`
+	ContentSelector             string `json:"content_selector"`
+}
+
+type scrapedFeedPreviewEntry struct {
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	Date    string `json:"date"`
+	Content string `json:"content"`
+}
+
+// previewScrapedFeed returns the first entries extracted from a web page, without creating the feed.
+func (h *handler) previewScrapedFeed(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	printer := locale.NewPrinter(user.Language)
+
+	var previewRequest scrapedFeedPreviewRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&previewRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if !validator.IsValidURL(previewRequest.URL) {
+		json.BadRequest(w, r, errors.New(printer.Printf("error.invalid_feed_url")))
+		return
+	}
+
+	selectors := &scraper.FeedSelectors{
+		Item:    previewRequest.ItemSelector,
+		Title:   previewRequest.TitleSelector,
+		Link:    previewRequest.LinkSelector,
+		Date:    previewRequest.DateSelector,
+		Content: previewRequest.ContentSelector,
+	}
+
+	for _, selector := range []string{selectors.Item, selectors.Title, selectors.Link, selectors.Date, selectors.Content} {
+		if !validator.IsValidSelector(selector) {
+			json.BadRequest(w, r, errors.New(printer.Printf("error.feed_invalid_selector")))
+			return
+		}
+	}
+
+	if selectors.Item == "" {
+		json.BadRequest(w, r, errors.New(printer.Printf("error.scraped_feed_item_selector_required")))
+		return
+	}
+
+	clt := client.NewClientWithConfig(previewRequest.URL, config.Opts)
+	clt.WithCredentials(previewRequest.Username, previewRequest.Password)
+	clt.WithUserAgent(previewRequest.UserAgent)
+	clt.WithCookie(previewRequest.Cookie)
+	clt.AllowSelfSignedCertificates = previewRequest.AllowSelfSignedCertificates
+
+	if previewRequest.FetchViaProxy {
+		clt.WithProxy()
+	}
+
+	response, requestErr := browser.Exec(clt)
+	if requestErr != nil {
+		json.BadRequest(w, r, errors.New(requestErr.Localize(printer)))
+		return
+	}
+
+	feed, parseErr := scraper.ParseFeed(response.EffectiveURL, response.BodyAsString(), selectors)
+	if parseErr != nil {
+		json.BadRequest(w, r, errors.New(parseErr.Localize(printer)))
+		return
+	}
+
+	entries := make([]*scrapedFeedPreviewEntry, 0, scrapedFeedPreviewSize)
+	for _, entry := range feed.Entries {
+		if len(entries) == scrapedFeedPreviewSize {
+			break
+		}
+
+		entries = append(entries, &scrapedFeedPreviewEntry{
+			Title:   entry.Title,
+			URL:     entry.URL,
+			Date:    timezone.Convert(user.Timezone, entry.Date).Format(time.RFC1123),
+			Content: strings.Join(strings.Fields(sanitizer.StripTags(entry.Content)), " "),
+		})
+	}
+
+	json.OK(w, r, map[string]interface{}{
+		"summary": printer.Plural("page.add_feed.scraped_feed_preview", len(feed.Entries), feed.Title, len(feed.Entries)),
+		"entries": entries,
+	})
+}
@@ -43,6 +43,7 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 	v.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
 	v.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
 	v.Set("hasProxyConfigured", config.Opts.HasHTTPClientProxyConfigured())
+	v.Set("hasNewsletterService", config.Opts.HasNewsletterService())
 
 	subscriptionForm := form.NewSubscriptionForm(r)
 	if err := subscriptionForm.Validate(); err != nil {
@@ -52,6 +53,41 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Web pages without feed are scraped with the CSS selectors provided by the user.
+	if subscriptionForm.ItemSelector != "" {
+		feed, err := feedHandler.CreateFeed(h.store, user.ID, &model.FeedCreationRequest{
+			CategoryID:                  subscriptionForm.CategoryID,
+			FeedURL:                     subscriptionForm.URL,
+			Crawler:                     subscriptionForm.Crawler,
+			AllowSelfSignedCertificates: subscriptionForm.AllowSelfSignedCertificates,
+			UserAgent:                   subscriptionForm.UserAgent,
+			Cookie:                      subscriptionForm.Cookie,
+			Username:                    subscriptionForm.Username,
+			Password:                    subscriptionForm.Password,
+			ScraperRules:                subscriptionForm.ScraperRules,
+			RewriteRules:                subscriptionForm.RewriteRules,
+			BlocklistRules:              subscriptionForm.BlocklistRules,
+			KeeplistRules:               subscriptionForm.KeeplistRules,
+			UrlRewriteRules:             subscriptionForm.UrlRewriteRules,
+			FetchViaProxy:               subscriptionForm.FetchViaProxy,
+			ItemSelector:                subscriptionForm.ItemSelector,
+			TitleSelector:               subscriptionForm.TitleSelector,
+			LinkSelector:                subscriptionForm.LinkSelector,
+			DateSelector:                subscriptionForm.DateSelector,
+			ContentSelector:             subscriptionForm.ContentSelector,
+		})
+		if err != nil {
+			logger.Error("[UI:SubmitSubscription] %q -> %s", subscriptionForm.URL, err)
+			v.Set("form", subscriptionForm)
+			v.Set("errorMessage", err)
+			html.OK(w, r, v.Render("add_subscription"))
+			return
+		}
+
+		html.Redirect(w, r, route.Path(h.router, "feedEntries", "feedID", feed.ID))
+		return
+	}
+
 	subscriptions, findErr := subscription.FindSubscriptions(
 		subscriptionForm.URL,
 		subscriptionForm.UserAgent,
@@ -111,6 +147,7 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 		v.Set("countUnread", h.store.CountUnreadEntries(user.ID))
 		v.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
 		v.Set("hasProxyConfigured", config.Opts.HasHTTPClientProxyConfigured())
+		v.Set("hasNewsletterService", config.Opts.HasNewsletterService())
 
 		html.OK(w, r, v.Render("choose_subscription"))
 	}
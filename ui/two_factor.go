@@ -0,0 +1,118 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"image/png"
+	"strings"
+	"time"
+
+	"miniflux.app/crypto"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	twoFactorIssuer          = "Miniflux"
+	twoFactorPeriod          = 30
+	twoFactorMaxAttempts     = 5
+	twoFactorLockDuration    = 15 * time.Minute
+	twoFactorRecoveryCodes   = 10
+	twoFactorQRCodeImageSize = 200
+)
+
+// generateTwoFactorKey returns a new TOTP secret for the given account.
+func generateTwoFactorKey(username string) (*otp.Key, error) {
+	return totp.Generate(totp.GenerateOpts{
+		Issuer:      twoFactorIssuer,
+		AccountName: username,
+	})
+}
+
+// twoFactorKeyFromSecret rebuilds the key shown during the enrollment from the stored secret.
+func twoFactorKeyFromSecret(username, secret string) (*otp.Key, error) {
+	secretBytes, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return totp.Generate(totp.GenerateOpts{
+		Issuer:      twoFactorIssuer,
+		AccountName: username,
+		Secret:      secretBytes,
+	})
+}
+
+// twoFactorQRCode returns the otpauth:// URL of the key as a PNG image embedded in a data URL.
+func twoFactorQRCode(key *otp.Key) (string, error) {
+	image, err := key.Image(twoFactorQRCodeImageSize, twoFactorQRCodeImageSize)
+	if err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, image); err != nil {
+		return "", err
+	}
+
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buffer.Bytes()), nil
+}
+
+// validateTwoFactorCode checks a six digits code and consumes it, a code cannot be used twice.
+func (h *handler) validateTwoFactorCode(userID int64, secret, code string) (bool, error) {
+	timeStep, valid := twoFactorCodeTimeStep(secret, code, time.Now())
+	if !valid {
+		return false, nil
+	}
+
+	// The codes of the previous and next periods are accepted, the codes of the time step
+	// already used and of the ones before are rejected to prevent replays.
+	return h.store.UseTwoFactorTimeStep(userID, timeStep)
+}
+
+// twoFactorCodeTimeStep returns the time step of a valid code, the codes of the previous and next periods are accepted to tolerate clock drift.
+func twoFactorCodeTimeStep(secret, code string, now time.Time) (int64, bool) {
+	if secret == "" {
+		return 0, false
+	}
+
+	code = strings.ReplaceAll(code, " ", "")
+	currentTimeStep := now.Unix() / twoFactorPeriod
+	for _, timeStep := range []int64{currentTimeStep - 1, currentTimeStep, currentTimeStep + 1} {
+		valid, err := totp.ValidateCustom(code, secret, time.Unix(timeStep*twoFactorPeriod, 0).UTC(), totp.ValidateOpts{
+			Period:    twoFactorPeriod,
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err == nil && valid {
+			return timeStep, true
+		}
+	}
+
+	return 0, false
+}
+
+// generateRecoveryCodes returns single-use codes formatted as "xxxxx-xxxxx".
+func generateRecoveryCodes() []string {
+	codes := make([]string, 0, twoFactorRecoveryCodes)
+	for i := 0; i < twoFactorRecoveryCodes; i++ {
+		code := crypto.GenerateRandomStringHex(5)
+		codes = append(codes, code[:5]+"-"+code[5:])
+	}
+	return codes
+}
+
+// normalizeRecoveryCode accepts recovery codes typed in uppercase, with spaces or without the dash.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.Join(strings.Fields(code), ""))
+	if len(code) == 10 && !strings.Contains(code, "-") {
+		code = code[:5] + "-" + code[5:]
+	}
+	return code
+}
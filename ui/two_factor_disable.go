@@ -0,0 +1,67 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+	"strings"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/locale"
+	"miniflux.app/logger"
+	"miniflux.app/ui/session"
+)
+
+// disableTwoFactor removes the second step of the login, a valid code is required to make sure the user still owns the device.
+func (h *handler) disableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	secret, enabled, err := h.store.TwoFactorSecret(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !enabled {
+		html.Redirect(w, r, route.Path(h.router, "twoFactor"))
+		return
+	}
+
+	code := strings.TrimSpace(r.FormValue("code"))
+	valid, err := h.validateTwoFactorCode(user.ID, secret, code)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !valid && code != "" {
+		if valid, err = h.store.UseRecoveryCode(user.ID, normalizeRecoveryCode(code)); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	if !valid {
+		h.renderTwoFactorPage(w, r, user, "error.invalid_two_factor_code")
+		return
+	}
+
+	if err := h.store.DisableTwoFactor(user.ID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	logger.Info("[UI:DisableTwoFactor] username=%s disabled two-factor authentication", user.Username)
+
+	sess := session.New(h.store, request.SessionID(r))
+	sess.NewFlashMessage(locale.NewPrinter(request.UserLanguage(r)).Printf("alert.two_factor_disabled"))
+	html.Redirect(w, r, route.Path(h.router, "twoFactor"))
+}
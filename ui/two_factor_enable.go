@@ -0,0 +1,54 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/logger"
+)
+
+// enableTwoFactor confirms the enrollment with a code generated by the authenticator application.
+func (h *handler) enableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	secret, enabled, err := h.store.TwoFactorSecret(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if enabled {
+		html.Redirect(w, r, route.Path(h.router, "twoFactor"))
+		return
+	}
+
+	valid, err := h.validateTwoFactorCode(user.ID, secret, r.FormValue("code"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !valid {
+		h.renderTwoFactorPage(w, r, user, "error.invalid_two_factor_code")
+		return
+	}
+
+	recoveryCodes := generateRecoveryCodes()
+	if err := h.store.EnableTwoFactor(user.ID, recoveryCodes); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	logger.Info("[UI:EnableTwoFactor] username=%s enabled two-factor authentication", user.Username)
+	h.renderRecoveryCodesPage(w, r, user, recoveryCodes)
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/model"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+// regenerateRecoveryCodes replaces all the recovery codes, including the unused ones.
+func (h *handler) regenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !h.store.HasTwoFactor(user.ID) {
+		html.Redirect(w, r, route.Path(h.router, "twoFactor"))
+		return
+	}
+
+	recoveryCodes := generateRecoveryCodes()
+	if err := h.store.ReplaceRecoveryCodes(user.ID, recoveryCodes); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	h.renderRecoveryCodesPage(w, r, user, recoveryCodes)
+}
+
+// renderRecoveryCodesPage shows the recovery codes, only their hash is stored so they cannot be displayed again.
+func (h *handler) renderRecoveryCodesPage(w http.ResponseWriter, r *http.Request, user *model.User, recoveryCodes []string) {
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("recoveryCodes", recoveryCodes)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	view.Set("menuSavedSearches", h.store.MenuSavedSearches(user.ID))
+
+	html.OK(w, r, view.Render("two_factor_recovery_codes"))
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/model"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+func (h *handler) showTwoFactorPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	h.renderTwoFactorPage(w, r, user, "")
+}
+
+// renderTwoFactorPage shows the enrollment form, or the recovery codes status once the two-factor authentication is enabled.
+//
+// The secret generated for the enrollment is kept until the user confirms it with a valid code.
+func (h *handler) renderTwoFactorPage(w http.ResponseWriter, r *http.Request, user *model.User, errorMessage string) {
+	secret, enabled, err := h.store.TwoFactorSecret(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("twoFactorEnabled", enabled)
+	view.Set("errorMessage", errorMessage)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	view.Set("menuSavedSearches", h.store.MenuSavedSearches(user.ID))
+
+	if enabled {
+		count, err := h.store.CountRecoveryCodes(user.ID)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		view.Set("recoveryCodesCount", count)
+		html.OK(w, r, view.Render("two_factor"))
+		return
+	}
+
+	key, err := twoFactorKeyFromSecret(user.Username, secret)
+	if secret == "" || err != nil {
+		if key, err = generateTwoFactorKey(user.Username); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		if err := h.store.SetPendingTwoFactorSecret(user.ID, key.Secret()); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	qrCode, err := twoFactorQRCode(key)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	view.Set("twoFactorSecret", key.Secret())
+	view.Set("twoFactorQRCode", qrCode)
+	html.OK(w, r, view.Render("two_factor"))
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+)
+
+const testTwoFactorSecret = "JBSWY3DPEHPK3PXP"
+
+func TestTwoFactorCodeTimeStep(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	currentTimeStep := now.Unix() / twoFactorPeriod
+
+	scenarios := map[time.Duration]bool{
+		-2 * twoFactorPeriod * time.Second: false,
+		-1 * twoFactorPeriod * time.Second: true,
+		0:                                  true,
+		twoFactorPeriod * time.Second:      true,
+		2 * twoFactorPeriod * time.Second:  false,
+	}
+
+	for offset, expected := range scenarios {
+		codeTime := now.Add(offset)
+		code, err := totp.GenerateCode(testTwoFactorSecret, codeTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		timeStep, valid := twoFactorCodeTimeStep(testTwoFactorSecret, code, now)
+		if valid != expected {
+			t.Errorf(`Unexpected validation for a code generated %v from now, got %v`, offset, valid)
+		}
+
+		if valid && timeStep != currentTimeStep+int64(offset/time.Second)/twoFactorPeriod {
+			t.Errorf(`Unexpected time step for a code generated %v from now, got %d`, offset, timeStep)
+		}
+	}
+}
+
+func TestTwoFactorCodeTimeStepWithSpaces(t *testing.T) {
+	now := time.Now()
+	code, _ := totp.GenerateCode(testTwoFactorSecret, now)
+
+	if _, valid := twoFactorCodeTimeStep(testTwoFactorSecret, code[:3]+" "+code[3:], now); !valid {
+		t.Fatal(`The spaces in the code should be ignored`)
+	}
+}
+
+func TestTwoFactorCodeTimeStepWithInvalidCode(t *testing.T) {
+	now := time.Now()
+	code, _ := totp.GenerateCode(testTwoFactorSecret, now)
+
+	for _, input := range []string{"", "abcdef", "1234567", code[:5]} {
+		if _, valid := twoFactorCodeTimeStep(testTwoFactorSecret, input, now); valid {
+			t.Errorf(`The code %q should be rejected`, input)
+		}
+	}
+
+	if _, valid := twoFactorCodeTimeStep("", code, now); valid {
+		t.Error(`The codes should be rejected without secret`)
+	}
+}
+
+func TestTwoFactorKeyFromSecret(t *testing.T) {
+	key, err := generateTwoFactorKey("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rebuiltKey, err := twoFactorKeyFromSecret("alice", key.Secret())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rebuiltKey.Secret() != key.Secret() || rebuiltKey.AccountName() != "alice" || rebuiltKey.Issuer() != twoFactorIssuer {
+		t.Fatalf(`The key should be rebuilt from the secret, got %q`, rebuiltKey.URL())
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes := generateRecoveryCodes()
+	if len(codes) != twoFactorRecoveryCodes {
+		t.Fatalf(`Unexpected number of recovery codes, got %d`, len(codes))
+	}
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if !regexp.MustCompile(`^[0-9a-f]{5}-[0-9a-f]{5}$`).MatchString(code) {
+			t.Errorf(`Unexpected recovery code format, got %q`, code)
+		}
+
+		if seen[code] {
+			t.Errorf(`The recovery codes should be unique, got %q twice`, code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	scenarios := map[string]string{
+		"abcde-01234":   "abcde-01234",
+		"ABCDE-01234":   "abcde-01234",
+		"abcde01234":    "abcde-01234",
+		" abcde 01234 ": "abcde-01234",
+		"abc":           "abc",
+	}
+
+	for input, expected := range scenarios {
+		if result := normalizeRecoveryCode(input); result != expected {
+			t.Errorf(`Unexpected recovery code for %q, got %q instead of %q`, input, result, expected)
+		}
+	}
+}
@@ -41,7 +41,10 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	// New subscription pages.
 	uiRouter.HandleFunc("/subscribe", handler.showAddSubscriptionPage).Name("addSubscription").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/subscribe", handler.submitSubscription).Name("submitSubscription").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/subscribe/preview", handler.previewScrapedFeed).Name("previewScrapedFeed").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/subscriptions", handler.showChooseSubscriptionPage).Name("chooseSubscription").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/newsletter/create", handler.showCreateNewsletterPage).Name("createNewsletter").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/newsletter/save", handler.saveNewsletter).Name("saveNewsletter").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/bookmarklet", handler.bookmarklet).Name("bookmarklet").Methods(http.MethodGet)
 
 	// Unread page.
@@ -65,12 +68,15 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	// Feed listing pages.
 	uiRouter.HandleFunc("/feeds", handler.showFeedsPage).Name("feeds").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feeds/refresh", handler.refreshAllFeeds).Name("refreshAllFeeds").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/feeds/health", handler.showFeedHealthPage).Name("feedHealth").Methods(http.MethodGet)
 
 	// Individual feed pages.
 	uiRouter.HandleFunc("/feed/{feedID}/refresh", handler.refreshFeed).Name("refreshFeed").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feed/{feedID}/edit", handler.showEditFeedPage).Name("editFeed").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feed/{feedID}/remove", handler.removeFeed).Name("removeFeed").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/feed/{feedID}/update", handler.updateFeed).Name("updateFeed").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/feed/{feedID}/rediscover", handler.rediscoverFeed).Name("rediscoverFeed").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/feed/{feedID}/migrate", handler.migrateFeed).Name("migrateFeed").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/feed/{feedID}/entries", handler.showFeedEntriesPage).Name("feedEntries").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feed/{feedID}/entries/all", handler.showFeedEntriesAllPage).Name("feedEntriesAll").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feed/{feedID}/entry/{entryID}", handler.showFeedEntryPage).Name("feedEntry").Methods(http.MethodGet)
@@ -92,18 +98,47 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/category/{categoryID}/remove", handler.removeCategory).Name("removeCategory").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/category/{categoryID}/mark-all-as-read", handler.markCategoryAsRead).Name("markCategoryAsRead").Methods(http.MethodPost)
 
+	// Tag pages.
+	uiRouter.HandleFunc("/tags", handler.showTagListPage).Name("tags").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/tags/entries", handler.showTagEntriesByTitle).Name("tagEntriesByTitle").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/tag/{tagID}/entries", handler.showTagEntriesPage).Name("tagEntries").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/tag/{tagID}/entry/{entryID}", handler.showTagEntryPage).Name("tagEntry").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/tag/{tagID}/remove", handler.removeTag).Name("removeTag").Methods(http.MethodPost)
+
+	// Saved search pages.
+	uiRouter.HandleFunc("/saved-searches", handler.showSavedSearchListPage).Name("savedSearches").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/saved-search/create", handler.showCreateSavedSearchPage).Name("createSavedSearch").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/saved-search/save", handler.saveSavedSearch).Name("saveSavedSearch").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/saved-search/{searchID}/entries", handler.showSavedSearchEntriesPage).Name("savedSearchEntries").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/saved-search/{searchID}/entry/{entryID}", handler.showSavedSearchEntryPage).Name("savedSearchEntry").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/saved-search/{searchID}/edit", handler.showEditSavedSearchPage).Name("editSavedSearch").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/saved-search/{searchID}/update", handler.updateSavedSearch).Name("updateSavedSearch").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/saved-search/{searchID}/remove", handler.removeSavedSearch).Name("removeSavedSearch").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/saved-search/{searchID}/mark-all-as-read", handler.markSavedSearchAsRead).Name("markSavedSearchAsRead").Methods(http.MethodPost)
+
 	// Entry pages.
 	uiRouter.HandleFunc("/entry/status", handler.updateEntriesStatus).Name("updateEntriesStatus").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/entry/save/{entryID}", handler.saveEntry).Name("saveEntry").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/entry/download/{entryID}", handler.fetchContent).Name("fetchContent").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/proxy/{encodedDigest}/{encodedURL}", handler.imageProxy).Name("proxy").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/media/{entryID}/{encodedDigest}/{encodedURL}", handler.mediaProxy).Name("mediaProxy").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/entry/bookmark/{entryID}", handler.toggleBookmark).Name("toggleBookmark").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/enclosure/{enclosureID}/playback", handler.updateEnclosurePlayback).Name("updateEnclosurePlayback").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entry/tags/{entryID}", handler.showEditEntryTagsPage).Name("editEntryTags").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/entry/tags/{entryID}", handler.updateEntryTags).Name("updateEntryTags").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entry/annotations/{entryID}", handler.createAnnotation).Name("createAnnotation").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/annotation/{annotationID}/remove", handler.removeAnnotation).Name("removeAnnotation").Methods(http.MethodPost)
 
 	// Share pages.
 	uiRouter.HandleFunc("/entry/share/{entryID}", handler.createSharedEntry).Name("shareEntry").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/entry/unshare/{entryID}", handler.unshareEntry).Name("unshareEntry").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/share/{shareCode}", handler.sharedEntry).Name("sharedEntry").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/shares", handler.sharedEntries).Name("sharedEntries").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/shares/public/enable", handler.enableSharedEntriesPage).Name("enableSharedEntriesPage").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/shares/public/disable", handler.disableSharedEntriesPage).Name("disableSharedEntriesPage").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/shares/{code:[a-f0-9]+}", handler.showPublicSharedEntriesPage).Name("publicSharedEntries").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/entry/share/{entryID}/comment", handler.showEditShareCommentPage).Name("editShareComment").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/entry/share/{entryID}/comment", handler.updateShareComment).Name("updateShareComment").Methods(http.MethodPost)
 
 	// User pages.
 	uiRouter.HandleFunc("/users", handler.showUsersPage).Name("users").Methods(http.MethodGet)
@@ -122,6 +157,22 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/integration/pocket/callback", handler.pocketCallback).Name("pocketCallback").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/about", handler.showAboutPage).Name("about").Methods(http.MethodGet)
 
+	// Two-factor authentication pages.
+	uiRouter.HandleFunc("/login/two-factor", handler.showTwoFactorLoginPage).Name("twoFactorLogin").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/login/two-factor", handler.checkTwoFactorLogin).Name("checkTwoFactorLogin").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/settings/two-factor", handler.showTwoFactorPage).Name("twoFactor").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/settings/two-factor/enable", handler.enableTwoFactor).Name("enableTwoFactor").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/settings/two-factor/disable", handler.disableTwoFactor).Name("disableTwoFactor").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/settings/two-factor/recovery-codes", handler.regenerateRecoveryCodes).Name("regenerateRecoveryCodes").Methods(http.MethodPost)
+
+	// WebAuthn pages.
+	uiRouter.HandleFunc("/settings/passkeys", handler.showWebAuthnCredentialsPage).Name("webAuthnCredentials").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/settings/passkeys/{credentialID}/remove", handler.removeWebAuthnCredential).Name("removeWebAuthnCredential").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/webauthn/register/begin", handler.beginWebAuthnRegistration).Name("webAuthnRegisterBegin").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/webauthn/register/finish", handler.finishWebAuthnRegistration).Name("webAuthnRegisterFinish").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/webauthn/login/begin", handler.beginWebAuthnLogin).Name("webAuthnLoginBegin").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/webauthn/login/finish", handler.finishWebAuthnLogin).Name("webAuthnLoginFinish").Methods(http.MethodPost)
+
 	// Session pages.
 	uiRouter.HandleFunc("/sessions", handler.showSessionsPage).Name("sessions").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/sessions/{sessionID}/remove", handler.removeSession).Name("removeSession").Methods(http.MethodPost)
@@ -132,6 +183,11 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/keys/create", handler.showCreateAPIKeyPage).Name("createAPIKey").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/keys/save", handler.saveAPIKey).Name("saveAPIKey").Methods(http.MethodPost)
 
+	// Feed outputs pages.
+	uiRouter.HandleFunc("/outputs", handler.showFeedOutputsPage).Name("feedOutputs").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/outputs/save", handler.saveFeedOutput).Name("saveFeedOutput").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/outputs/{outputID}/remove", handler.removeFeedOutput).Name("removeFeedOutput").Methods(http.MethodPost)
+
 	// OPML pages.
 	uiRouter.HandleFunc("/export", handler.exportFeeds).Name("export").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/import", handler.showImportPage).Name("import").Methods(http.MethodGet)
@@ -143,6 +199,9 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/oauth2/{provider}/redirect", handler.oauth2Redirect).Name("oauth2Redirect").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/oauth2/{provider}/callback", handler.oauth2Callback).Name("oauth2Callback").Methods(http.MethodGet)
 
+	// Real-time events.
+	uiRouter.HandleFunc("/events", handler.streamEvents).Name("events").Methods(http.MethodGet)
+
 	// Offline page
 	uiRouter.HandleFunc("/offline", handler.showOfflinePage).Name("offline").Methods(http.MethodGet)
 
@@ -151,6 +210,14 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/logout", handler.logout).Name("logout").Methods(http.MethodGet)
 	uiRouter.Handle("/", middleware.handleAuthProxy(http.HandlerFunc(handler.showLoginPage))).Name("login").Methods(http.MethodGet)
 
+	// Feed outputs are authenticated by their token, without user session.
+	router.HandleFunc("/feeds/starred.{format}", handler.showFeedOutput).Name("starredFeedOutput").Methods(http.MethodGet)
+	router.HandleFunc("/feeds/category/{categoryID:[0-9]+}.{format}", handler.showFeedOutput).Name("categoryFeedOutput").Methods(http.MethodGet)
+	router.HandleFunc("/feeds/search/{searchID:[0-9]+}.{format}", handler.showFeedOutput).Name("savedSearchFeedOutput").Methods(http.MethodGet)
+
+	// The feed of the public shared entries page doesn't need a session either.
+	router.HandleFunc("/shares/{code:[a-f0-9]+}.atom", handler.showPublicSharedEntriesFeed).Name("publicSharedEntriesFeed").Methods(http.MethodGet)
+
 	router.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain")
 		w.Write([]byte("User-agent: *\nDisallow: /"))
@@ -0,0 +1,99 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+	"strings"
+
+	"miniflux.app/config"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+)
+
+// isAllowedByGroups returns true if the user is a member of one of the required groups, or if no group is required.
+func isAllowedByGroups(groups []string) bool {
+	requiredGroups := config.Opts.AuthRequiredGroups()
+	return len(requiredGroups) == 0 || hasCommonGroup(groups, requiredGroups)
+}
+
+// checkUserGroups checks the groups received from the identity provider, the result is kept for the logins without groups.
+//
+// The sessions of an existing user are removed when the groups are not allowed anymore.
+func checkUserGroups(store *storage.Storage, user *model.User, groups []string) (bool, error) {
+	if len(config.Opts.AuthRequiredGroups()) == 0 {
+		return true, nil
+	}
+
+	allowed := isAllowedByGroups(groups)
+	if user != nil {
+		if err := store.SetDeniedByGroups(user.ID, !allowed); err != nil {
+			return false, err
+		}
+	}
+
+	return allowed, nil
+}
+
+// isDeniedByGroups returns true if the user cannot log in with a password or a passkey because
+// the last groups received from the identity provider were not allowed.
+func isDeniedByGroups(store *storage.Storage, userID int64) bool {
+	return len(config.Opts.AuthRequiredGroups()) > 0 && store.IsDeniedByGroups(userID)
+}
+
+// applyAdminGroups grants or removes the administrator role according to the groups of the user.
+func applyAdminGroups(store *storage.Storage, user *model.User, groups []string) error {
+	adminGroups := config.Opts.AuthAdminGroups()
+	if len(adminGroups) == 0 {
+		return nil
+	}
+
+	isAdmin := hasCommonGroup(groups, adminGroups)
+	if user.IsAdmin == isAdmin {
+		return nil
+	}
+
+	if err := store.SetAdmin(user.ID, isAdmin); err != nil {
+		return err
+	}
+
+	logger.Info("[UI:Groups] username=%s is_admin=%v according to the groups %v", user.Username, isAdmin, groups)
+	user.IsAdmin = isAdmin
+	return nil
+}
+
+// isDeniedByProxyGroups returns true if the request comes from the authentication proxy with groups not allowed to log in.
+func isDeniedByProxyGroups(r *http.Request) bool {
+	proxyHeader := config.Opts.AuthProxyHeader()
+	groupsHeader := config.Opts.AuthProxyGroupsHeader()
+	if proxyHeader == "" || groupsHeader == "" || r.Header.Get(proxyHeader) == "" {
+		return false
+	}
+
+	return !isAllowedByGroups(authProxyGroups(r.Header.Get(groupsHeader)))
+}
+
+// authProxyGroups splits the comma-separated groups sent by the authentication proxy.
+func authProxyGroups(value string) []string {
+	groups := []string{}
+	for _, group := range strings.Split(value, ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+func hasCommonGroup(groups, wantedGroups []string) bool {
+	for _, group := range groups {
+		for _, wantedGroup := range wantedGroups {
+			if group == wantedGroup {
+				return true
+			}
+		}
+	}
+	return false
+}
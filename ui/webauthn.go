@@ -0,0 +1,101 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+
+	"miniflux.app/config"
+	"miniflux.app/http/request"
+	"miniflux.app/model"
+	"miniflux.app/ui/session"
+)
+
+const webAuthnHandleSize = 32
+
+// webAuthnUser adapts a user to the interface of the WebAuthn library.
+//
+// The handle is the user ID seen by the authenticator, each credential gets a random one.
+type webAuthnUser struct {
+	user        *model.User
+	handle      []byte
+	credentials model.WebAuthnCredentials
+}
+
+func (u *webAuthnUser) WebAuthnID() []byte {
+	return u.handle
+}
+
+func (u *webAuthnUser) WebAuthnName() string {
+	return u.user.Username
+}
+
+func (u *webAuthnUser) WebAuthnDisplayName() string {
+	return u.user.Username
+}
+
+func (u *webAuthnUser) WebAuthnIcon() string {
+	return ""
+}
+
+func (u *webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, 0, len(u.credentials))
+	for _, credential := range u.credentials {
+		credentials = append(credentials, credential.Credential)
+	}
+	return credentials
+}
+
+// newWebAuthn returns a relying party bound to the hostname and the origin of the base URL.
+func newWebAuthn() (*webauthn.WebAuthn, error) {
+	rootURL, err := url.Parse(config.Opts.RootURL())
+	if err != nil {
+		return nil, fmt.Errorf("webauthn: invalid base URL: %v", err)
+	}
+
+	// A passkey replaces the password and the second factor, the authenticator must always verify the user.
+	return webauthn.New(&webauthn.Config{
+		RPDisplayName: "Miniflux",
+		RPID:          rootURL.Hostname(),
+		RPOrigins:     []string{rootURL.Scheme + "://" + rootURL.Host},
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			UserVerification: protocol.VerificationRequired,
+		},
+	})
+}
+
+func saveWebAuthnSession(sess *session.Session, sessionData *webauthn.SessionData) error {
+	data, err := json.Marshal(sessionData)
+	if err != nil {
+		return fmt.Errorf("webauthn: unable to encode session data: %v", err)
+	}
+
+	sess.SetWebAuthnSession(string(data))
+	return nil
+}
+
+// webAuthnSession returns the state saved when the ceremony started, it can be used only once.
+func webAuthnSession(r *http.Request, sess *session.Session) (*webauthn.SessionData, error) {
+	data := request.WebAuthnSession(r)
+	if data == "" {
+		return nil, errors.New("webauthn: no ongoing ceremony")
+	}
+
+	sess.SetWebAuthnSession("")
+
+	var sessionData webauthn.SessionData
+	if err := json.Unmarshal([]byte(data), &sessionData); err != nil {
+		return nil, fmt.Errorf("webauthn: unable to decode session data: %v", err)
+	}
+
+	return &sessionData, nil
+}
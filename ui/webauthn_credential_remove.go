@@ -0,0 +1,24 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/logger"
+)
+
+func (h *handler) removeWebAuthnCredential(w http.ResponseWriter, r *http.Request) {
+	credentialID := request.RouteInt64Param(r, "credentialID")
+	err := h.store.RemoveWebAuthnCredential(request.UserID(r), credentialID)
+	if err != nil {
+		logger.Error("[UI:RemoveWebAuthnCredential] %v", err)
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "webAuthnCredentials"))
+}
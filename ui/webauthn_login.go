@@ -0,0 +1,154 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+
+	"miniflux.app/config"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/json"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+	"miniflux.app/ui/session"
+)
+
+func (h *handler) beginWebAuthnLogin(w http.ResponseWriter, r *http.Request) {
+	if !config.Opts.WebAuthn() {
+		json.NotFound(w, r)
+		return
+	}
+
+	relyingParty, err := newWebAuthn()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	options, sessionData, err := relyingParty.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if err := saveWebAuthnSession(session.New(h.store, request.SessionID(r)), sessionData); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, options)
+}
+
+func (h *handler) finishWebAuthnLogin(w http.ResponseWriter, r *http.Request) {
+	if !config.Opts.WebAuthn() {
+		json.NotFound(w, r)
+		return
+	}
+
+	clientIP := request.ClientIP(r)
+	sess := session.New(h.store, request.SessionID(r))
+	sessionData, err := webAuthnSession(r, sess)
+	if err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	parsedResponse, err := protocol.ParseCredentialRequestResponse(r)
+	if err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	relyingParty, err := newWebAuthn()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	user, _, err := validateWebAuthnLogin(h.store, relyingParty, sessionData, parsedResponse)
+	if err != nil {
+		logger.Error("[UI:WebAuthn] [ClientIP=%s] Passkey login failed: %v", clientIP, err)
+		json.Unauthorized(w, r)
+		return
+	}
+
+	if isDeniedByGroups(h.store, user.ID) {
+		logger.Error("[UI:WebAuthn] [ClientIP=%s] username=%s is not a member of the required groups", clientIP, user.Username)
+		json.Forbidden(w, r)
+		return
+	}
+
+	logger.Info("[UI:WebAuthn] [ClientIP=%s] username=%s logged in with a passkey", clientIP, user.Username)
+
+	if err := h.createUserSession(w, r, sess, user); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
+// validateWebAuthnLogin verifies the assertion of the authenticator and returns the user of the credential.
+//
+// A signature counter going backward means the authenticator may be cloned: the credential is disabled
+// and refused until the user registers it again.
+func validateWebAuthnLogin(store *storage.Storage, relyingParty *webauthn.WebAuthn, sessionData *webauthn.SessionData, parsedResponse *protocol.ParsedCredentialAssertionData) (*model.User, *model.WebAuthnCredential, error) {
+	var user *model.User
+	var webAuthnCredential *model.WebAuthnCredential
+
+	// The user handle sent by the authenticator identifies the credential, and therefore the user.
+	findUser := func(rawID, userHandle []byte) (webauthn.User, error) {
+		var err error
+		webAuthnCredential, err = store.WebAuthnCredentialByHandle(userHandle)
+		if err != nil {
+			return nil, err
+		}
+
+		if webAuthnCredential == nil {
+			return nil, errors.New("webauthn: unknown credential")
+		}
+
+		if webAuthnCredential.Credential.Authenticator.CloneWarning {
+			return nil, errors.New("webauthn: disabled credential")
+		}
+
+		user, err = store.UserByID(webAuthnCredential.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		if user == nil {
+			return nil, errors.New("webauthn: user not found")
+		}
+
+		return &webAuthnUser{user: user, handle: webAuthnCredential.Handle, credentials: model.WebAuthnCredentials{webAuthnCredential}}, nil
+	}
+
+	credential, err := relyingParty.ValidateDiscoverableLogin(findUser, *sessionData, parsedResponse)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if credential.Authenticator.CloneWarning {
+		if err := store.DisableWebAuthnCredential(webAuthnCredential.ID); err != nil {
+			return nil, nil, err
+		}
+
+		return nil, nil, fmt.Errorf("webauthn: the signature counter of the passkey %q of username=%s went backward, the authenticator may be cloned", webAuthnCredential.Name, user.Username)
+	}
+
+	webAuthnCredential.Credential.Authenticator = credential.Authenticator
+	if err := store.WebAuthnCredentialUsed(webAuthnCredential); err != nil {
+		return nil, nil, err
+	}
+
+	return user, webAuthnCredential, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+
+	"miniflux.app/config"
+	"miniflux.app/crypto"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/json"
+	"miniflux.app/locale"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+	"miniflux.app/ui/session"
+)
+
+func (h *handler) beginWebAuthnRegistration(w http.ResponseWriter, r *http.Request) {
+	if !config.Opts.WebAuthn() {
+		json.NotFound(w, r)
+		return
+	}
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	credentials, err := h.store.WebAuthnCredentials(user.ID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	relyingParty, err := newWebAuthn()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	// The authenticators already registered are excluded to avoid duplicates.
+	exclusions := make([]protocol.CredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		exclusions = append(exclusions, credential.Credential.Descriptor())
+	}
+
+	// A resident key is required, the passkey login doesn't ask for the username.
+	// The user verification is required, the passkey also replaces the second factor.
+	options, sessionData, err := relyingParty.BeginRegistration(
+		&webAuthnUser{user: user, handle: crypto.GenerateRandomBytes(webAuthnHandleSize), credentials: credentials},
+		webauthn.WithExclusions(exclusions),
+		webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
+			RequireResidentKey: protocol.ResidentKeyRequired(),
+			ResidentKey:        protocol.ResidentKeyRequirementRequired,
+			UserVerification:   protocol.VerificationRequired,
+		}),
+	)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if err := saveWebAuthnSession(session.New(h.store, request.SessionID(r)), sessionData); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, options)
+}
+
+func (h *handler) finishWebAuthnRegistration(w http.ResponseWriter, r *http.Request) {
+	if !config.Opts.WebAuthn() {
+		json.NotFound(w, r)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	sessionData, err := webAuthnSession(r, sess)
+	if err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	relyingParty, err := newWebAuthn()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	parsedResponse, err := protocol.ParseCredentialCreationResponse(r)
+	if err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	name := strings.TrimSpace(request.QueryStringParam(r, "name", ""))
+	if _, err := createWebAuthnCredential(h.store, relyingParty, user, sessionData, parsedResponse, name); err != nil {
+		logger.Error("[UI:WebAuthn] Registration failed for username=%s: %v", user.Username, err)
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	logger.Info("[UI:WebAuthn] username=%s registered a new passkey", user.Username)
+	sess.NewFlashMessage(locale.NewPrinter(request.UserLanguage(r)).Printf("alert.passkey_registered"))
+	json.NoContent(w, r)
+}
+
+// createWebAuthnCredential verifies the attestation of the authenticator and stores the new credential.
+func createWebAuthnCredential(store *storage.Storage, relyingParty *webauthn.WebAuthn, user *model.User, sessionData *webauthn.SessionData, parsedResponse *protocol.ParsedCredentialCreationData, name string) (*model.WebAuthnCredential, error) {
+	credential, err := relyingParty.CreateCredential(&webAuthnUser{user: user, handle: sessionData.UserID}, *sessionData, parsedResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	webAuthnCredential := &model.WebAuthnCredential{
+		UserID:     user.ID,
+		Handle:     sessionData.UserID,
+		Name:       name,
+		Credential: *credential,
+	}
+
+	if err := store.CreateWebAuthnCredential(webAuthnCredential); err != nil {
+		return nil, err
+	}
+
+	return webAuthnCredential, nil
+}
@@ -0,0 +1,340 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	"github.com/go-webauthn/webauthn/webauthn"
+
+	"miniflux.app/config"
+	"miniflux.app/crypto"
+	"miniflux.app/database"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+)
+
+// testAuthenticator is a software authenticator for the origin http://localhost.
+type testAuthenticator struct {
+	t            *testing.T
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	handle       []byte
+	counter      uint32
+}
+
+func newTestAuthenticator(t *testing.T) *testAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testAuthenticator{t: t, key: key, credentialID: crypto.GenerateRandomBytes(16)}
+}
+
+// register returns the response of the authenticator to a registration ceremony, with the "none" attestation.
+func (a *testAuthenticator) register(sessionData *webauthn.SessionData) *protocol.ParsedCredentialCreationData {
+	a.handle = sessionData.UserID
+
+	publicKey, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{
+			KeyType:   int64(webauthncose.EllipticKey),
+			Algorithm: int64(webauthncose.AlgES256),
+		},
+		Curve:  1,
+		XCoord: a.key.X.FillBytes(make([]byte, 32)),
+		YCoord: a.key.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		a.t.Fatal(err)
+	}
+
+	// The flags are user present, user verified and attested credential data included.
+	authData := a.authenticatorData(0x45)
+	authData = append(authData, make([]byte, 16)...)
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.credentialID)))
+	authData = append(authData, a.credentialID...)
+	authData = append(authData, publicKey...)
+
+	attestationObject, err := webauthncbor.Marshal(map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": authData,
+	})
+	if err != nil {
+		a.t.Fatal(err)
+	}
+
+	body := a.encode(map[string]interface{}{
+		"id":    base64.RawURLEncoding.EncodeToString(a.credentialID),
+		"rawId": base64.RawURLEncoding.EncodeToString(a.credentialID),
+		"type":  "public-key",
+		"response": map[string]string{
+			"attestationObject": base64.RawURLEncoding.EncodeToString(attestationObject),
+			"clientDataJSON":    base64.RawURLEncoding.EncodeToString(a.clientData("webauthn.create", sessionData.Challenge)),
+		},
+	})
+
+	parsedResponse, err := protocol.ParseCredentialCreationResponseBody(bytes.NewReader(body))
+	if err != nil {
+		a.t.Fatal(err)
+	}
+
+	return parsedResponse
+}
+
+// login returns the response of the authenticator to a login ceremony, signed with the given counter.
+func (a *testAuthenticator) login(sessionData *webauthn.SessionData, counter uint32) *protocol.ParsedCredentialAssertionData {
+	a.counter = counter
+
+	// The flags are user present and user verified.
+	authData := a.authenticatorData(0x05)
+	clientData := a.clientData("webauthn.get", sessionData.Challenge)
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		a.t.Fatal(err)
+	}
+
+	body := a.encode(map[string]interface{}{
+		"id":    base64.RawURLEncoding.EncodeToString(a.credentialID),
+		"rawId": base64.RawURLEncoding.EncodeToString(a.credentialID),
+		"type":  "public-key",
+		"response": map[string]string{
+			"authenticatorData": base64.RawURLEncoding.EncodeToString(authData),
+			"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientData),
+			"signature":         base64.RawURLEncoding.EncodeToString(signature),
+			"userHandle":        base64.RawURLEncoding.EncodeToString(a.handle),
+		},
+	})
+
+	parsedResponse, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(body))
+	if err != nil {
+		a.t.Fatal(err)
+	}
+
+	return parsedResponse
+}
+
+func (a *testAuthenticator) authenticatorData(flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte("localhost"))
+	authData := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(authData, a.counter)
+}
+
+func (a *testAuthenticator) clientData(ceremony, challenge string) []byte {
+	return a.encode(map[string]string{
+		"type":      ceremony,
+		"challenge": challenge,
+		"origin":    "http://localhost",
+	})
+}
+
+func (a *testAuthenticator) encode(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		a.t.Fatal(err)
+	}
+
+	return data
+}
+
+func newTestWebAuthn(t *testing.T) (*storage.Storage, *webauthn.WebAuthn) {
+	config.Opts = config.NewOptions()
+
+	db, err := database.NewConnectionPool("sqlite://"+filepath.Join(t.TempDir(), "miniflux.db"), 1, 1, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := database.Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+
+	relyingParty, err := newWebAuthn()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return storage.NewStorage(db), relyingParty
+}
+
+func registerTestAuthenticator(t *testing.T, store *storage.Storage, relyingParty *webauthn.WebAuthn, user *model.User, authenticator *testAuthenticator) *model.WebAuthnCredential {
+	_, sessionData, err := relyingParty.BeginRegistration(
+		&webAuthnUser{user: user, handle: crypto.GenerateRandomBytes(webAuthnHandleSize)},
+		webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{UserVerification: protocol.VerificationRequired}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	credential, err := createWebAuthnCredential(store, relyingParty, user, sessionData, authenticator.register(sessionData), "Laptop")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return credential
+}
+
+func loginWithTestAuthenticator(t *testing.T, store *storage.Storage, relyingParty *webauthn.WebAuthn, authenticator *testAuthenticator, counter uint32) (*model.User, error) {
+	_, sessionData, err := relyingParty.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user, _, err := validateWebAuthnLogin(store, relyingParty, sessionData, authenticator.login(sessionData, counter))
+	return user, err
+}
+
+func createWebAuthnTestUser(t *testing.T, store *storage.Storage, username string) *model.User {
+	user, err := store.CreateUser(&model.UserCreationRequest{Username: username, Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return user
+}
+
+func TestWebAuthnRegistration(t *testing.T) {
+	store, relyingParty := newTestWebAuthn(t)
+	user := createWebAuthnTestUser(t, store, "alice")
+	authenticator := newTestAuthenticator(t)
+
+	credential := registerTestAuthenticator(t, store, relyingParty, user, authenticator)
+	if credential.Name != "Laptop" || !bytes.Equal(credential.Credential.ID, authenticator.credentialID) {
+		t.Fatalf(`Unexpected credential, got %v`, credential)
+	}
+
+	credentials, err := store.WebAuthnCredentials(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(credentials) != 1 || !bytes.Equal(credentials[0].Handle, authenticator.handle) {
+		t.Fatalf(`The credential should be stored with its handle, got %d credentials`, len(credentials))
+	}
+}
+
+func TestWebAuthnRegistrationWithAnotherChallenge(t *testing.T) {
+	store, relyingParty := newTestWebAuthn(t)
+	user := createWebAuthnTestUser(t, store, "alice")
+
+	_, sessionData, _ := relyingParty.BeginRegistration(&webAuthnUser{user: user, handle: crypto.GenerateRandomBytes(webAuthnHandleSize)})
+	_, otherSessionData, _ := relyingParty.BeginRegistration(&webAuthnUser{user: user, handle: sessionData.UserID})
+
+	parsedResponse := newTestAuthenticator(t).register(otherSessionData)
+	if _, err := createWebAuthnCredential(store, relyingParty, user, sessionData, parsedResponse, ""); err == nil {
+		t.Fatal(`The response to another ceremony should be rejected`)
+	}
+
+	if credentials, _ := store.WebAuthnCredentials(user.ID); len(credentials) != 0 {
+		t.Fatalf(`No credential should be stored, got %d`, len(credentials))
+	}
+}
+
+func TestWebAuthnLogin(t *testing.T) {
+	store, relyingParty := newTestWebAuthn(t)
+	user := createWebAuthnTestUser(t, store, "alice")
+	createWebAuthnTestUser(t, store, "bob")
+	authenticator := newTestAuthenticator(t)
+	registerTestAuthenticator(t, store, relyingParty, user, authenticator)
+
+	for _, counter := range []uint32{1, 2, 10} {
+		loggedUser, err := loginWithTestAuthenticator(t, store, relyingParty, authenticator, counter)
+		if err != nil {
+			t.Fatalf(`The login with the counter %d should succeed, got %v`, counter, err)
+		}
+
+		if loggedUser.ID != user.ID {
+			t.Fatalf(`The user of the credential should be logged in, got %q`, loggedUser.Username)
+		}
+	}
+
+	credentials, _ := store.WebAuthnCredentials(user.ID)
+	if credentials[0].Credential.Authenticator.SignCount != 10 || credentials[0].LastSeenAt == nil {
+		t.Fatalf(`The signature counter and the last use should be saved, got %d`, credentials[0].Credential.Authenticator.SignCount)
+	}
+}
+
+func TestWebAuthnLoginWithoutCounter(t *testing.T) {
+	store, relyingParty := newTestWebAuthn(t)
+	user := createWebAuthnTestUser(t, store, "alice")
+	authenticator := newTestAuthenticator(t)
+	registerTestAuthenticator(t, store, relyingParty, user, authenticator)
+
+	// Some authenticators don't implement the signature counter and always send zero.
+	for i := 0; i < 2; i++ {
+		if _, err := loginWithTestAuthenticator(t, store, relyingParty, authenticator, 0); err != nil {
+			t.Fatalf(`The login without counter should succeed, got %v`, err)
+		}
+	}
+}
+
+func TestWebAuthnLoginWithCounterGoingBackward(t *testing.T) {
+	store, relyingParty := newTestWebAuthn(t)
+	user := createWebAuthnTestUser(t, store, "alice")
+	authenticator := newTestAuthenticator(t)
+	registerTestAuthenticator(t, store, relyingParty, user, authenticator)
+
+	if _, err := loginWithTestAuthenticator(t, store, relyingParty, authenticator, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loginWithTestAuthenticator(t, store, relyingParty, authenticator, 5); err == nil {
+		t.Fatal(`The login should be rejected when the counter doesn't increase`)
+	}
+
+	credentials, _ := store.WebAuthnCredentials(user.ID)
+	if !credentials[0].Credential.Authenticator.CloneWarning {
+		t.Fatal(`The credential should be disabled`)
+	}
+
+	if _, err := loginWithTestAuthenticator(t, store, relyingParty, authenticator, 6); err == nil {
+		t.Fatal(`The disabled credential should be rejected`)
+	}
+}
+
+func TestWebAuthnLoginWithUnknownCredential(t *testing.T) {
+	store, relyingParty := newTestWebAuthn(t)
+	user := createWebAuthnTestUser(t, store, "alice")
+	registerTestAuthenticator(t, store, relyingParty, user, newTestAuthenticator(t))
+
+	authenticator := newTestAuthenticator(t)
+	authenticator.handle = crypto.GenerateRandomBytes(webAuthnHandleSize)
+	if _, err := loginWithTestAuthenticator(t, store, relyingParty, authenticator, 1); err == nil {
+		t.Fatal(`An unknown credential should be rejected`)
+	}
+}
+
+func TestWebAuthnLoginWithAnotherKey(t *testing.T) {
+	store, relyingParty := newTestWebAuthn(t)
+	user := createWebAuthnTestUser(t, store, "alice")
+	authenticator := newTestAuthenticator(t)
+	registerTestAuthenticator(t, store, relyingParty, user, authenticator)
+
+	// Same credential ID and handle, but the signature is made with another private key.
+	impostor := newTestAuthenticator(t)
+	impostor.credentialID = authenticator.credentialID
+	impostor.handle = authenticator.handle
+	if _, err := loginWithTestAuthenticator(t, store, relyingParty, impostor, 1); err == nil {
+		t.Fatal(`A signature made with another key should be rejected`)
+	}
+}
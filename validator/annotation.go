@@ -0,0 +1,24 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package validator // import "miniflux.app/validator"
+
+import (
+	"strings"
+
+	"miniflux.app/model"
+)
+
+// ValidateAnnotationRequest validates annotation creation and modification.
+func ValidateAnnotationRequest(request *model.AnnotationRequest) *ValidationError {
+	if strings.TrimSpace(request.Quote) == "" && strings.TrimSpace(request.Note) == "" {
+		return NewValidationError("error.annotation_required")
+	}
+
+	if request.StartOffset < 0 || request.EndOffset < request.StartOffset {
+		return NewValidationError("error.invalid_annotation_range")
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package validator // import "miniflux.app/validator"
+
+import (
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestValidateAnnotationRequest(t *testing.T) {
+	if err := ValidateAnnotationRequest(&model.AnnotationRequest{Quote: "quoted text", StartOffset: 10, EndOffset: 21}); err != nil {
+		t.Error(`A highlight should be accepted`)
+	}
+
+	if err := ValidateAnnotationRequest(&model.AnnotationRequest{Note: "Read this again"}); err != nil {
+		t.Error(`A note without quote should be accepted`)
+	}
+
+	if err := ValidateAnnotationRequest(&model.AnnotationRequest{Quote: " ", Note: "\n"}); err == nil {
+		t.Error(`An empty annotation is not valid`)
+	}
+
+	if err := ValidateAnnotationRequest(&model.AnnotationRequest{Quote: "quoted text", StartOffset: 21, EndOffset: 10}); err == nil {
+		t.Error(`An inverted range is not valid`)
+	}
+
+	if err := ValidateAnnotationRequest(&model.AnnotationRequest{Quote: "quoted text", StartOffset: -1}); err == nil {
+		t.Error(`A negative offset is not valid`)
+	}
+}
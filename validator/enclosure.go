@@ -0,0 +1,24 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package validator // import "miniflux.app/validator"
+
+import (
+	"fmt"
+
+	"miniflux.app/model"
+)
+
+// ValidateEnclosureUpdateRequest validates the playback state of an attachment.
+func ValidateEnclosureUpdateRequest(request *model.EnclosureUpdateRequest) error {
+	if request.MediaProgression == nil && request.Played == nil {
+		return fmt.Errorf(`The media progression or the played state is required`)
+	}
+
+	if request.MediaProgression != nil && *request.MediaProgression < 0 {
+		return fmt.Errorf(`The media progression should be >= 0`)
+	}
+
+	return nil
+}
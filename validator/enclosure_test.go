@@ -0,0 +1,28 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package validator // import "miniflux.app/validator"
+
+import (
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestValidateEnclosureUpdateRequest(t *testing.T) {
+	progression := 120
+	played := true
+	if err := ValidateEnclosureUpdateRequest(&model.EnclosureUpdateRequest{MediaProgression: &progression, Played: &played}); err != nil {
+		t.Error(`A valid request should not be rejected`)
+	}
+
+	if err := ValidateEnclosureUpdateRequest(&model.EnclosureUpdateRequest{}); err == nil {
+		t.Error(`An empty request is not valid`)
+	}
+
+	negativeProgression := -1
+	if err := ValidateEnclosureUpdateRequest(&model.EnclosureUpdateRequest{MediaProgression: &negativeProgression}); err == nil {
+		t.Error(`A negative media progression is not valid`)
+	}
+}
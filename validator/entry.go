@@ -6,8 +6,10 @@ package validator // import "miniflux.app/validator"
 
 import (
 	"fmt"
+	"strings"
 
 	"miniflux.app/model"
+	"miniflux.app/storage"
 )
 
 // ValidateEntriesStatusUpdateRequest validates a status update for a list of entries.
@@ -38,3 +40,27 @@ func ValidateEntryOrder(order string) error {
 
 	return fmt.Errorf(`Invalid entry order, valid order values are: "id", "status", "changed_at", "published_at", "created_at", "category_title", "category_id", "title", "author"`)
 }
+
+// ValidateEntryTagsRequest makes sure the list of tags is valid.
+func ValidateEntryTagsRequest(request *model.EntryTagsRequest) error {
+	for _, tag := range request.Tags {
+		if strings.TrimSpace(tag) == "" {
+			return fmt.Errorf(`Tags cannot be empty`)
+		}
+
+		if strings.Contains(tag, ",") {
+			return fmt.Errorf(`Tags cannot contain a comma`)
+		}
+	}
+
+	return nil
+}
+
+// ValidateSearchQuery makes sure the search query can be parsed.
+func ValidateSearchQuery(query string) error {
+	if _, err := storage.ParseSearchQuery(query); err != nil {
+		return fmt.Errorf(`Invalid search query: %v`, err)
+	}
+
+	return nil
+}
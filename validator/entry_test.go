@@ -58,3 +58,21 @@ func TestValidateEntryOrder(t *testing.T) {
 		t.Error(`An invalid order should generate a error`)
 	}
 }
+
+func TestValidateEntryTagsRequest(t *testing.T) {
+	if err := ValidateEntryTagsRequest(&model.EntryTagsRequest{Tags: []string{"golang", "to read"}}); err != nil {
+		t.Error(`A valid list of tags should not generate any error`)
+	}
+
+	if err := ValidateEntryTagsRequest(&model.EntryTagsRequest{}); err != nil {
+		t.Error(`An empty list of tags should be accepted`)
+	}
+
+	if err := ValidateEntryTagsRequest(&model.EntryTagsRequest{Tags: []string{"golang", " "}}); err == nil {
+		t.Error(`An empty tag should generate a error`)
+	}
+
+	if err := ValidateEntryTagsRequest(&model.EntryTagsRequest{Tags: []string{"a,b"}}); err == nil {
+		t.Error(`A tag with a comma should generate a error`)
+	}
+}
@@ -35,6 +35,12 @@ func ValidateFeedCreation(store *storage.Storage, userID int64, request *model.F
 		return NewValidationError("error.feed_invalid_keeplist_rule")
 	}
 
+	for _, selector := range []string{request.ItemSelector, request.TitleSelector, request.LinkSelector, request.DateSelector, request.ContentSelector} {
+		if !IsValidSelector(selector) {
+			return NewValidationError("error.feed_invalid_selector")
+		}
+	}
+
 	return nil
 }
 
@@ -84,5 +90,11 @@ func ValidateFeedModification(store *storage.Storage, userID int64, request *mod
 		}
 	}
 
+	for _, selector := range []*string{request.ItemSelector, request.TitleSelector, request.LinkSelector, request.DateSelector, request.ContentSelector} {
+		if selector != nil && !IsValidSelector(*selector) {
+			return NewValidationError("error.feed_invalid_selector")
+		}
+	}
+
 	return nil
 }
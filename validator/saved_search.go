@@ -0,0 +1,66 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package validator // import "miniflux.app/validator"
+
+import (
+	"miniflux.app/model"
+	"miniflux.app/storage"
+)
+
+// ValidateSavedSearchCreation validates saved search creation.
+func ValidateSavedSearchCreation(store *storage.Storage, userID int64, request *model.SavedSearchRequest) *ValidationError {
+	if err := validateSavedSearchRequest(store, userID, request); err != nil {
+		return err
+	}
+
+	if store.SavedSearchTitleExists(userID, request.Title) {
+		return NewValidationError("error.saved_search_already_exists")
+	}
+
+	return nil
+}
+
+// ValidateSavedSearchModification validates saved search modification.
+func ValidateSavedSearchModification(store *storage.Storage, userID, searchID int64, request *model.SavedSearchRequest) *ValidationError {
+	if err := validateSavedSearchRequest(store, userID, request); err != nil {
+		return err
+	}
+
+	if store.AnotherSavedSearchExists(userID, searchID, request.Title) {
+		return NewValidationError("error.saved_search_already_exists")
+	}
+
+	return nil
+}
+
+func validateSavedSearchRequest(store *storage.Storage, userID int64, request *model.SavedSearchRequest) *ValidationError {
+	if request.Title == "" {
+		return NewValidationError("error.title_required")
+	}
+
+	if request.Query == "" {
+		return NewValidationError("error.search_query_required")
+	}
+
+	if _, err := storage.ParseSearchQuery(request.Query); err != nil {
+		return NewValidationError("error.invalid_search_query")
+	}
+
+	if request.CategoryID != 0 && !store.CategoryIDExists(userID, request.CategoryID) {
+		return NewValidationError("error.feed_category_not_found")
+	}
+
+	if request.FeedID != 0 && !store.FeedExists(userID, request.FeedID) {
+		return NewValidationError("error.saved_search_feed_not_found")
+	}
+
+	switch request.Status {
+	case "", model.EntryStatusUnread, model.EntryStatusRead:
+	default:
+		return NewValidationError("error.invalid_saved_search_status")
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ package validator // import "miniflux.app/validator"
 import (
 	"miniflux.app/locale"
 	"miniflux.app/model"
+	"miniflux.app/reader/filter"
 	"miniflux.app/storage"
 )
 
@@ -97,6 +98,19 @@ func ValidateUserModification(store *storage.Storage, userID int64, changes *mod
 		}
 	}
 
+	if changes.FilterRules != nil {
+		if err := validateFilterRules(*changes.FilterRules); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateFilterRules(rules string) *ValidationError {
+	if _, err := filter.Parse(rules); err != nil {
+		return NewValidationError("error.invalid_filter_rules")
+	}
 	return nil
 }
 
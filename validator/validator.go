@@ -11,6 +11,8 @@ import (
 	"regexp"
 
 	"miniflux.app/locale"
+
+	"github.com/andybalholm/cascadia"
 )
 
 // ValidationError represents a validation error.
@@ -60,6 +62,15 @@ func IsValidRegex(expr string) bool {
 	return err == nil
 }
 
+// IsValidSelector verifies if the CSS selector can be compiled, an empty selector is valid.
+func IsValidSelector(selector string) bool {
+	if selector == "" {
+		return true
+	}
+	_, err := cascadia.Compile(selector)
+	return err == nil
+}
+
 // IsValidURL verifies if the provided value is a valid absolute URL.
 func IsValidURL(absoluteURL string) bool {
 	_, err := url.ParseRequestURI(absoluteURL)
@@ -60,3 +60,19 @@ func TestIsValidRegex(t *testing.T) {
 		}
 	}
 }
+
+func TestIsValidSelector(t *testing.T) {
+	scenarios := map[string]bool{
+		"":                    true,
+		"article.post > h2 a": true,
+		"time[datetime]":      true,
+		"div[":                false,
+	}
+
+	for selector, expected := range scenarios {
+		result := IsValidSelector(selector)
+		if result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, selector, result, expected)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+/*
+Package websub implements a WebSub (PubSubHubbub) subscriber.
+
+Feeds advertising a hub are subscribed to it, the hub then pushes new content to the callback endpoint.
+*/
+package websub // import "miniflux.app/websub"
@@ -0,0 +1,202 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websub // import "miniflux.app/websub"
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response"
+	"miniflux.app/http/response/html"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/reader/media"
+	"miniflux.app/reader/parser"
+	"miniflux.app/reader/processor"
+	"miniflux.app/storage"
+
+	"github.com/gorilla/mux"
+)
+
+// Serve declares the callback endpoint used by the hubs.
+func Serve(router *mux.Router, store *storage.Storage) {
+	handler := &handler{store}
+
+	sr := router.PathPrefix("/websub").Subrouter()
+	sr.HandleFunc("/callback/{feedID:[0-9]+}/{token}", handler.verifyIntent).Name("websubVerify").Methods(http.MethodGet)
+	sr.HandleFunc("/callback/{feedID:[0-9]+}/{token}", handler.receiveContent).Name("websubCallback").Methods(http.MethodPost)
+}
+
+type handler struct {
+	store *storage.Storage
+}
+
+// subscription returns the subscription of the callback URL, or nil if the token is not the one of the subscription.
+func (h *handler) subscription(r *http.Request) (*model.WebSubSubscription, error) {
+	subscription, err := h.store.WebSubSubscription(request.RouteInt64Param(r, "feedID"))
+	if err != nil || subscription == nil {
+		return nil, err
+	}
+
+	token := request.RouteStringParam(r, "token")
+	if subscription.CallbackToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(subscription.CallbackToken)) != 1 {
+		return nil, nil
+	}
+
+	return subscription, nil
+}
+
+// verifyIntent confirms to the hub that the subscription or the unsubscription has been requested by Miniflux.
+func (h *handler) verifyIntent(w http.ResponseWriter, r *http.Request) {
+	feedID := request.RouteInt64Param(r, "feedID")
+	mode := request.QueryStringParam(r, "hub.mode", "")
+	topic := request.QueryStringParam(r, "hub.topic", "")
+	challenge := request.QueryStringParam(r, "hub.challenge", "")
+
+	subscription, err := h.subscription(r)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if subscription == nil || subscription.TopicURL != topic {
+		html.NotFound(w, r)
+		return
+	}
+
+	switch mode {
+	case "subscribe":
+		if subscription.State != model.WebSubStatePending && subscription.State != model.WebSubStateSubscribed {
+			html.NotFound(w, r)
+			return
+		}
+
+		lease := request.QueryIntParam(r, "hub.lease_seconds", leaseSeconds)
+		if lease <= 0 {
+			lease = leaseSeconds
+		}
+
+		leaseExpiresAt := time.Now().Add(time.Duration(lease) * time.Second)
+		if err := h.store.UpdateWebSubSubscriptionState(feedID, model.WebSubStateSubscribed, &leaseExpiresAt); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		logger.Debug("[WebSub] Subscription of feed #%d confirmed by hub %q until %v", feedID, subscription.HubURL, leaseExpiresAt)
+	case "unsubscribe":
+		if subscription.State != model.WebSubStateUnsubscribed {
+			html.NotFound(w, r)
+			return
+		}
+
+		if err := h.store.RemoveWebSubSubscription(feedID); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		logger.Debug("[WebSub] Unsubscription of feed #%d confirmed by hub %q", feedID, subscription.HubURL)
+	case "denied":
+		// Hubs can only deny a subscription not confirmed yet.
+		if subscription.State != model.WebSubStatePending {
+			html.NotFound(w, r)
+			return
+		}
+
+		if err := h.store.UpdateWebSubSubscriptionState(feedID, model.WebSubStateDenied, nil); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		logger.Info("[WebSub] Subscription of feed #%d denied by hub %q: %s", feedID, subscription.HubURL, request.QueryStringParam(r, "hub.reason", ""))
+	default:
+		html.BadRequest(w, r, errors.New("websub: invalid hub.mode"))
+		return
+	}
+
+	builder := response.New(w, r)
+	builder.WithHeader("Content-Type", "text/plain; charset=utf-8")
+	builder.WithBody(challenge)
+	builder.Write()
+}
+
+// receiveContent handles the content distributed by the hub.
+//
+// Content with a missing or invalid signature is acknowledged but ignored, as required by the specification.
+func (h *handler) receiveContent(w http.ResponseWriter, r *http.Request) {
+	feedID := request.RouteInt64Param(r, "feedID")
+
+	subscription, err := h.subscription(r)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if subscription == nil || subscription.State == model.WebSubStateUnsubscribed {
+		html.NotFound(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, config.Opts.HTTPClientMaxBodySize()))
+	if err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	if VerifySignature(subscription.Secret, r.Header.Get("X-Hub-Signature"), body) {
+		go refreshFeedEntries(h.store, subscription.UserID, feedID, string(body))
+	} else {
+		logger.Error("[WebSub] Invalid signature for feed #%d, the content is ignored", feedID)
+	}
+
+	builder := response.New(w, r)
+	builder.WithStatus(http.StatusAccepted)
+	builder.Write()
+}
+
+// refreshFeedEntries stores the entries pushed by the hub the same way as the entries of a regular refresh.
+func refreshFeedEntries(store *storage.Storage, userID, feedID int64, content string) {
+	user, err := store.UserByID(userID)
+	if err != nil {
+		logger.Error("[WebSub] %v", err)
+		return
+	}
+
+	feed, err := store.FeedByID(userID, feedID)
+	if err != nil {
+		logger.Error("[WebSub] %v", err)
+		return
+	}
+
+	if user == nil || feed == nil || feed.Disabled {
+		return
+	}
+
+	pushedFeed, parseErr := parser.ParseFeed(feed.FeedURL, content)
+	if parseErr != nil {
+		logger.Error("[WebSub] Unable to parse content pushed for feed #%d: %v", feedID, parseErr)
+		return
+	}
+
+	feed.Entries = pushedFeed.Entries
+	processor.ProcessFeedEntries(store, feed, user)
+
+	// We don't update existing entries when the crawler is enabled (we crawl only inexisting entries).
+	newEntries, _, err := store.RefreshFeedEntries(userID, feedID, feed.Entries, !feed.Crawler)
+	if err != nil {
+		logger.Error("[WebSub] %v", err)
+		return
+	}
+
+	logger.Debug("[WebSub] %d entries pushed for feed #%d, %d new", len(feed.Entries), feedID, len(newEntries))
+
+	processor.PushEntries(store, feed, newEntries)
+
+	media.CacheEntries(store, feed, feed.Entries)
+}
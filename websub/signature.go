@@ -0,0 +1,44 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websub // import "miniflux.app/websub"
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"strings"
+)
+
+var signatureAlgorithms = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// VerifySignature checks the "X-Hub-Signature" header sent by the hub, for example "sha256=4a5b...".
+func VerifySignature(secret, signature string, body []byte) bool {
+	algorithm, value, found := strings.Cut(signature, "=")
+	if !found {
+		return false
+	}
+
+	newHash, found := signatureAlgorithms[strings.ToLower(algorithm)]
+	if !found {
+		return false
+	}
+
+	expected, err := hex.DecodeString(value)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,41 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websub // import "miniflux.app/websub"
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"testing"
+)
+
+func sign(newHash func() hash.Hash, secret string, body []byte) string {
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`<feed xmlns="http://www.w3.org/2005/Atom"></feed>`)
+
+	scenarios := map[string]bool{
+		"sha1=" + sign(sha1.New, "secret", body):     true,
+		"sha256=" + sign(sha256.New, "secret", body): true,
+		"SHA256=" + sign(sha256.New, "secret", body): true,
+		"sha256=" + sign(sha256.New, "other", body):  false,
+		"md5=" + sign(sha256.New, "secret", body):    false,
+		"sha256=invalid": false,
+		"sha256":         false,
+		"":               false,
+	}
+
+	for signature, expected := range scenarios {
+		if result := VerifySignature("secret", signature, body); result != expected {
+			t.Errorf(`Unexpected result for signature %q, got %v instead of %v`, signature, result, expected)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websub // import "miniflux.app/websub"
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/http/client"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+)
+
+const (
+	// leaseSeconds is the subscription duration requested to the hub, hubs are free to grant a different one.
+	leaseSeconds = 10 * 24 * 60 * 60
+
+	// renewalDelay is the time before the end of the lease when the subscription is renewed.
+	renewalDelay = 24 * time.Hour
+
+	// retryDelay is the time to wait before sending a new request for a subscription that is not confirmed.
+	retryDelay = 24 * time.Hour
+)
+
+// CallbackURL returns the URL where the hub sends verification requests and new content for the given subscription.
+//
+// The URL contains a random token, only the hub knows the callback of the subscription.
+func CallbackURL(subscription *model.WebSubSubscription) string {
+	return fmt.Sprintf("%s/websub/callback/%d/%s", config.Opts.BaseURL(), subscription.FeedID, subscription.CallbackToken)
+}
+
+// Subscribe makes sure the feed is subscribed to the hub it advertises.
+//
+// Feeds that stopped advertising a hub are unsubscribed from the previous one.
+func Subscribe(store *storage.Storage, feedID int64, hubURL, topicURL string) error {
+	subscription, err := store.WebSubSubscription(feedID)
+	if err != nil {
+		return err
+	}
+
+	if hubURL == "" {
+		if subscription == nil || subscription.State == model.WebSubStateUnsubscribed {
+			return nil
+		}
+
+		return unsubscribe(store, subscription)
+	}
+
+	if subscription != nil && subscription.HubURL == hubURL && subscription.TopicURL == topicURL {
+		if subscription.IsActive() {
+			return nil
+		}
+
+		if subscription.State != model.WebSubStateSubscribed && time.Since(subscription.CreatedAt) < retryDelay {
+			return nil
+		}
+	}
+
+	// The subscription is saved before contacting the hub because hubs can verify the intent synchronously.
+	subscription = model.NewWebSubSubscription(feedID, hubURL, topicURL)
+	if err := store.CreateWebSubSubscription(subscription); err != nil {
+		return err
+	}
+
+	logger.Debug("[WebSub] Subscribing feed #%d to hub %q (topic=%q)", feedID, hubURL, topicURL)
+	return sendHubRequest("subscribe", subscription)
+}
+
+// RenewSubscriptions renews the subscriptions with a lease about to expire.
+func RenewSubscriptions(store *storage.Storage) {
+	subscriptions, err := store.WebSubSubscriptionsToRenew(time.Now().Add(renewalDelay))
+	if err != nil {
+		logger.Error("[WebSub] %v", err)
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		logger.Debug("[WebSub] Renewing subscription of feed #%d to hub %q", subscription.FeedID, subscription.HubURL)
+		if err := sendHubRequest("subscribe", subscription); err != nil {
+			logger.Error("[WebSub] Unable to renew subscription of feed #%d: %v", subscription.FeedID, err)
+		}
+	}
+}
+
+func unsubscribe(store *storage.Storage, subscription *model.WebSubSubscription) error {
+	if err := store.UpdateWebSubSubscriptionState(subscription.FeedID, model.WebSubStateUnsubscribed, nil); err != nil {
+		return err
+	}
+
+	logger.Debug("[WebSub] Unsubscribing feed #%d from hub %q", subscription.FeedID, subscription.HubURL)
+	return sendHubRequest("unsubscribe", subscription)
+}
+
+func sendHubRequest(mode string, subscription *model.WebSubSubscription) error {
+	values := url.Values{}
+	values.Set("hub.callback", CallbackURL(subscription))
+	values.Set("hub.mode", mode)
+	values.Set("hub.topic", subscription.TopicURL)
+
+	if mode == "subscribe" {
+		values.Set("hub.secret", subscription.Secret)
+		values.Set("hub.lease_seconds", strconv.Itoa(leaseSeconds))
+	}
+
+	response, err := client.NewClientWithConfig(subscription.HubURL, config.Opts).PostForm(values)
+	if err != nil {
+		return fmt.Errorf("websub: unable to send %s request to hub %q: %v", mode, subscription.HubURL, err)
+	}
+
+	if response.StatusCode != http.StatusAccepted && response.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("websub: hub %q refused the %s request, status=%d", subscription.HubURL, mode, response.StatusCode)
+	}
+
+	return nil
+}
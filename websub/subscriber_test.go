@@ -0,0 +1,77 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websub // import "miniflux.app/websub"
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"miniflux.app/config"
+	"miniflux.app/model"
+)
+
+func parseConfig(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("BASE_URL", "https://miniflux.example.org/reader")
+
+	var err error
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+}
+
+func TestCallbackURL(t *testing.T) {
+	parseConfig(t)
+
+	expected := "https://miniflux.example.org/reader/websub/callback/42/token"
+	if result := CallbackURL(&model.WebSubSubscription{FeedID: 42, CallbackToken: "token"}); result != expected {
+		t.Fatalf(`Unexpected callback URL, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestSendSubscribeRequest(t *testing.T) {
+	parseConfig(t)
+
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expected := map[string]string{
+			"hub.mode":          "subscribe",
+			"hub.topic":         "https://example.org/feed",
+			"hub.callback":      "https://miniflux.example.org/reader/websub/callback/42/token",
+			"hub.secret":        "secret",
+			"hub.lease_seconds": "864000",
+		}
+
+		for key, value := range expected {
+			if result := r.PostFormValue(key); result != value {
+				t.Errorf(`Unexpected %s value, got %q instead of %q`, key, result, value)
+			}
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer hub.Close()
+
+	subscription := &model.WebSubSubscription{FeedID: 42, HubURL: hub.URL, TopicURL: "https://example.org/feed", Secret: "secret", CallbackToken: "token"}
+	if err := sendHubRequest("subscribe", subscription); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSendRequestRefusedByHub(t *testing.T) {
+	parseConfig(t)
+
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer hub.Close()
+
+	subscription := &model.WebSubSubscription{FeedID: 42, HubURL: hub.URL, TopicURL: "https://example.org/feed", Secret: "secret"}
+	if err := sendHubRequest("unsubscribe", subscription); err == nil {
+		t.Fatal(`An error should be returned when the hub refuses the request`)
+	}
+}